@@ -0,0 +1,60 @@
+package client
+
+import (
+	"bet_service/domain"
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	eventpb "muchway/event_service/proto"
+)
+
+// EventClient is a client for the event service
+type EventClient struct {
+	client eventpb.EventServiceClient
+	conn   *grpc.ClientConn
+}
+
+// NewEventClient creates a new event service client. Extra options, such as
+// per-RPC credentials, are passed on to grpc.Dial.
+func NewEventClient(address string, opts ...grpc.DialOption) (*EventClient, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.Dial(address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to event service: %w", err)
+	}
+
+	return &EventClient{
+		client: eventpb.NewEventServiceClient(conn),
+		conn:   conn,
+	}, nil
+}
+
+// Close closes the connection to the event service
+func (c *EventClient) Close() error {
+	return c.conn.Close()
+}
+
+// GetSelection retrieves the current price and state of a selection
+func (c *EventClient) GetSelection(id string) (*domain.Selection, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetSelection(ctx, &eventpb.GetSelectionRequest{Id: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get selection: %w", err)
+	}
+	if resp.Selection == nil || resp.Market == nil {
+		return nil, fmt.Errorf("selection %s not found", id)
+	}
+
+	return &domain.Selection{
+		ID:      resp.Selection.Id,
+		EventID: resp.Market.EventId,
+		Price:   resp.Selection.Price,
+		Open:    resp.Market.Status == "open" && resp.Selection.Result == "pending",
+	}, nil
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"bet_service/domain"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"muchway/payment_service/pb"
+	"muchway/pkg/money"
+)
+
+// PaymentClient is a client for the payment service
+type PaymentClient struct {
+	client pb.PaymentServiceClient
+	conn   *grpc.ClientConn
+}
+
+// NewPaymentClient creates a new payment service client. Extra options, such as
+// per-RPC credentials, are passed on to grpc.Dial.
+func NewPaymentClient(address string, opts ...grpc.DialOption) (*PaymentClient, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.Dial(address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to payment service: %w", err)
+	}
+
+	return &PaymentClient{
+		client: pb.NewPaymentServiceClient(conn),
+		conn:   conn,
+	}, nil
+}
+
+// Close closes the connection to the payment service
+func (c *PaymentClient) Close() error {
+	return c.conn.Close()
+}
+
+// CreditPayout credits the payout of a won bet to the bettor's balance
+func (c *PaymentClient) CreditPayout(bet *domain.Bet) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.CreatePayment(ctx, &pb.CreatePaymentRequest{
+		UserId: bet.UserID,
+		Type:   "payout",
+		Amount: money.ToProto(bet.Payout),
+		// A payout retried after a lost response must not be credited twice
+		IdempotencyKey: "payout:" + bet.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to credit payout: %w", err)
+	}
+
+	log.Printf("Credited payout %s for bet %s (payment %s)", bet.Payout, bet.ID, resp.Id)
+	return nil
+}
+
+// ReserveStake holds the stake of a bet on the bettor's balance. The bet ID is
+// used as the reference so a retried reservation is not taken twice.
+func (c *PaymentClient) ReserveStake(bet *domain.Bet) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.ReserveFunds(ctx, &pb.ReserveFundsRequest{
+		UserId:    bet.UserID,
+		Amount:    money.ToProto(bet.Amount),
+		Reference: "bet:" + bet.ID,
+	})
+	if status.Code(err) == codes.FailedPrecondition {
+		return "", domain.ErrInsufficientFunds
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to reserve stake: %w", err)
+	}
+	return resp.Id, nil
+}
+
+// CaptureStake confirms a stake reservation once the bet has been stored
+func (c *PaymentClient) CaptureStake(reservationID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := c.client.CaptureFunds(ctx, &pb.CaptureFundsRequest{ReservationId: reservationID}); err != nil {
+		return fmt.Errorf("failed to capture stake: %w", err)
+	}
+	return nil
+}
+
+// ReleaseStake returns a reserved stake to the bettor's balance
+func (c *PaymentClient) ReleaseStake(reservationID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := c.client.ReleaseFunds(ctx, &pb.ReleaseFundsRequest{ReservationId: reservationID}); err != nil {
+		return fmt.Errorf("failed to release stake: %w", err)
+	}
+	return nil
+}
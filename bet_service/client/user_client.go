@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"muchway/pkg/health"
+	"muchway/pkg/limits"
+	userpb "muchway/user_service/proto/userpb"
+)
+
+// UserClient is a client for the user service
+type UserClient struct {
+	client userpb.UserServiceClient
+	conn   *grpc.ClientConn
+}
+
+// NewUserClient creates a new user service client. Extra options, such as
+// per-RPC credentials, are passed on to grpc.Dial.
+func NewUserClient(address string, opts ...grpc.DialOption) (*UserClient, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.Dial(address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to user service: %w", err)
+	}
+
+	return &UserClient{
+		client: userpb.NewUserServiceClient(conn),
+		conn:   conn,
+	}, nil
+}
+
+// Close closes the connection to the user service
+func (c *UserClient) Close() error {
+	return c.conn.Close()
+}
+
+// Check reports whether the user service is reachable and serving
+func (c *UserClient) Check(ctx context.Context) error {
+	return health.GRPC(c.conn, userpb.UserService_ServiceDesc.ServiceName)(ctx)
+}
+
+// GetLimits returns the responsible gambling limits the bettor has set
+func (c *UserClient) GetLimits(userID string) ([]limits.Limit, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	id, err := strconv.ParseInt(userID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+	resp, err := c.client.GetLimits(ctx, &userpb.GetLimitsRequest{UserId: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get limits: %w", err)
+	}
+
+	ls := make([]limits.Limit, 0, len(resp.Limits))
+	for _, l := range resp.Limits {
+		ls = append(ls, limits.FromProto(l))
+	}
+	return ls, nil
+}
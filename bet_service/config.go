@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	"muchway/pkg/config"
+)
+
+// Config is the bet service's configuration, loaded by config.MustLoad.
+type Config struct {
+	GRPCAddr   string `yaml:"grpc_addr" env:"GRPC_ADDR" default:":50052" usage:"address the gRPC server listens on"`
+	HealthAddr string `yaml:"health_addr" env:"HEALTH_ADDR" default:":8052" usage:"address /healthz and /readyz are served on"`
+	JWKSURL    string `yaml:"jwks_url" env:"JWKS_URL" default:"http://localhost:8051/.well-known/jwks.json" usage:"where the user service publishes its signing keys"`
+
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" default:"30s" usage:"how long shutdown waits for work in flight"`
+	HealthInterval  time.Duration `yaml:"health_interval" env:"HEALTH_INTERVAL" default:"10s" usage:"how often dependencies are checked for /readyz and gRPC health"`
+
+	Services struct {
+		User    string `yaml:"user" env:"USER_SERVICE_ADDR" default:"localhost:50051" usage:"user service gRPC address"`
+		Event   string `yaml:"event" env:"EVENT_SERVICE_ADDR" default:"localhost:50053" usage:"event service gRPC address"`
+		Payment string `yaml:"payment" env:"PAYMENT_SERVICE_ADDR" default:"localhost:50054" usage:"payment service gRPC address"`
+	} `yaml:"services"`
+	ServiceClientSecret string `yaml:"service_client_secret" env:"SERVICE_CLIENT_SECRET" required:"true" secret:"true" usage:"secret this service authenticates to the user service with"`
+
+	Database config.Postgres `yaml:"database"`
+	RabbitMQ config.RabbitMQ `yaml:"rabbitmq"`
+	Redis    config.Redis    `yaml:"redis"`
+
+	IdempotencyRetention time.Duration `yaml:"idempotency_retention" env:"IDEMPOTENCY_KEY_RETENTION" default:"24h" usage:"how long idempotency keys are honoured"`
+	StakeRetryInterval   time.Duration `yaml:"stake_retry_interval" env:"STAKE_RETRY_INTERVAL" default:"1m" usage:"how often failed stake captures and releases are retried"`
+}
+
+func (c *Config) Validate() error {
+	if c.IdempotencyRetention <= 0 {
+		return errors.New("idempotency_retention must be positive")
+	}
+	if c.StakeRetryInterval <= 0 {
+		return errors.New("stake_retry_interval must be positive")
+	}
+	if c.ShutdownTimeout <= 0 {
+		return errors.New("shutdown_timeout must be positive")
+	}
+	if c.HealthInterval <= 0 {
+		return errors.New("health_interval must be positive")
+	}
+	return nil
+}
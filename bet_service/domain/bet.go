@@ -1,15 +1,40 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"muchway/pkg/money"
+	"time"
+)
+
+const (
+	BetStatusPending = "pending"
+	BetStatusWon     = "won"
+	BetStatusLost    = "lost"
+)
+
+var (
+	// ErrDuplicateIdempotencyKey is returned by the repository when another
+	// bet already holds the idempotency key.
+	ErrDuplicateIdempotencyKey = errors.New("idempotency key already used")
+	// ErrIdempotencyKeyReused is returned when a key is replayed with a
+	// different bet.
+	ErrIdempotencyKeyReused = errors.New("idempotency key was used for a different bet")
+)
 
 type Bet struct {
-	ID        string    `bson:"id"`
-	UserID    string    `bson:"user_id"`
-	EventID   string    `bson:"event_id"`
-	Amount    float64   `bson:"amount"`
-	Odds      float64   `bson:"odds"`
-	Status    string    `bson:"status"`           
-	Payout    float64   `bson:"payout,omitempty"` 
-	CreatedAt time.Time `bson:"created_at"`
-	UpdatedAt time.Time `bson:"updated_at"`
+	ID            string      `bson:"id"`
+	UserID        string      `bson:"user_id"`
+	EventID       string      `bson:"event_id"`
+	SelectionID   string      `bson:"selection_id"`
+	Amount        money.Money `bson:"amount"`
+	Odds          float64     `bson:"odds"`
+	Status        string      `bson:"status"`
+	Payout        money.Money `bson:"payout,omitempty"`
+	ReservationID string      `bson:"reservation_id,omitempty"`
+	PaidOutAt     *time.Time  `bson:"paid_out_at,omitempty"`
+	CreatedAt     time.Time   `bson:"created_at"`
+	UpdatedAt     time.Time   `bson:"updated_at"`
+	// IdempotencyKey is optional. Placing a bet again with the same key
+	// returns the original bet.
+	IdempotencyKey string `bson:"idempotency_key,omitempty"`
 }
@@ -1,7 +1,29 @@
 package domain
 
+import "muchway/pkg/events"
+
+// EventSource stamps the messages bet_service publishes.
+var EventSource = events.Source{Producer: "bet_service"}
+
+// BetEventPublisher publishes messages that are not tied to a database write.
+// Created, updated and deleted go through the outbox together with the
+// change they announce.
 type BetEventPublisher interface {
-	PublishBetCreated(bet *Bet) error
-	PublishBetUpdated(bet *Bet) error
-	PublishBetDeleted(bet *Bet) error
+	PublishBetRejected(bet *Bet, reason string) error
+}
+
+// BetPayload is the bet as published on the bus.
+func BetPayload(b *Bet) events.BetV1 {
+	return events.BetV1{
+		ID:          b.ID,
+		UserID:      b.UserID,
+		EventID:     b.EventID,
+		SelectionID: b.SelectionID,
+		Amount:      b.Amount,
+		Odds:        b.Odds,
+		Status:      b.Status,
+		Payout:      b.Payout,
+		CreatedAt:   b.CreatedAt,
+		UpdatedAt:   b.UpdatedAt,
+	}
 }
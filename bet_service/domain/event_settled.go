@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// EventSettled is published by event_service on the event.settled queue once
+// the result of an event has been recorded.
+type EventSettled struct {
+	EventID             string    `json:"event_id"`
+	WinnerID            string    `json:"winner_id,omitempty"`
+	WinningSelectionIDs []string  `json:"winning_selection_ids,omitempty"`
+	SettledAt           time.Time `json:"settled_at"`
+}
+
+// IsWinner reports whether a bet on the given selection won.
+func (e *EventSettled) IsWinner(selectionID string) bool {
+	if selectionID == "" {
+		return false
+	}
+	if selectionID == e.WinnerID {
+		return true
+	}
+	for _, id := range e.WinningSelectionIDs {
+		if id == selectionID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "muchway/pkg/limits"
+
+// LimitProvider returns the responsible gambling limits a bettor has set on
+// themselves, as currently in force.
+type LimitProvider interface {
+	GetLimits(userID string) ([]limits.Limit, error)
+}
@@ -0,0 +1,11 @@
+package domain
+
+// OddsPolicy decides whether a bet is still accepted when the price of its
+// selection moved away from the odds the bettor asked for.
+type OddsPolicy string
+
+const (
+	OddsPolicyReject       OddsPolicy = "reject"
+	OddsPolicyAcceptHigher OddsPolicy = "accept_higher"
+	OddsPolicyAcceptAny    OddsPolicy = "accept_any"
+)
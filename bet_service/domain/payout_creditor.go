@@ -0,0 +1,5 @@
+package domain
+
+type PayoutCreditor interface {
+	CreditPayout(bet *Bet) error
+}
@@ -0,0 +1,13 @@
+package domain
+
+// Selection is the outcome a bet is placed on, priced by event_service.
+type Selection struct {
+	ID      string
+	EventID string
+	Price   float64
+	Open    bool
+}
+
+type SelectionProvider interface {
+	GetSelection(id string) (*Selection, error)
+}
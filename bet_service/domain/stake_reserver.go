@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// StakeReserver holds the stake of a bet on the bettor's balance while the bet
+// is being placed. A reservation is captured once the bet is stored and
+// released if placing the bet fails. Capturing or releasing a reservation
+// again is harmless, so either can be retried.
+type StakeReserver interface {
+	ReserveStake(bet *Bet) (string, error)
+	CaptureStake(reservationID string) error
+	ReleaseStake(reservationID string) error
+}
+
+// What is still to be done with a stake reservation.
+const (
+	StakeCapture = "capture"
+	StakeRelease = "release"
+)
+
+// PendingStake is a stake reservation still to be captured or released.
+type PendingStake struct {
+	ReservationID string
+	BetID         string
+	Action        string
+	CreatedAt     time.Time
+}
@@ -9,6 +9,8 @@ import (
 	"bet_service/muchway/bet_service/proto/betpb"
 
 	"google.golang.org/grpc"
+
+	"muchway/pkg/money"
 )
 
 var createdBetID string
@@ -29,7 +31,7 @@ func TestCreateBet_Integration(t *testing.T) {
 		Bet: &betpb.Bet{
 			UserId:  "user123",
 			EventId: "event456",
-			Amount:  150.0,
+			Amount:  money.ToProto(money.MustParse("150")),
 			Odds:    2.75,
 		},
 	}
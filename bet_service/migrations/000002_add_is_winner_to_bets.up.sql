@@ -1 +1 @@
-ALTER TABLE bets ADD COLUMN is_winner BOOLEAN DEFAULT false;
+ALTER TABLE bets ADD COLUMN IF NOT EXISTS is_winner BOOLEAN DEFAULT false;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_bets_event_id;
+ALTER TABLE bets DROP COLUMN IF EXISTS paid_out_at;
+ALTER TABLE bets DROP COLUMN IF EXISTS selection_id;
@@ -0,0 +1,3 @@
+ALTER TABLE bets ADD COLUMN IF NOT EXISTS selection_id TEXT;
+ALTER TABLE bets ADD COLUMN IF NOT EXISTS paid_out_at TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS idx_bets_event_id ON bets (event_id);
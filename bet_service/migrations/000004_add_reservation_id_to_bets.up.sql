@@ -0,0 +1 @@
+ALTER TABLE bets ADD COLUMN IF NOT EXISTS reservation_id TEXT;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS bet_idempotency_keys;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS bet_idempotency_keys (
+    user_id TEXT NOT NULL,
+    key TEXT NOT NULL,
+    bet_id UUID NOT NULL REFERENCES bets (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, key)
+);
+CREATE INDEX IF NOT EXISTS idx_bet_idempotency_keys_created_at ON bet_idempotency_keys (created_at);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS bet_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    exchange TEXT NOT NULL DEFAULT '',
+    routing_key TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    sent_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_bet_outbox_due ON bet_outbox (next_attempt_at, id) WHERE sent_at IS NULL;
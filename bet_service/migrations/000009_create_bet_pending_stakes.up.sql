@@ -0,0 +1,10 @@
+-- Stake reservations still to be captured at payment_service, for stored
+-- bets, or released, for bets that were not. Rows are deleted once the call
+-- succeeds; until then they are retried.
+CREATE TABLE IF NOT EXISTS bet_pending_stakes (
+    reservation_id TEXT PRIMARY KEY,
+    bet_id TEXT NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('capture', 'release')),
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_bet_pending_stakes_created_at ON bet_pending_stakes (created_at);
@@ -9,6 +9,7 @@ package betpb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	moneypb "muchway/pkg/money/moneypb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,15 +22,67 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// OddsChangePolicy says what to do when the price of the selection no longer
+// matches the odds the bettor saw.
+type OddsChangePolicy int32
+
+const (
+	OddsChangePolicy_ODDS_CHANGE_POLICY_REJECT        OddsChangePolicy = 0
+	OddsChangePolicy_ODDS_CHANGE_POLICY_ACCEPT_HIGHER OddsChangePolicy = 1
+	OddsChangePolicy_ODDS_CHANGE_POLICY_ACCEPT_ANY    OddsChangePolicy = 2
+)
+
+// Enum value maps for OddsChangePolicy.
+var (
+	OddsChangePolicy_name = map[int32]string{
+		0: "ODDS_CHANGE_POLICY_REJECT",
+		1: "ODDS_CHANGE_POLICY_ACCEPT_HIGHER",
+		2: "ODDS_CHANGE_POLICY_ACCEPT_ANY",
+	}
+	OddsChangePolicy_value = map[string]int32{
+		"ODDS_CHANGE_POLICY_REJECT":        0,
+		"ODDS_CHANGE_POLICY_ACCEPT_HIGHER": 1,
+		"ODDS_CHANGE_POLICY_ACCEPT_ANY":    2,
+	}
+)
+
+func (x OddsChangePolicy) Enum() *OddsChangePolicy {
+	p := new(OddsChangePolicy)
+	*p = x
+	return p
+}
+
+func (x OddsChangePolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OddsChangePolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_bet_proto_enumTypes[0].Descriptor()
+}
+
+func (OddsChangePolicy) Type() protoreflect.EnumType {
+	return &file_bet_proto_enumTypes[0]
+}
+
+func (x OddsChangePolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OddsChangePolicy.Descriptor instead.
+func (OddsChangePolicy) EnumDescriptor() ([]byte, []int) {
+	return file_bet_proto_rawDescGZIP(), []int{0}
+}
+
 type Bet struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	EventId       string                 `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
-	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
 	Odds          float64                `protobuf:"fixed64,5,opt,name=odds,proto3" json:"odds,omitempty"`
 	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
-	Payout        float64                `protobuf:"fixed64,7,opt,name=payout,proto3" json:"payout,omitempty"`
+	SelectionId   string                 `protobuf:"bytes,8,opt,name=selection_id,json=selectionId,proto3" json:"selection_id,omitempty"`
+	Amount        *moneypb.Money         `protobuf:"bytes,9,opt,name=amount,proto3" json:"amount,omitempty"`
+	Payout        *moneypb.Money         `protobuf:"bytes,10,opt,name=payout,proto3" json:"payout,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -85,13 +138,6 @@ func (x *Bet) GetEventId() string {
 	return ""
 }
 
-func (x *Bet) GetAmount() float64 {
-	if x != nil {
-		return x.Amount
-	}
-	return 0
-}
-
 func (x *Bet) GetOdds() float64 {
 	if x != nil {
 		return x.Odds
@@ -106,23 +152,103 @@ func (x *Bet) GetStatus() string {
 	return ""
 }
 
-func (x *Bet) GetPayout() float64 {
+func (x *Bet) GetSelectionId() string {
+	if x != nil {
+		return x.SelectionId
+	}
+	return ""
+}
+
+func (x *Bet) GetAmount() *moneypb.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Bet) GetPayout() *moneypb.Money {
 	if x != nil {
 		return x.Payout
 	}
-	return 0
+	return nil
 }
 
-type CreateBetRequest struct {
+// OddsChanged is attached to an ABORTED status when a bet is refused because
+// the price moved; current_odds is the price to re-quote.
+type OddsChanged struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Bet           *Bet                   `protobuf:"bytes,1,opt,name=bet,proto3" json:"bet,omitempty"`
+	SelectionId   string                 `protobuf:"bytes,1,opt,name=selection_id,json=selectionId,proto3" json:"selection_id,omitempty"`
+	RequestedOdds float64                `protobuf:"fixed64,2,opt,name=requested_odds,json=requestedOdds,proto3" json:"requested_odds,omitempty"`
+	CurrentOdds   float64                `protobuf:"fixed64,3,opt,name=current_odds,json=currentOdds,proto3" json:"current_odds,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *OddsChanged) Reset() {
+	*x = OddsChanged{}
+	mi := &file_bet_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OddsChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OddsChanged) ProtoMessage() {}
+
+func (x *OddsChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_bet_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OddsChanged.ProtoReflect.Descriptor instead.
+func (*OddsChanged) Descriptor() ([]byte, []int) {
+	return file_bet_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OddsChanged) GetSelectionId() string {
+	if x != nil {
+		return x.SelectionId
+	}
+	return ""
+}
+
+func (x *OddsChanged) GetRequestedOdds() float64 {
+	if x != nil {
+		return x.RequestedOdds
+	}
+	return 0
+}
+
+func (x *OddsChanged) GetCurrentOdds() float64 {
+	if x != nil {
+		return x.CurrentOdds
+	}
+	return 0
+}
+
+type CreateBetRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Bet              *Bet                   `protobuf:"bytes,1,opt,name=bet,proto3" json:"bet,omitempty"`
+	OddsChangePolicy OddsChangePolicy       `protobuf:"varint,2,opt,name=odds_change_policy,json=oddsChangePolicy,proto3,enum=bet.OddsChangePolicy" json:"odds_change_policy,omitempty"`
+	// Optional. Repeating a request with the same key returns the original
+	// bet instead of placing another one.
+	IdempotencyKey string `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
 func (x *CreateBetRequest) Reset() {
 	*x = CreateBetRequest{}
-	mi := &file_bet_proto_msgTypes[1]
+	mi := &file_bet_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -134,7 +260,7 @@ func (x *CreateBetRequest) String() string {
 func (*CreateBetRequest) ProtoMessage() {}
 
 func (x *CreateBetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bet_proto_msgTypes[1]
+	mi := &file_bet_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -147,7 +273,7 @@ func (x *CreateBetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateBetRequest.ProtoReflect.Descriptor instead.
 func (*CreateBetRequest) Descriptor() ([]byte, []int) {
-	return file_bet_proto_rawDescGZIP(), []int{1}
+	return file_bet_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *CreateBetRequest) GetBet() *Bet {
@@ -157,6 +283,20 @@ func (x *CreateBetRequest) GetBet() *Bet {
 	return nil
 }
 
+func (x *CreateBetRequest) GetOddsChangePolicy() OddsChangePolicy {
+	if x != nil {
+		return x.OddsChangePolicy
+	}
+	return OddsChangePolicy_ODDS_CHANGE_POLICY_REJECT
+}
+
+func (x *CreateBetRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 type CreateBetResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Bet           *Bet                   `protobuf:"bytes,1,opt,name=bet,proto3" json:"bet,omitempty"`
@@ -166,7 +306,7 @@ type CreateBetResponse struct {
 
 func (x *CreateBetResponse) Reset() {
 	*x = CreateBetResponse{}
-	mi := &file_bet_proto_msgTypes[2]
+	mi := &file_bet_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -178,7 +318,7 @@ func (x *CreateBetResponse) String() string {
 func (*CreateBetResponse) ProtoMessage() {}
 
 func (x *CreateBetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bet_proto_msgTypes[2]
+	mi := &file_bet_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -191,7 +331,7 @@ func (x *CreateBetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateBetResponse.ProtoReflect.Descriptor instead.
 func (*CreateBetResponse) Descriptor() ([]byte, []int) {
-	return file_bet_proto_rawDescGZIP(), []int{2}
+	return file_bet_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CreateBetResponse) GetBet() *Bet {
@@ -210,7 +350,7 @@ type GetBetByIDRequest struct {
 
 func (x *GetBetByIDRequest) Reset() {
 	*x = GetBetByIDRequest{}
-	mi := &file_bet_proto_msgTypes[3]
+	mi := &file_bet_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -222,7 +362,7 @@ func (x *GetBetByIDRequest) String() string {
 func (*GetBetByIDRequest) ProtoMessage() {}
 
 func (x *GetBetByIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bet_proto_msgTypes[3]
+	mi := &file_bet_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -235,7 +375,7 @@ func (x *GetBetByIDRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBetByIDRequest.ProtoReflect.Descriptor instead.
 func (*GetBetByIDRequest) Descriptor() ([]byte, []int) {
-	return file_bet_proto_rawDescGZIP(), []int{3}
+	return file_bet_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetBetByIDRequest) GetId() string {
@@ -254,7 +394,7 @@ type GetBetByIDResponse struct {
 
 func (x *GetBetByIDResponse) Reset() {
 	*x = GetBetByIDResponse{}
-	mi := &file_bet_proto_msgTypes[4]
+	mi := &file_bet_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -266,7 +406,7 @@ func (x *GetBetByIDResponse) String() string {
 func (*GetBetByIDResponse) ProtoMessage() {}
 
 func (x *GetBetByIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bet_proto_msgTypes[4]
+	mi := &file_bet_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -279,7 +419,7 @@ func (x *GetBetByIDResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBetByIDResponse.ProtoReflect.Descriptor instead.
 func (*GetBetByIDResponse) Descriptor() ([]byte, []int) {
-	return file_bet_proto_rawDescGZIP(), []int{4}
+	return file_bet_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetBetByIDResponse) GetBet() *Bet {
@@ -298,7 +438,7 @@ type GetBetsByUserIDRequest struct {
 
 func (x *GetBetsByUserIDRequest) Reset() {
 	*x = GetBetsByUserIDRequest{}
-	mi := &file_bet_proto_msgTypes[5]
+	mi := &file_bet_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -310,7 +450,7 @@ func (x *GetBetsByUserIDRequest) String() string {
 func (*GetBetsByUserIDRequest) ProtoMessage() {}
 
 func (x *GetBetsByUserIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bet_proto_msgTypes[5]
+	mi := &file_bet_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -323,7 +463,7 @@ func (x *GetBetsByUserIDRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBetsByUserIDRequest.ProtoReflect.Descriptor instead.
 func (*GetBetsByUserIDRequest) Descriptor() ([]byte, []int) {
-	return file_bet_proto_rawDescGZIP(), []int{5}
+	return file_bet_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetBetsByUserIDRequest) GetUserId() string {
@@ -342,7 +482,7 @@ type GetBetsByUserIDResponse struct {
 
 func (x *GetBetsByUserIDResponse) Reset() {
 	*x = GetBetsByUserIDResponse{}
-	mi := &file_bet_proto_msgTypes[6]
+	mi := &file_bet_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -354,7 +494,7 @@ func (x *GetBetsByUserIDResponse) String() string {
 func (*GetBetsByUserIDResponse) ProtoMessage() {}
 
 func (x *GetBetsByUserIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bet_proto_msgTypes[6]
+	mi := &file_bet_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -367,7 +507,7 @@ func (x *GetBetsByUserIDResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBetsByUserIDResponse.ProtoReflect.Descriptor instead.
 func (*GetBetsByUserIDResponse) Descriptor() ([]byte, []int) {
-	return file_bet_proto_rawDescGZIP(), []int{6}
+	return file_bet_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetBetsByUserIDResponse) GetBets() []*Bet {
@@ -386,7 +526,7 @@ type UpdateBetRequest struct {
 
 func (x *UpdateBetRequest) Reset() {
 	*x = UpdateBetRequest{}
-	mi := &file_bet_proto_msgTypes[7]
+	mi := &file_bet_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -398,7 +538,7 @@ func (x *UpdateBetRequest) String() string {
 func (*UpdateBetRequest) ProtoMessage() {}
 
 func (x *UpdateBetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bet_proto_msgTypes[7]
+	mi := &file_bet_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -411,7 +551,7 @@ func (x *UpdateBetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateBetRequest.ProtoReflect.Descriptor instead.
 func (*UpdateBetRequest) Descriptor() ([]byte, []int) {
-	return file_bet_proto_rawDescGZIP(), []int{7}
+	return file_bet_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *UpdateBetRequest) GetBet() *Bet {
@@ -430,7 +570,7 @@ type UpdateBetResponse struct {
 
 func (x *UpdateBetResponse) Reset() {
 	*x = UpdateBetResponse{}
-	mi := &file_bet_proto_msgTypes[8]
+	mi := &file_bet_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -442,7 +582,7 @@ func (x *UpdateBetResponse) String() string {
 func (*UpdateBetResponse) ProtoMessage() {}
 
 func (x *UpdateBetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bet_proto_msgTypes[8]
+	mi := &file_bet_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -455,7 +595,7 @@ func (x *UpdateBetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateBetResponse.ProtoReflect.Descriptor instead.
 func (*UpdateBetResponse) Descriptor() ([]byte, []int) {
-	return file_bet_proto_rawDescGZIP(), []int{8}
+	return file_bet_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *UpdateBetResponse) GetBet() *Bet {
@@ -474,7 +614,7 @@ type DeleteBetRequest struct {
 
 func (x *DeleteBetRequest) Reset() {
 	*x = DeleteBetRequest{}
-	mi := &file_bet_proto_msgTypes[9]
+	mi := &file_bet_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -486,7 +626,7 @@ func (x *DeleteBetRequest) String() string {
 func (*DeleteBetRequest) ProtoMessage() {}
 
 func (x *DeleteBetRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bet_proto_msgTypes[9]
+	mi := &file_bet_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -499,7 +639,7 @@ func (x *DeleteBetRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteBetRequest.ProtoReflect.Descriptor instead.
 func (*DeleteBetRequest) Descriptor() ([]byte, []int) {
-	return file_bet_proto_rawDescGZIP(), []int{9}
+	return file_bet_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DeleteBetRequest) GetId() string {
@@ -518,7 +658,7 @@ type DeleteBetResponse struct {
 
 func (x *DeleteBetResponse) Reset() {
 	*x = DeleteBetResponse{}
-	mi := &file_bet_proto_msgTypes[10]
+	mi := &file_bet_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -530,7 +670,7 @@ func (x *DeleteBetResponse) String() string {
 func (*DeleteBetResponse) ProtoMessage() {}
 
 func (x *DeleteBetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bet_proto_msgTypes[10]
+	mi := &file_bet_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -543,7 +683,7 @@ func (x *DeleteBetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteBetResponse.ProtoReflect.Descriptor instead.
 func (*DeleteBetResponse) Descriptor() ([]byte, []int) {
-	return file_bet_proto_rawDescGZIP(), []int{10}
+	return file_bet_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *DeleteBetResponse) GetSuccess() bool {
@@ -557,17 +697,25 @@ var File_bet_proto protoreflect.FileDescriptor
 
 const file_bet_proto_rawDesc = "" +
 	"\n" +
-	"\tbet.proto\x12\x03bet\"\xa5\x01\n" +
+	"\tbet.proto\x12\x03bet\x1a\x11money/money.proto\"\xe4\x01\n" +
 	"\x03Bet\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x19\n" +
-	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12\x16\n" +
-	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x12\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12\x12\n" +
 	"\x04odds\x18\x05 \x01(\x01R\x04odds\x12\x16\n" +
-	"\x06status\x18\x06 \x01(\tR\x06status\x12\x16\n" +
-	"\x06payout\x18\a \x01(\x01R\x06payout\".\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12!\n" +
+	"\fselection_id\x18\b \x01(\tR\vselectionId\x12$\n" +
+	"\x06amount\x18\t \x01(\v2\f.money.MoneyR\x06amount\x12$\n" +
+	"\x06payout\x18\n" +
+	" \x01(\v2\f.money.MoneyR\x06payout\"z\n" +
+	"\vOddsChanged\x12!\n" +
+	"\fselection_id\x18\x01 \x01(\tR\vselectionId\x12%\n" +
+	"\x0erequested_odds\x18\x02 \x01(\x01R\rrequestedOdds\x12!\n" +
+	"\fcurrent_odds\x18\x03 \x01(\x01R\vcurrentOdds\"\x9c\x01\n" +
 	"\x10CreateBetRequest\x12\x1a\n" +
-	"\x03bet\x18\x01 \x01(\v2\b.bet.BetR\x03bet\"/\n" +
+	"\x03bet\x18\x01 \x01(\v2\b.bet.BetR\x03bet\x12C\n" +
+	"\x12odds_change_policy\x18\x02 \x01(\x0e2\x15.bet.OddsChangePolicyR\x10oddsChangePolicy\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\"/\n" +
 	"\x11CreateBetResponse\x12\x1a\n" +
 	"\x03bet\x18\x01 \x01(\v2\b.bet.BetR\x03bet\"#\n" +
 	"\x11GetBetByIDRequest\x12\x0e\n" +
@@ -585,7 +733,11 @@ const file_bet_proto_rawDesc = "" +
 	"\x10DeleteBetRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"-\n" +
 	"\x11DeleteBetResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xcd\x02\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess*z\n" +
+	"\x10OddsChangePolicy\x12\x1d\n" +
+	"\x19ODDS_CHANGE_POLICY_REJECT\x10\x00\x12$\n" +
+	" ODDS_CHANGE_POLICY_ACCEPT_HIGHER\x10\x01\x12!\n" +
+	"\x1dODDS_CHANGE_POLICY_ACCEPT_ANY\x10\x022\xcd\x02\n" +
 	"\n" +
 	"BetService\x12:\n" +
 	"\tCreateBet\x12\x15.bet.CreateBetRequest\x1a\x16.bet.CreateBetResponse\x12=\n" +
@@ -607,42 +759,49 @@ func file_bet_proto_rawDescGZIP() []byte {
 	return file_bet_proto_rawDescData
 }
 
-var file_bet_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_bet_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_bet_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_bet_proto_goTypes = []any{
-	(*Bet)(nil),                     // 0: bet.Bet
-	(*CreateBetRequest)(nil),        // 1: bet.CreateBetRequest
-	(*CreateBetResponse)(nil),       // 2: bet.CreateBetResponse
-	(*GetBetByIDRequest)(nil),       // 3: bet.GetBetByIDRequest
-	(*GetBetByIDResponse)(nil),      // 4: bet.GetBetByIDResponse
-	(*GetBetsByUserIDRequest)(nil),  // 5: bet.GetBetsByUserIDRequest
-	(*GetBetsByUserIDResponse)(nil), // 6: bet.GetBetsByUserIDResponse
-	(*UpdateBetRequest)(nil),        // 7: bet.UpdateBetRequest
-	(*UpdateBetResponse)(nil),       // 8: bet.UpdateBetResponse
-	(*DeleteBetRequest)(nil),        // 9: bet.DeleteBetRequest
-	(*DeleteBetResponse)(nil),       // 10: bet.DeleteBetResponse
+	(OddsChangePolicy)(0),           // 0: bet.OddsChangePolicy
+	(*Bet)(nil),                     // 1: bet.Bet
+	(*OddsChanged)(nil),             // 2: bet.OddsChanged
+	(*CreateBetRequest)(nil),        // 3: bet.CreateBetRequest
+	(*CreateBetResponse)(nil),       // 4: bet.CreateBetResponse
+	(*GetBetByIDRequest)(nil),       // 5: bet.GetBetByIDRequest
+	(*GetBetByIDResponse)(nil),      // 6: bet.GetBetByIDResponse
+	(*GetBetsByUserIDRequest)(nil),  // 7: bet.GetBetsByUserIDRequest
+	(*GetBetsByUserIDResponse)(nil), // 8: bet.GetBetsByUserIDResponse
+	(*UpdateBetRequest)(nil),        // 9: bet.UpdateBetRequest
+	(*UpdateBetResponse)(nil),       // 10: bet.UpdateBetResponse
+	(*DeleteBetRequest)(nil),        // 11: bet.DeleteBetRequest
+	(*DeleteBetResponse)(nil),       // 12: bet.DeleteBetResponse
+	(*moneypb.Money)(nil),           // 13: money.Money
 }
 var file_bet_proto_depIdxs = []int32{
-	0,  // 0: bet.CreateBetRequest.bet:type_name -> bet.Bet
-	0,  // 1: bet.CreateBetResponse.bet:type_name -> bet.Bet
-	0,  // 2: bet.GetBetByIDResponse.bet:type_name -> bet.Bet
-	0,  // 3: bet.GetBetsByUserIDResponse.bets:type_name -> bet.Bet
-	0,  // 4: bet.UpdateBetRequest.bet:type_name -> bet.Bet
-	0,  // 5: bet.UpdateBetResponse.bet:type_name -> bet.Bet
-	1,  // 6: bet.BetService.CreateBet:input_type -> bet.CreateBetRequest
-	3,  // 7: bet.BetService.GetBetByID:input_type -> bet.GetBetByIDRequest
-	5,  // 8: bet.BetService.GetBetsByUserID:input_type -> bet.GetBetsByUserIDRequest
-	7,  // 9: bet.BetService.UpdateBet:input_type -> bet.UpdateBetRequest
-	9,  // 10: bet.BetService.DeleteBet:input_type -> bet.DeleteBetRequest
-	2,  // 11: bet.BetService.CreateBet:output_type -> bet.CreateBetResponse
-	4,  // 12: bet.BetService.GetBetByID:output_type -> bet.GetBetByIDResponse
-	6,  // 13: bet.BetService.GetBetsByUserID:output_type -> bet.GetBetsByUserIDResponse
-	8,  // 14: bet.BetService.UpdateBet:output_type -> bet.UpdateBetResponse
-	10, // 15: bet.BetService.DeleteBet:output_type -> bet.DeleteBetResponse
-	11, // [11:16] is the sub-list for method output_type
-	6,  // [6:11] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	13, // 0: bet.Bet.amount:type_name -> money.Money
+	13, // 1: bet.Bet.payout:type_name -> money.Money
+	1,  // 2: bet.CreateBetRequest.bet:type_name -> bet.Bet
+	0,  // 3: bet.CreateBetRequest.odds_change_policy:type_name -> bet.OddsChangePolicy
+	1,  // 4: bet.CreateBetResponse.bet:type_name -> bet.Bet
+	1,  // 5: bet.GetBetByIDResponse.bet:type_name -> bet.Bet
+	1,  // 6: bet.GetBetsByUserIDResponse.bets:type_name -> bet.Bet
+	1,  // 7: bet.UpdateBetRequest.bet:type_name -> bet.Bet
+	1,  // 8: bet.UpdateBetResponse.bet:type_name -> bet.Bet
+	3,  // 9: bet.BetService.CreateBet:input_type -> bet.CreateBetRequest
+	5,  // 10: bet.BetService.GetBetByID:input_type -> bet.GetBetByIDRequest
+	7,  // 11: bet.BetService.GetBetsByUserID:input_type -> bet.GetBetsByUserIDRequest
+	9,  // 12: bet.BetService.UpdateBet:input_type -> bet.UpdateBetRequest
+	11, // 13: bet.BetService.DeleteBet:input_type -> bet.DeleteBetRequest
+	4,  // 14: bet.BetService.CreateBet:output_type -> bet.CreateBetResponse
+	6,  // 15: bet.BetService.GetBetByID:output_type -> bet.GetBetByIDResponse
+	8,  // 16: bet.BetService.GetBetsByUserID:output_type -> bet.GetBetsByUserIDResponse
+	10, // 17: bet.BetService.UpdateBet:output_type -> bet.UpdateBetResponse
+	12, // 18: bet.BetService.DeleteBet:output_type -> bet.DeleteBetResponse
+	14, // [14:19] is the sub-list for method output_type
+	9,  // [9:14] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_bet_proto_init() }
@@ -655,13 +814,14 @@ func file_bet_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bet_proto_rawDesc), len(file_bet_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      1,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_bet_proto_goTypes,
 		DependencyIndexes: file_bet_proto_depIdxs,
+		EnumInfos:         file_bet_proto_enumTypes,
 		MessageInfos:      file_bet_proto_msgTypes,
 	}.Build()
 	File_bet_proto = out.File
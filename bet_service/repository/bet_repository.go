@@ -1,11 +1,69 @@
 package repository
 
-import "bet_service/domain"
+import (
+	"bet_service/domain"
+	"time"
+
+	"muchway/pkg/limits"
+	"muchway/pkg/money"
+	"muchway/pkg/outbox"
+)
+
+// TotalsFunc sums the stakes and the payouts of the bets the user placed
+// since the given time.
+type TotalsFunc func(userID string, since time.Time) (staked, returned money.Money, err error)
+
+// LimitCheck refuses a bet that the user's totals leave no room for.
+type LimitCheck func(totals TotalsFunc) error
 
 type BetRepository interface {
-	Create(bet *domain.Bet) error
+	// Create stores the bet together with its idempotency key, if any, the
+	// outbox messages announcing it and, when it holds a stake reservation,
+	// the capture still owed on it. It returns
+	// domain.ErrDuplicateIdempotencyKey when the key is taken. A non-nil
+	// check runs first, in the same transaction and with the user's bets
+	// locked, so that two bets cannot both fit under a limit only one of
+	// them fits under; its error is returned as is.
+	Create(bet *domain.Bet, check LimitCheck, msgs ...outbox.Message) error
 	GetByID(id string) (*domain.Bet, error)
 	GetByUserID(userID string) ([]*domain.Bet, error)
-	Update(bet *domain.Bet) error
-	Delete(id string) error
+	GetByEventID(eventID string) ([]*domain.Bet, error)
+	Update(bet *domain.Bet, msgs ...outbox.Message) error
+	Delete(id string, msgs ...outbox.Message) error
+
+	// Settle moves a pending bet to its final status and payout. It reports
+	// false when the bet was already settled, so replays are harmless.
+	Settle(bet *domain.Bet) (bool, error)
+	// MarkPaidOut claims the payout of a won bet. It reports false when the
+	// payout has already been claimed.
+	MarkPaidOut(id string) (bool, error)
+	// UnmarkPaidOut releases a claim taken by MarkPaidOut after the credit failed.
+	UnmarkPaidOut(id string) error
+
+	// Totals sums the stakes and the payouts of the bets the user placed
+	// since the given time. Unsettled bets have no payout yet.
+	Totals(userID string, since time.Time) (staked, returned money.Money, err error)
+
+	// SaveExclusion records a user's self-exclusion. An exclusion that ends
+	// sooner than the one on record is ignored, so redelivered messages
+	// cannot shorten it.
+	SaveExclusion(userID string, e limits.Exclusion) error
+	// GetExclusion returns the user's latest exclusion, or nil if there is
+	// none.
+	GetExclusion(userID string) (*limits.Exclusion, error)
+
+	// QueueStakeRelease records that the reservation of a bet that was not
+	// stored is to be released.
+	QueueStakeRelease(reservationID, betID string) error
+	// PendingStakes returns up to limit reservations still to be captured or
+	// released, oldest first.
+	PendingStakes(limit int) ([]domain.PendingStake, error)
+	// StakeDone forgets a reservation once it was captured or released.
+	StakeDone(reservationID string) error
+
+	// FindByIdempotencyKey returns the bet placed with the user's key, or nil
+	// if there is none.
+	FindByIdempotencyKey(userID, key string) (*domain.Bet, error)
+	// PurgeIdempotencyKeys forgets keys created before the given time.
+	PurgeIdempotencyKeys(before time.Time) (int64, error)
 }
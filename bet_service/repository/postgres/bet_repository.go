@@ -2,39 +2,102 @@ package postgres
 
 import (
 	"bet_service/domain"
+	"bet_service/repository"
+	"context"
 	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+
+	"muchway/pkg/limits"
+	"muchway/pkg/money"
+	"muchway/pkg/outbox"
 )
 
+// OutboxTable holds the messages waiting to be relayed to RabbitMQ.
+const OutboxTable = "bet_outbox"
+
+// AuditTable records the calls refused for lack of permission.
+const AuditTable = "bet_audit_log"
+
 type PostgresBetRepository struct {
-	db *sql.DB
+	db     *sql.DB
+	outbox *outbox.Store
 }
 
 func NewPostgresBetRepository(db *sql.DB) *PostgresBetRepository {
-	return &PostgresBetRepository{db: db}
+	return &PostgresBetRepository{db: db, outbox: outbox.NewStore(OutboxTable)}
 }
 
-func (r *PostgresBetRepository) Create(bet *domain.Bet) error {
+func (r *PostgresBetRepository) Create(bet *domain.Bet, check repository.LimitCheck, msgs ...outbox.Message) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if check != nil {
+		// Bets of the same user wait here until this one is stored
+		if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('bets:' || $1))`, bet.UserID); err != nil {
+			return err
+		}
+		if err := check(func(userID string, since time.Time) (money.Money, money.Money, error) {
+			return totals(tx, userID, since)
+		}); err != nil {
+			return err
+		}
+	}
+
 	query := `
-        INSERT INTO bets (id, user_id, event_id, amount, odds, status, payout, created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        INSERT INTO bets (id, user_id, event_id, selection_id, amount, odds, status, payout, reservation_id, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
     `
-	_, err := r.db.Exec(query,
+	_, err = tx.Exec(query,
 		bet.ID,
 		bet.UserID,
 		bet.EventID,
+		bet.SelectionID,
 		bet.Amount,
 		bet.Odds,
 		bet.Status,
 		bet.Payout,
+		bet.ReservationID,
 		bet.CreatedAt,
 		bet.UpdatedAt,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+
+	if bet.ReservationID != "" {
+		if err := queueStake(tx, bet.ReservationID, bet.ID, domain.StakeCapture); err != nil {
+			return err
+		}
+	}
+
+	if bet.IdempotencyKey != "" {
+		_, err = tx.Exec(`
+        INSERT INTO bet_idempotency_keys (user_id, key, bet_id, created_at)
+        VALUES ($1, $2, $3, $4)
+    `, bet.UserID, bet.IdempotencyKey, bet.ID, bet.CreatedAt)
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return domain.ErrDuplicateIdempotencyKey
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := r.outbox.Add(context.Background(), tx, msgs...); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 func (r *PostgresBetRepository) GetByID(id string) (*domain.Bet, error) {
 	query := `
-        SELECT id, user_id, event_id, amount, odds, status, payout, created_at, updated_at
+        SELECT id, user_id, event_id, COALESCE(selection_id, ''), amount, odds, status, payout, COALESCE(reservation_id, ''), paid_out_at, created_at, updated_at
         FROM bets
         WHERE id = $1
     `
@@ -45,10 +108,13 @@ func (r *PostgresBetRepository) GetByID(id string) (*domain.Bet, error) {
 		&bet.ID,
 		&bet.UserID,
 		&bet.EventID,
+		&bet.SelectionID,
 		&bet.Amount,
 		&bet.Odds,
 		&bet.Status,
 		&bet.Payout,
+		&bet.ReservationID,
+		&bet.PaidOutAt,
 		&bet.CreatedAt,
 		&bet.UpdatedAt,
 	)
@@ -61,7 +127,7 @@ func (r *PostgresBetRepository) GetByID(id string) (*domain.Bet, error) {
 
 func (r *PostgresBetRepository) GetByUserID(userID string) ([]*domain.Bet, error) {
 	query := `
-        SELECT id, user_id, event_id, amount, odds, status, payout, created_at, updated_at
+        SELECT id, user_id, event_id, COALESCE(selection_id, ''), amount, odds, status, payout, COALESCE(reservation_id, ''), paid_out_at, created_at, updated_at
         FROM bets
         WHERE user_id = $1
     `
@@ -78,10 +144,51 @@ func (r *PostgresBetRepository) GetByUserID(userID string) ([]*domain.Bet, error
 			&bet.ID,
 			&bet.UserID,
 			&bet.EventID,
+			&bet.SelectionID,
+			&bet.Amount,
+			&bet.Odds,
+			&bet.Status,
+			&bet.Payout,
+			&bet.ReservationID,
+			&bet.PaidOutAt,
+			&bet.CreatedAt,
+			&bet.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		bets = append(bets, bet)
+	}
+
+	return bets, nil
+}
+
+func (r *PostgresBetRepository) GetByEventID(eventID string) ([]*domain.Bet, error) {
+	query := `
+        SELECT id, user_id, event_id, COALESCE(selection_id, ''), amount, odds, status, payout, COALESCE(reservation_id, ''), paid_out_at, created_at, updated_at
+        FROM bets
+        WHERE event_id = $1
+    `
+	rows, err := r.db.Query(query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bets []*domain.Bet
+	for rows.Next() {
+		bet := &domain.Bet{}
+		err := rows.Scan(
+			&bet.ID,
+			&bet.UserID,
+			&bet.EventID,
+			&bet.SelectionID,
 			&bet.Amount,
 			&bet.Odds,
 			&bet.Status,
 			&bet.Payout,
+			&bet.ReservationID,
+			&bet.PaidOutAt,
 			&bet.CreatedAt,
 			&bet.UpdatedAt,
 		)
@@ -94,15 +201,22 @@ func (r *PostgresBetRepository) GetByUserID(userID string) ([]*domain.Bet, error
 	return bets, nil
 }
 
-func (r *PostgresBetRepository) Update(bet *domain.Bet) error {
+func (r *PostgresBetRepository) Update(bet *domain.Bet, msgs ...outbox.Message) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
         UPDATE bets
-        SET user_id=$1, event_id=$2, amount=$3, odds=$4, status=$5, payout=$6, created_at=$7, updated_at=$8
-        WHERE id=$9
+        SET user_id=$1, event_id=$2, selection_id=$3, amount=$4, odds=$5, status=$6, payout=$7, created_at=$8, updated_at=$9
+        WHERE id=$10
     `
-	_, err := r.db.Exec(query,
+	_, err = tx.Exec(query,
 		bet.UserID,
 		bet.EventID,
+		bet.SelectionID,
 		bet.Amount,
 		bet.Odds,
 		bet.Status,
@@ -111,14 +225,207 @@ func (r *PostgresBetRepository) Update(bet *domain.Bet) error {
 		bet.UpdatedAt,
 		bet.ID,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	if err := r.outbox.Add(context.Background(), tx, msgs...); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-func (r *PostgresBetRepository) Delete(id string) error {
+func (r *PostgresBetRepository) Delete(id string, msgs ...outbox.Message) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
         DELETE FROM bets
         WHERE id = $1
+    `
+	if _, err := tx.Exec(query, id); err != nil {
+		return err
+	}
+	if err := r.outbox.Add(context.Background(), tx, msgs...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresBetRepository) Settle(bet *domain.Bet) (bool, error) {
+	query := `
+        UPDATE bets
+        SET status=$1, payout=$2, updated_at=$3
+        WHERE id=$4 AND status=$5
+    `
+	res, err := r.db.Exec(query,
+		bet.Status,
+		bet.Payout,
+		bet.UpdatedAt,
+		bet.ID,
+		domain.BetStatusPending,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (r *PostgresBetRepository) MarkPaidOut(id string) (bool, error) {
+	query := `
+        UPDATE bets
+        SET paid_out_at=$1
+        WHERE id=$2 AND paid_out_at IS NULL
+    `
+	res, err := r.db.Exec(query, time.Now(), id)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (r *PostgresBetRepository) UnmarkPaidOut(id string) error {
+	query := `
+        UPDATE bets
+        SET paid_out_at=NULL
+        WHERE id=$1
     `
 	_, err := r.db.Exec(query, id)
 	return err
 }
+
+func (r *PostgresBetRepository) Totals(userID string, since time.Time) (money.Money, money.Money, error) {
+	return totals(r.db, userID, since)
+}
+
+func totals(q interface {
+	QueryRow(query string, args ...any) *sql.Row
+}, userID string, since time.Time) (money.Money, money.Money, error) {
+	var staked, returned money.Money
+	err := q.QueryRow(`
+        SELECT COALESCE(SUM(amount), 0), COALESCE(SUM(payout), 0)
+        FROM bets
+        WHERE user_id = $1 AND created_at >= $2
+    `, userID, since).Scan(&staked, &returned)
+	return staked, returned, err
+}
+
+func (r *PostgresBetRepository) SaveExclusion(userID string, e limits.Exclusion) error {
+	_, err := r.db.Exec(`
+        INSERT INTO bet_exclusions (user_id, since, until, updated_at)
+        VALUES ($1, $2, $3, now())
+        ON CONFLICT (user_id) DO UPDATE SET since = EXCLUDED.since, until = EXCLUDED.until, updated_at = now()
+        WHERE bet_exclusions.until IS NOT NULL AND (EXCLUDED.until IS NULL OR EXCLUDED.until > bet_exclusions.until)
+    `, userID, e.Since, e.Until)
+	return err
+}
+
+func (r *PostgresBetRepository) GetExclusion(userID string) (*limits.Exclusion, error) {
+	var e limits.Exclusion
+	var until sql.NullTime
+	err := r.db.QueryRow(`SELECT since, until FROM bet_exclusions WHERE user_id = $1`, userID).Scan(&e.Since, &until)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if until.Valid {
+		e.Until = &until.Time
+	}
+	return &e, nil
+}
+
+func (r *PostgresBetRepository) FindByIdempotencyKey(userID, key string) (*domain.Bet, error) {
+	query := `
+        SELECT b.id, b.user_id, b.event_id, COALESCE(b.selection_id, ''), b.amount, b.odds, b.status, b.payout, COALESCE(b.reservation_id, ''), b.paid_out_at, b.created_at, b.updated_at, k.key
+        FROM bet_idempotency_keys k
+        JOIN bets b ON b.id = k.bet_id
+        WHERE k.user_id = $1 AND k.key = $2
+    `
+	bet := &domain.Bet{}
+	err := r.db.QueryRow(query, userID, key).Scan(
+		&bet.ID,
+		&bet.UserID,
+		&bet.EventID,
+		&bet.SelectionID,
+		&bet.Amount,
+		&bet.Odds,
+		&bet.Status,
+		&bet.Payout,
+		&bet.ReservationID,
+		&bet.PaidOutAt,
+		&bet.CreatedAt,
+		&bet.UpdatedAt,
+		&bet.IdempotencyKey,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bet, nil
+}
+
+func (r *PostgresBetRepository) PurgeIdempotencyKeys(before time.Time) (int64, error) {
+	res, err := r.db.Exec(`DELETE FROM bet_idempotency_keys WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *PostgresBetRepository) QueueStakeRelease(reservationID, betID string) error {
+	return queueStake(r.db, reservationID, betID, domain.StakeRelease)
+}
+
+// queueStake records what is still to be done with a stake reservation.
+func queueStake(q interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}, reservationID, betID, action string) error {
+	_, err := q.Exec(`
+        INSERT INTO bet_pending_stakes (reservation_id, bet_id, action, created_at)
+        VALUES ($1, $2, $3, $4)
+        ON CONFLICT (reservation_id) DO NOTHING
+    `, reservationID, betID, action, time.Now())
+	return err
+}
+
+func (r *PostgresBetRepository) PendingStakes(limit int) ([]domain.PendingStake, error) {
+	rows, err := r.db.Query(`
+        SELECT reservation_id, bet_id, action, created_at
+        FROM bet_pending_stakes
+        ORDER BY created_at
+        LIMIT $1
+    `, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pending []domain.PendingStake
+	for rows.Next() {
+		var p domain.PendingStake
+		if err := rows.Scan(&p.ReservationID, &p.BetID, &p.Action, &p.CreatedAt); err != nil {
+			return nil, err
+		}
+		pending = append(pending, p)
+	}
+	return pending, rows.Err()
+}
+
+func (r *PostgresBetRepository) StakeDone(reservationID string) error {
+	_, err := r.db.Exec(`DELETE FROM bet_pending_stakes WHERE reservation_id = $1`, reservationID)
+	return err
+}
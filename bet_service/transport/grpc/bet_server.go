@@ -3,75 +3,133 @@ package grpc
 import (
 	"bet_service/domain"
 	"bet_service/muchway/bet_service/proto/betpb"
-	"bet_service/transport/rabbitmq"
 	"bet_service/usecase"
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"muchway/pkg/auth"
+	"muchway/pkg/limits"
+	"muchway/pkg/money"
 )
 
 type BetServer struct {
 	betpb.UnimplementedBetServiceServer
-	usecase   *usecase.BetUsecase
-	publisher *rabbitmq.Publisher
+	usecase *usecase.BetUsecase
+	authz   *auth.Authorizer
 }
 
-func NewBetServer(u *usecase.BetUsecase, p *rabbitmq.Publisher) *BetServer {
-	return &BetServer{usecase: u, publisher: p}
+func NewBetServer(u *usecase.BetUsecase, authz *auth.Authorizer) *BetServer {
+	return &BetServer{usecase: u, authz: authz}
 }
 
-
 func (s *BetServer) CreateBet(ctx context.Context, req *betpb.CreateBetRequest) (*betpb.CreateBetResponse, error) {
-	bet := &domain.Bet{
-		ID:      uuid.New().String(),
-		UserID:  req.Bet.UserId,
-		EventID: req.Bet.EventId,
-		Amount:  req.Bet.Amount,
-		Odds:    req.Bet.Odds,
-		Status:  "pending",
+	if req.Bet == nil {
+		return nil, status.Error(codes.InvalidArgument, "bet must be provided")
 	}
-
-	if err := s.usecase.CreateBet(bet); err != nil {
+	// Bettors only place bets on their own account
+	if err := s.authz.RequireOwner(ctx, req.Bet.UserId); err != nil {
 		return nil, err
 	}
 
-	_ = s.publisher.PublishBetCreated(bet)
+	bet := &domain.Bet{
+		ID:             uuid.New().String(),
+		UserID:         req.Bet.UserId,
+		EventID:        req.Bet.EventId,
+		SelectionID:    req.Bet.SelectionId,
+		Amount:         money.FromProto(req.Bet.Amount),
+		Odds:           req.Bet.Odds,
+		Status:         "pending",
+		IdempotencyKey: req.IdempotencyKey,
+	}
+
+	if err := s.usecase.CreateBet(bet, oddsPolicyFromProto(req.OddsChangePolicy)); err != nil {
+		return nil, createBetError(err)
+	}
 
 	return &betpb.CreateBetResponse{
 		Bet: &betpb.Bet{
-			Id:      bet.ID,
-			UserId:  bet.UserID,
-			EventId: bet.EventID,
-			Amount:  bet.Amount,
-			Odds:    bet.Odds,
-			Status:  bet.Status,
-			Payout:  bet.Payout,
+			Id:          bet.ID,
+			UserId:      bet.UserID,
+			EventId:     bet.EventID,
+			SelectionId: bet.SelectionID,
+			Amount:      money.ToProto(bet.Amount),
+			Odds:        bet.Odds,
+			Status:      bet.Status,
+			Payout:      money.ToProto(bet.Payout),
 		},
 	}, nil
 }
 
+func oddsPolicyFromProto(p betpb.OddsChangePolicy) domain.OddsPolicy {
+	switch p {
+	case betpb.OddsChangePolicy_ODDS_CHANGE_POLICY_ACCEPT_HIGHER:
+		return domain.OddsPolicyAcceptHigher
+	case betpb.OddsChangePolicy_ODDS_CHANGE_POLICY_ACCEPT_ANY:
+		return domain.OddsPolicyAcceptAny
+	default:
+		return domain.OddsPolicyReject
+	}
+}
+
+// createBetError maps bet placement errors onto gRPC statuses. A price change
+// comes back as ABORTED with an OddsChanged detail carrying the current odds.
+func createBetError(err error) error {
+	var oddsErr *usecase.OddsChangedError
+	switch {
+	case errors.As(err, &oddsErr):
+		st := status.New(codes.Aborted, oddsErr.Error())
+		detailed, detailErr := st.WithDetails(&betpb.OddsChanged{
+			SelectionId:   oddsErr.SelectionID,
+			RequestedOdds: oddsErr.Requested,
+			CurrentOdds:   oddsErr.Current,
+		})
+		if detailErr != nil {
+			return st.Err()
+		}
+		return detailed.Err()
+	case errors.Is(err, usecase.ErrSelectionRequired), errors.Is(err, usecase.ErrOddsRequired), errors.Is(err, usecase.ErrInvalidStake),
+		errors.Is(err, domain.ErrIdempotencyKeyReused):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, usecase.ErrSelectionClosed), errors.Is(err, domain.ErrInsufficientFunds),
+		errors.Is(err, limits.ErrLimitExceeded), errors.Is(err, limits.ErrSelfExcluded):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
 
 func (s *BetServer) GetBetByID(ctx context.Context, req *betpb.GetBetByIDRequest) (*betpb.GetBetByIDResponse, error) {
 	bet, err := s.usecase.GetBetByID(req.Id)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.authz.RequireOwner(ctx, bet.UserID, auth.RoleTrader); err != nil {
+		return nil, err
+	}
 
 	return &betpb.GetBetByIDResponse{
 		Bet: &betpb.Bet{
-			Id:      bet.ID,
-			UserId:  bet.UserID,
-			EventId: bet.EventID,
-			Amount:  bet.Amount,
-			Odds:    bet.Odds,
-			Status:  bet.Status,
-			Payout:  bet.Payout,
+			Id:          bet.ID,
+			UserId:      bet.UserID,
+			EventId:     bet.EventID,
+			SelectionId: bet.SelectionID,
+			Amount:      money.ToProto(bet.Amount),
+			Odds:        bet.Odds,
+			Status:      bet.Status,
+			Payout:      money.ToProto(bet.Payout),
 		},
 	}, nil
 }
 
-
 func (s *BetServer) GetBetsByUserID(ctx context.Context, req *betpb.GetBetsByUserIDRequest) (*betpb.GetBetsByUserIDResponse, error) {
+	if err := s.authz.RequireOwner(ctx, req.UserId, auth.RoleTrader); err != nil {
+		return nil, err
+	}
+
 	bets, err := s.usecase.GetBetsByUserID(req.UserId)
 	if err != nil {
 		return nil, err
@@ -80,29 +138,30 @@ func (s *BetServer) GetBetsByUserID(ctx context.Context, req *betpb.GetBetsByUse
 	var pbBets []*betpb.Bet
 	for _, bet := range bets {
 		pbBets = append(pbBets, &betpb.Bet{
-			Id:      bet.ID,
-			UserId:  bet.UserID,
-			EventId: bet.EventID,
-			Amount:  bet.Amount,
-			Odds:    bet.Odds,
-			Status:  bet.Status,
-			Payout:  bet.Payout,
+			Id:          bet.ID,
+			UserId:      bet.UserID,
+			EventId:     bet.EventID,
+			SelectionId: bet.SelectionID,
+			Amount:      money.ToProto(bet.Amount),
+			Odds:        bet.Odds,
+			Status:      bet.Status,
+			Payout:      money.ToProto(bet.Payout),
 		})
 	}
 
 	return &betpb.GetBetsByUserIDResponse{Bets: pbBets}, nil
 }
 
-
 func (s *BetServer) UpdateBet(ctx context.Context, req *betpb.UpdateBetRequest) (*betpb.UpdateBetResponse, error) {
 	bet := &domain.Bet{
-		ID:      req.Bet.Id,
-		UserID:  req.Bet.UserId,
-		EventID: req.Bet.EventId,
-		Amount:  req.Bet.Amount,
-		Odds:    req.Bet.Odds,
-		Status:  req.Bet.Status,
-		Payout:  req.Bet.Payout,
+		ID:          req.Bet.Id,
+		UserID:      req.Bet.UserId,
+		EventID:     req.Bet.EventId,
+		SelectionID: req.Bet.SelectionId,
+		Amount:      money.FromProto(req.Bet.Amount),
+		Odds:        req.Bet.Odds,
+		Status:      req.Bet.Status,
+		Payout:      money.FromProto(req.Bet.Payout),
 	}
 
 	if err := s.usecase.UpdateBet(bet); err != nil {
@@ -111,18 +170,18 @@ func (s *BetServer) UpdateBet(ctx context.Context, req *betpb.UpdateBetRequest)
 
 	return &betpb.UpdateBetResponse{
 		Bet: &betpb.Bet{
-			Id:      bet.ID,
-			UserId:  bet.UserID,
-			EventId: bet.EventID,
-			Amount:  bet.Amount,
-			Odds:    bet.Odds,
-			Status:  bet.Status,
-			Payout:  bet.Payout,
+			Id:          bet.ID,
+			UserId:      bet.UserID,
+			EventId:     bet.EventID,
+			SelectionId: bet.SelectionID,
+			Amount:      money.ToProto(bet.Amount),
+			Odds:        bet.Odds,
+			Status:      bet.Status,
+			Payout:      money.ToProto(bet.Payout),
 		},
 	}, nil
 }
 
-
 func (s *BetServer) DeleteBet(ctx context.Context, req *betpb.DeleteBetRequest) (*betpb.DeleteBetResponse, error) {
 	err := s.usecase.DeleteBet(req.Id)
 	if err != nil {
@@ -0,0 +1,21 @@
+package grpc
+
+import (
+	"bet_service/muchway/bet_service/proto/betpb"
+
+	"muchway/pkg/auth"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Policy says who may call each BetService method. Bettors place and read
+// their own bets, which BetServer checks; changing or removing a bet is
+// left to admins. Health checks are open.
+var Policy = auth.Policy{
+	betpb.BetService_CreateBet_FullMethodName:       {auth.RoleBettor},
+	betpb.BetService_GetBetByID_FullMethodName:      {auth.RoleBettor, auth.RoleTrader, auth.RoleService},
+	betpb.BetService_GetBetsByUserID_FullMethodName: {auth.RoleBettor, auth.RoleTrader, auth.RoleService},
+
+	healthpb.Health_Check_FullMethodName: {auth.Anyone},
+	healthpb.Health_Watch_FullMethodName: {auth.Anyone},
+}
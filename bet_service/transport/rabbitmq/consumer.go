@@ -1,39 +1,41 @@
 package rabbitmq
 
 import (
-	"log"
+	"context"
 
-	"github.com/rabbitmq/amqp091-go"
+	"github.com/streadway/amqp"
+
+	"muchway/pkg/consumer"
 )
 
 type Consumer interface {
-	Consume(queue string, handler func([]byte)) error
+	// Consume acks a message once handler returns nil; failed messages are
+	// retried and finally dead-lettered.
+	Consume(queue string, handler func([]byte) error) error
+	// Shutdown stops consuming and waits for the messages being handled.
+	Shutdown(ctx context.Context) error
+	// Check fails once a subscription has stopped other than by Shutdown.
+	Check(ctx context.Context) error
 }
 
-type amqpConsumer struct {
-	ch *amqp091.Channel
+// NewConsumer consumes over its own connection, made with the client library
+// of the shared consumer package, so publishing cannot hold up consuming.
+func NewConsumer(conn *amqp.Connection) (Consumer, error) {
+	return &amqpConsumer{c: consumer.New(conn)}, nil
 }
 
-func NewConsumer(conn *amqp091.Connection) (Consumer, error) {
-	ch, err := conn.Channel()
-	if err != nil {
-		return nil, err
-	}
-	return &amqpConsumer{ch: ch}, nil
+type amqpConsumer struct {
+	c *consumer.Consumer
 }
 
-func (c *amqpConsumer) Consume(queue string, handler func([]byte)) error {
-	msgs, err := c.ch.Consume(queue, "", true, false, false, false, nil)
-	if err != nil {
-		return err
-	}
+func (c *amqpConsumer) Consume(queue string, handler func([]byte) error) error {
+	return c.c.Consume(queue, handler)
+}
 
-	go func() {
-		for msg := range msgs {
-			log.Printf("Received on %s: %s", queue, string(msg.Body))
-			handler(msg.Body)
-		}
-	}()
+func (c *amqpConsumer) Shutdown(ctx context.Context) error {
+	return c.c.Shutdown(ctx)
+}
 
-	return nil
+func (c *amqpConsumer) Check(ctx context.Context) error {
+	return c.c.Check(ctx)
 }
@@ -5,100 +5,56 @@ import (
 	"encoding/json"
 	"log"
 
-	"github.com/rabbitmq/amqp091-go"
+	"github.com/streadway/amqp"
+
+	"muchway/pkg/events"
+	"muchway/pkg/topology"
 )
 
+// Publisher publishes messages directly, without going through the outbox.
 type Publisher struct {
-	channel *amqp091.Channel
-	queue   amqp091.Queue
+	channel *amqp.Channel
 }
 
-func NewPublisher(conn *amqp091.Connection) (*Publisher, error) {
+func NewPublisher(conn *amqp.Connection) (*Publisher, error) {
 	ch, err := conn.Channel()
 	if err != nil {
 		return nil, err
 	}
 
-	q, err := ch.QueueDeclare(
-		"bet.created",
-		true,
-		false,
-		false,
-		false,
-		nil,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	return &Publisher{channel: ch, queue: q}, nil
+	return &Publisher{channel: ch}, nil
 }
 
-func (p *Publisher) PublishBetCreated(bet *domain.Bet) error {
-	body, err := json.Marshal(bet)
+func (p *Publisher) PublishBetRejected(bet *domain.Bet, reason string) error {
+	env, err := domain.EventSource.New(topology.BetRejected, events.BetRejectedVersion, bet.ID,
+		events.BetRejectedV1{Bet: domain.BetPayload(bet), Reason: reason})
 	if err != nil {
 		return err
 	}
-
-	err = p.channel.Publish(
-		"",
-		p.queue.Name,
-		false,
-		false,
-		amqp091.Publishing{
-			ContentType: "application/json",
-			Body:        body,
-		},
-	)
-	if err != nil {
-		log.Printf("Failed to publish message: %v", err)
-		return err
-	}
-
-	log.Println("Published bet.created event:", string(body))
-	return nil
-}
-func (p *Publisher) PublishBetUpdated(bet *domain.Bet) error {
-	return p.publish("bet.updated", bet)
-}
-
-func (p *Publisher) PublishBetDeleted(bet *domain.Bet) error {
-	return p.publish("bet.deleted", bet)
+	return p.publish(topology.BetRejected, env)
 }
 
-func (p *Publisher) publish(queueName string, bet *domain.Bet) error {
-	body, err := json.Marshal(bet)
-	if err != nil {
-		return err
-	}
-
-	_, err = p.channel.QueueDeclare(
-		queueName,
-		true,
-		false,
-		false,
-		false,
-		nil,
-	)
+func (p *Publisher) publish(routingKey string, msg interface{}) error {
+	body, err := json.Marshal(msg)
 	if err != nil {
 		return err
 	}
 
 	err = p.channel.Publish(
-		"",
-		queueName,
+		topology.ExchangeBets,
+		routingKey,
 		false,
 		false,
-		amqp091.Publishing{
+		amqp.Publishing{
 			ContentType: "application/json",
 			Body:        body,
 		},
 	)
 	if err != nil {
-		log.Printf("Failed to publish to %s: %v", queueName, err)
+		log.Printf("Failed to publish %s: %v", routingKey, err)
 		return err
 	}
 
-	log.Printf(" Published %s event: %s", queueName, string(body))
+	log.Printf(" Published %s event: %s", routingKey, string(body))
 	return nil
 }
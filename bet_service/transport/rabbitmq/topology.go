@@ -0,0 +1,20 @@
+package rabbitmq
+
+import "muchway/pkg/topology"
+
+// Queues consumed by bet_service.
+const (
+	QueueEventSettled = "bet_service.event.settled"
+	QueueUserCreated  = "bet_service.user.created"
+	QueueUserLoggedIn = "bet_service.user.logged_in"
+	QueueUserExcluded = "bet_service.user.excluded"
+)
+
+// Queues lists the queues bet_service declares at startup with their
+// bindings.
+var Queues = []topology.Queue{
+	{Name: QueueEventSettled, Bindings: []topology.Binding{{Exchange: topology.ExchangeEvents, Key: topology.EventSettled}}},
+	{Name: QueueUserCreated, Bindings: []topology.Binding{{Exchange: topology.ExchangeUsers, Key: topology.UserCreated}}},
+	{Name: QueueUserLoggedIn, Bindings: []topology.Binding{{Exchange: topology.ExchangeUsers, Key: topology.UserLoggedIn}}},
+	{Name: QueueUserExcluded, Bindings: []topology.Binding{{Exchange: topology.ExchangeUsers, Key: topology.UserExcluded}}},
+}
@@ -5,43 +5,271 @@ import (
 	"bet_service/repository"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math"
 	"time"
+
+	"muchway/pkg/events"
+	"muchway/pkg/limits"
+	"muchway/pkg/outbox"
+	"muchway/pkg/topology"
+)
+
+var (
+	ErrSelectionRequired = errors.New("selection_id is required")
+	ErrSelectionClosed   = errors.New("selection is not open for betting")
+	ErrOddsRequired      = errors.New("odds are required unless any price is accepted")
+	ErrInvalidStake      = errors.New("amount must be greater than zero")
 )
 
+// OddsChangedError is returned when the selection is no longer offered at the
+// requested odds and the odds policy does not allow the new price.
+type OddsChangedError struct {
+	SelectionID string
+	Requested   float64
+	Current     float64
+}
+
+func (e *OddsChangedError) Error() string {
+	return fmt.Sprintf("odds for selection %s changed from %.2f to %.2f", e.SelectionID, e.Requested, e.Current)
+}
+
 type BetUsecase struct {
-	betRepo   repository.BetRepository
-	publisher domain.BetEventPublisher
+	betRepo    repository.BetRepository
+	publisher  domain.BetEventPublisher
+	selections domain.SelectionProvider
+	stakes     domain.StakeReserver
+	limits     domain.LimitProvider
 }
 
-func NewBetUsecase(betRepo repository.BetRepository, publisher domain.BetEventPublisher) *BetUsecase {
-	return &BetUsecase{betRepo: betRepo, publisher: publisher}
+func NewBetUsecase(betRepo repository.BetRepository, publisher domain.BetEventPublisher, selections domain.SelectionProvider, stakes domain.StakeReserver, limits domain.LimitProvider) *BetUsecase {
+	return &BetUsecase{betRepo: betRepo, publisher: publisher, selections: selections, stakes: stakes, limits: limits}
 }
 
-func (u *BetUsecase) CreateBet(bet *domain.Bet) error {
+// CreateBet places a bet on a selection at the price currently offered by
+// event_service. bet.Odds holds the price the bettor asked for; the policy
+// decides whether a different current price is accepted. A bet with an
+// idempotency key that was already placed is returned as it was stored. A
+// bet from a self-excluded bettor, or one that would break their stake or
+// loss limits, is refused.
+func (u *BetUsecase) CreateBet(bet *domain.Bet, policy domain.OddsPolicy) error {
+	if bet.SelectionID == "" {
+		return ErrSelectionRequired
+	}
+	if !bet.Amount.IsPositive() {
+		return ErrInvalidStake
+	}
+	if bet.IdempotencyKey != "" {
+		existing, err := u.betRepo.FindByIdempotencyKey(bet.UserID, bet.IdempotencyKey)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return replayBet(bet, existing)
+		}
+	}
+	exclusion, err := u.betRepo.GetExclusion(bet.UserID)
+	if err != nil {
+		return err
+	}
+	if exclusion != nil && exclusion.Active(time.Now()) {
+		return exclusion.Err()
+	}
+	sel, err := u.selections.GetSelection(bet.SelectionID)
+	if err != nil {
+		return err
+	}
+	if !sel.Open {
+		return ErrSelectionClosed
+	}
+	if bet.EventID != "" && bet.EventID != sel.EventID {
+		return fmt.Errorf("selection %s does not belong to event %s", sel.ID, bet.EventID)
+	}
+	if err := checkOdds(sel, bet.Odds, policy); err != nil {
+		return err
+	}
+	bet.EventID = sel.EventID
+	bet.Odds = sel.Price
+
+	// Limits are checked before the stake is held, and again as the bet is
+	// stored in case another bet got there first
+	ls, err := u.limits.GetLimits(bet.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get limits: %w", err)
+	}
+	check := func(totals repository.TotalsFunc) error { return checkLimits(bet, ls, totals) }
+	if err := check(u.betRepo.Totals); err != nil {
+		u.rejectOverLimit(bet, err)
+		return err
+	}
+
+	// Hold the stake before the bet exists so it can never be unfunded
+	reservationID, err := u.stakes.ReserveStake(bet)
+	if errors.Is(err, domain.ErrInsufficientFunds) {
+		if pubErr := u.publisher.PublishBetRejected(bet, err.Error()); pubErr != nil {
+			log.Printf("Failed to publish bet.rejected for %s: %v", bet.ID, pubErr)
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	bet.ReservationID = reservationID
+
 	now := time.Now()
 	bet.CreatedAt = now
 	bet.UpdatedAt = now
-	bet.Status = "pending"
-	if err := u.betRepo.Create(bet); err != nil {
+	bet.Status = domain.BetStatusPending
+	created, err := betMessage(topology.BetCreated, bet)
+	if err != nil {
+		u.releaseStake(reservationID, bet.ID)
+		return err
+	}
+	if err := u.betRepo.Create(bet, check, created); err != nil {
+		u.releaseStake(reservationID, bet.ID)
+		u.rejectOverLimit(bet, err)
+		if errors.Is(err, domain.ErrDuplicateIdempotencyKey) {
+			// A concurrent request with the same key got there first
+			existing, findErr := u.betRepo.FindByIdempotencyKey(bet.UserID, bet.IdempotencyKey)
+			if findErr == nil && existing != nil {
+				return replayBet(bet, existing)
+			}
+		}
 		return err
 	}
-	return u.publisher.PublishBetCreated(bet)
+
+	// The capture was stored with the bet, so SettleStakes retries it if
+	// this attempt fails
+	if err := u.stakes.CaptureStake(reservationID); err != nil {
+		log.Printf("Failed to capture stake reservation %s for bet %s, will retry: %v", reservationID, bet.ID, err)
+		return nil
+	}
+	u.stakeDone(reservationID)
+	return nil
+}
+
+// releaseStake returns the stake of a bet that was not stored. The release
+// is recorded first so that SettleStakes retries it if this attempt fails.
+func (u *BetUsecase) releaseStake(reservationID, betID string) {
+	if err := u.betRepo.QueueStakeRelease(reservationID, betID); err != nil {
+		log.Printf("Failed to record the release of stake reservation %s for bet %s: %v", reservationID, betID, err)
+	}
+	if err := u.stakes.ReleaseStake(reservationID); err != nil {
+		log.Printf("Failed to release stake reservation %s for bet %s, will retry: %v", reservationID, betID, err)
+		return
+	}
+	u.stakeDone(reservationID)
+}
+
+// stakeDone forgets a reservation that was captured or released. If that
+// fails SettleStakes repeats the call, which is harmless.
+func (u *BetUsecase) stakeDone(reservationID string) {
+	if err := u.betRepo.StakeDone(reservationID); err != nil {
+		log.Printf("Failed to clear stake reservation %s: %v", reservationID, err)
+	}
+}
+
+// SettleStakes retries up to batch stake captures and releases that failed
+// when a bet was placed, and returns how many succeeded. A reservation whose
+// call fails again stays pending for the next run.
+func (u *BetUsecase) SettleStakes(batch int) (int, error) {
+	pending, err := u.betRepo.PendingStakes(batch)
+	if err != nil {
+		return 0, err
+	}
+	done := 0
+	for _, p := range pending {
+		settle := u.stakes.CaptureStake
+		if p.Action == domain.StakeRelease {
+			settle = u.stakes.ReleaseStake
+		}
+		if err := settle(p.ReservationID); err != nil {
+			log.Printf("Failed to %s stake reservation %s for bet %s: %v", p.Action, p.ReservationID, p.BetID, err)
+			continue
+		}
+		if err := u.betRepo.StakeDone(p.ReservationID); err != nil {
+			return done, err
+		}
+		done++
+	}
+	return done, nil
+}
+
+// checkLimits refuses a bet that would take the bettor over a stake limit,
+// or over a loss limit if it were lost. Losses are stakes less payouts, so
+// open bets count as lost until they are settled.
+func checkLimits(bet *domain.Bet, ls []limits.Limit, totals repository.TotalsFunc) error {
+	now := time.Now()
+	for _, l := range ls {
+		if l.Kind != limits.Stake && l.Kind != limits.Loss {
+			continue
+		}
+		staked, returned, err := totals(bet.UserID, l.Period.Since(now))
+		if err != nil {
+			return err
+		}
+		used := staked
+		if l.Kind == limits.Loss {
+			if used, err = staked.Sub(returned); err != nil {
+				return err
+			}
+		}
+		if err := l.Check(used, bet.Amount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rejectOverLimit announces that a bet was refused for breaking a limit.
+func (u *BetUsecase) rejectOverLimit(bet *domain.Bet, err error) {
+	if !errors.Is(err, limits.ErrLimitExceeded) {
+		return
+	}
+	if pubErr := u.publisher.PublishBetRejected(bet, err.Error()); pubErr != nil {
+		log.Printf("Failed to publish bet.rejected for %s: %v", bet.ID, pubErr)
+	}
+}
+
+// RecordExclusion stores a self-exclusion announced by user_service.
+func (u *BetUsecase) RecordExclusion(userID string, e limits.Exclusion) error {
+	return u.betRepo.SaveExclusion(userID, e)
+}
+
+// replayBet answers a repeated request with the bet placed by the first one,
+// provided both asked for the same selection and stake.
+func replayBet(bet, existing *domain.Bet) error {
+	if existing.SelectionID != bet.SelectionID || existing.Amount != bet.Amount {
+		return domain.ErrIdempotencyKeyReused
+	}
+	log.Printf("Replaying bet %s for idempotency key %s", existing.ID, bet.IdempotencyKey)
+	*bet = *existing
+	return nil
+}
+
+// PurgeIdempotencyKeys forgets idempotency keys older than the retention
+// window; a key may be reused once it has been purged.
+func (u *BetUsecase) PurgeIdempotencyKeys(retention time.Duration) (int64, error) {
+	return u.betRepo.PurgeIdempotencyKeys(time.Now().Add(-retention))
 }
 
 func (u *BetUsecase) UpdateBet(bet *domain.Bet) error {
 	bet.UpdatedAt = time.Now()
 
-	if err := u.betRepo.Update(bet); err != nil {
+	updated, err := betMessage(topology.BetUpdated, bet)
+	if err != nil {
+		return err
+	}
+	if err := u.betRepo.Update(bet, updated); err != nil {
 		return err
 	}
-
 
 	key := fmt.Sprintf("bet:%s", bet.ID)
 	repository.RedisClient.Del(context.Background(), key)
 
-	return u.publisher.PublishBetUpdated(bet)
+	return nil
 }
 
 func (u *BetUsecase) DeleteBet(id string) error {
@@ -50,20 +278,32 @@ func (u *BetUsecase) DeleteBet(id string) error {
 		return err
 	}
 
-	if err := u.betRepo.Delete(id); err != nil {
+	deleted, err := betMessage(topology.BetDeleted, bet)
+	if err != nil {
+		return err
+	}
+	if err := u.betRepo.Delete(id, deleted); err != nil {
 		return err
 	}
 
 	key := fmt.Sprintf("bet:%s", id)
 	repository.RedisClient.Del(context.Background(), key)
 
-	return u.publisher.PublishBetDeleted(bet)
+	return nil
+}
+
+// betMessage is an outbox message for the bets exchange.
+func betMessage(routingKey string, bet *domain.Bet) (outbox.Message, error) {
+	env, err := domain.EventSource.New(routingKey, events.BetVersion, bet.ID, domain.BetPayload(bet))
+	if err != nil {
+		return outbox.Message{}, err
+	}
+	return outbox.Message{Exchange: topology.ExchangeBets, RoutingKey: routingKey, Payload: env}, nil
 }
 
 func (u *BetUsecase) GetBetByID(id string) (*domain.Bet, error) {
 	key := fmt.Sprintf("bet:%s", id)
 
-
 	val, err := repository.RedisClient.Get(context.Background(), key).Result()
 	if err == nil {
 		var cachedBet domain.Bet
@@ -75,13 +315,11 @@ func (u *BetUsecase) GetBetByID(id string) (*domain.Bet, error) {
 
 	log.Println("Cache MISS:", key)
 
-	
 	bet, err := u.betRepo.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
 
-
 	bytes, _ := json.Marshal(bet)
 	repository.RedisClient.Set(context.Background(), key, bytes, 5*time.Minute)
 
@@ -91,3 +329,20 @@ func (u *BetUsecase) GetBetByID(id string) (*domain.Bet, error) {
 func (u *BetUsecase) GetBetsByUserID(userID string) ([]*domain.Bet, error) {
 	return u.betRepo.GetByUserID(userID)
 }
+
+func checkOdds(sel *domain.Selection, requested float64, policy domain.OddsPolicy) error {
+	if policy == domain.OddsPolicyAcceptAny {
+		return nil
+	}
+	if requested <= 0 {
+		return ErrOddsRequired
+	}
+
+	// Prices are quoted to two decimals
+	current := math.Round(sel.Price * 100)
+	asked := math.Round(requested * 100)
+	if current == asked || (policy == domain.OddsPolicyAcceptHigher && current > asked) {
+		return nil
+	}
+	return &OddsChangedError{SelectionID: sel.ID, Requested: requested, Current: sel.Price}
+}
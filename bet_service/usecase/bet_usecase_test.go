@@ -3,8 +3,14 @@ package usecase
 import (
 	"bet_service/domain"
 	"bet_service/repository"
+	"errors"
+	"muchway/pkg/limits"
+	"muchway/pkg/money"
+	"muchway/pkg/outbox"
+	"muchway/pkg/topology"
 	"os"
 	"testing"
+	"time"
 )
 
 // --- Моки ---
@@ -14,23 +20,87 @@ type mockBetRepo struct {
 	updateCalled bool
 	deleteCalled bool
 	getByIDFunc  func(id string) (*domain.Bet, error)
+	createErr    error
+	byKey        map[string]*domain.Bet
+	outbox       []outbox.Message
+	staked       money.Money
+	returned     money.Money
+	exclusion    *limits.Exclusion
+	// placedMeanwhile is staked by bets stored after the early limit check
+	placedMeanwhile money.Money
+	// pending holds the stake reservations still to be captured or released
+	pending map[string]domain.PendingStake
 }
 
-func (m *mockBetRepo) Create(bet *domain.Bet) error {
+func (m *mockBetRepo) Create(bet *domain.Bet, check repository.LimitCheck, msgs ...outbox.Message) error {
 	m.createCalled = true
+	if m.createErr != nil {
+		return m.createErr
+	}
+	if check != nil {
+		err := check(func(string, time.Time) (money.Money, money.Money, error) {
+			staked, err := m.staked.Add(m.placedMeanwhile)
+			return staked, m.returned, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if bet.ReservationID != "" {
+		m.queueStake(bet.ReservationID, bet.ID, domain.StakeCapture)
+	}
+	m.outbox = append(m.outbox, msgs...)
+	return nil
+}
+
+func (m *mockBetRepo) queueStake(reservationID, betID, action string) {
+	if m.pending == nil {
+		m.pending = map[string]domain.PendingStake{}
+	}
+	m.pending[reservationID] = domain.PendingStake{ReservationID: reservationID, BetID: betID, Action: action, CreatedAt: time.Now()}
+}
+
+func (m *mockBetRepo) QueueStakeRelease(reservationID, betID string) error {
+	m.queueStake(reservationID, betID, domain.StakeRelease)
 	return nil
 }
 
-func (m *mockBetRepo) Update(bet *domain.Bet) error {
+func (m *mockBetRepo) PendingStakes(limit int) ([]domain.PendingStake, error) {
+	var pending []domain.PendingStake
+	for _, p := range m.pending {
+		pending = append(pending, p)
+	}
+	return pending, nil
+}
+
+func (m *mockBetRepo) StakeDone(reservationID string) error {
+	delete(m.pending, reservationID)
+	return nil
+}
+
+func (m *mockBetRepo) Update(bet *domain.Bet, msgs ...outbox.Message) error {
 	m.updateCalled = true
+	m.outbox = append(m.outbox, msgs...)
 	return nil
 }
 
-func (m *mockBetRepo) Delete(id string) error {
+func (m *mockBetRepo) Delete(id string, msgs ...outbox.Message) error {
 	m.deleteCalled = true
+	m.outbox = append(m.outbox, msgs...)
 	return nil
 }
 
+// queued reports whether a message with the routing key was written to the
+// outbox.
+func (m *mockBetRepo) queued(routingKey string) bool {
+	for _, msg := range m.outbox {
+		if msg.RoutingKey == routingKey {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *mockBetRepo) GetByID(id string) (*domain.Bet, error) {
 	if m.getByIDFunc != nil {
 		return m.getByIDFunc(id)
@@ -44,55 +114,405 @@ func (m *mockBetRepo) GetByUserID(userID string) ([]*domain.Bet, error) {
 	}, nil
 }
 
+func (m *mockBetRepo) GetByEventID(eventID string) ([]*domain.Bet, error) {
+	return []*domain.Bet{
+		{ID: "bet123", EventID: eventID},
+	}, nil
+}
+
+func (m *mockBetRepo) Settle(bet *domain.Bet) (bool, error) {
+	return true, nil
+}
+
+func (m *mockBetRepo) MarkPaidOut(id string) (bool, error) {
+	return true, nil
+}
+
+func (m *mockBetRepo) UnmarkPaidOut(id string) error {
+	return nil
+}
+
+func (m *mockBetRepo) FindByIdempotencyKey(userID, key string) (*domain.Bet, error) {
+	return m.byKey[userID+"/"+key], nil
+}
+
+func (m *mockBetRepo) PurgeIdempotencyKeys(before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockBetRepo) SaveExclusion(userID string, e limits.Exclusion) error {
+	m.exclusion = &e
+	return nil
+}
+
+func (m *mockBetRepo) GetExclusion(userID string) (*limits.Exclusion, error) {
+	return m.exclusion, nil
+}
+
+func (m *mockBetRepo) Totals(userID string, since time.Time) (money.Money, money.Money, error) {
+	return m.staked, m.returned, nil
+}
+
 type mockPublisher struct {
-	created bool
-	updated bool
-	deleted bool
+	rejected bool
 }
 
-func (m *mockPublisher) PublishBetCreated(bet *domain.Bet) error {
-	m.created = true
+func (m *mockPublisher) PublishBetRejected(bet *domain.Bet, reason string) error {
+	m.rejected = true
 	return nil
 }
 
-func (m *mockPublisher) PublishBetUpdated(bet *domain.Bet) error {
-	m.updated = true
+type mockSelections struct {
+	selection *domain.Selection
+}
+
+func (m *mockSelections) GetSelection(id string) (*domain.Selection, error) {
+	if m.selection != nil {
+		return m.selection, nil
+	}
+	return &domain.Selection{ID: id, EventID: "event1", Price: 2.5, Open: true}, nil
+}
+
+type mockStakes struct {
+	reserveErr error
+	captureErr error
+	releaseErr error
+	captured   bool
+	released   bool
+}
+
+func (m *mockStakes) ReserveStake(bet *domain.Bet) (string, error) {
+	if m.reserveErr != nil {
+		return "", m.reserveErr
+	}
+	return "res1", nil
+}
+
+func (m *mockStakes) CaptureStake(reservationID string) error {
+	if m.captureErr != nil {
+		return m.captureErr
+	}
+	m.captured = true
 	return nil
 }
 
-func (m *mockPublisher) PublishBetDeleted(bet *domain.Bet) error {
-	m.deleted = true
+func (m *mockStakes) ReleaseStake(reservationID string) error {
+	if m.releaseErr != nil {
+		return m.releaseErr
+	}
+	m.released = true
 	return nil
 }
 
+type mockLimits struct {
+	limits []limits.Limit
+}
+
+func (m *mockLimits) GetLimits(userID string) ([]limits.Limit, error) {
+	return m.limits, nil
+}
+
+// placedBet is a bet as stored after it was placed, complete enough to be
+// published.
+func placedBet(id string) *domain.Bet {
+	return &domain.Bet{ID: id, UserID: "user1", EventID: "event1", SelectionID: "sel1", Amount: money.MustParse("10"),
+		Odds: 2.5, Status: domain.BetStatusPending, CreatedAt: time.Now()}
+}
+
 // --- Тесты ---
 
 func TestCreateBet(t *testing.T) {
 	mockRepo := &mockBetRepo{}
 	mockPub := &mockPublisher{}
-	uc := NewBetUsecase(mockRepo, mockPub)
+	uc := NewBetUsecase(mockRepo, mockPub, &mockSelections{}, &mockStakes{}, &mockLimits{})
 
-	bet := &domain.Bet{ID: "bet123", UserID: "user1"}
+	bet := &domain.Bet{ID: "bet123", UserID: "user1", SelectionID: "sel1", Amount: money.MustParse("10"), Odds: 2.5}
 
-	err := uc.CreateBet(bet)
+	err := uc.CreateBet(bet, domain.OddsPolicyReject)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 
+	if bet.Odds != 2.5 || bet.EventID != "event1" {
+		t.Errorf("expected bet to take price and event from selection, got odds %.2f event %q", bet.Odds, bet.EventID)
+	}
+
 	if !mockRepo.createCalled {
 		t.Error("expected Create to be called")
 	}
-	if !mockPub.created {
-		t.Error("expected PublishBetCreated to be called")
+	if !mockRepo.queued(topology.BetCreated) {
+		t.Error("expected bet.created to be written to the outbox")
+	}
+}
+
+func TestCreateBetInsufficientFunds(t *testing.T) {
+	mockRepo := &mockBetRepo{}
+	mockPub := &mockPublisher{}
+	uc := NewBetUsecase(mockRepo, mockPub, &mockSelections{}, &mockStakes{reserveErr: domain.ErrInsufficientFunds}, &mockLimits{})
+
+	err := uc.CreateBet(&domain.Bet{ID: "bet123", UserID: "user1", SelectionID: "sel1", Amount: money.MustParse("10"), Odds: 2.5}, domain.OddsPolicyReject)
+	if !errors.Is(err, domain.ErrInsufficientFunds) {
+		t.Errorf("expected ErrInsufficientFunds, got %v", err)
+	}
+
+	if mockRepo.createCalled {
+		t.Error("expected Create not to be called")
+	}
+	if !mockPub.rejected {
+		t.Error("expected PublishBetRejected to be called")
+	}
+}
+
+func TestCreateBetLimits(t *testing.T) {
+	cases := []struct {
+		name     string
+		limit    limits.Limit
+		staked   string
+		returned string
+		refused  bool
+	}{
+		{"under stake limit", limits.Limit{Kind: limits.Stake, Period: limits.Day, Amount: money.MustParse("50")}, "40", "0", false},
+		{"over stake limit", limits.Limit{Kind: limits.Stake, Period: limits.Day, Amount: money.MustParse("50")}, "45", "0", true},
+		{"winnings offset losses", limits.Limit{Kind: limits.Loss, Period: limits.Week, Amount: money.MustParse("50")}, "100", "60", false},
+		{"over loss limit", limits.Limit{Kind: limits.Loss, Period: limits.Week, Amount: money.MustParse("50")}, "100", "55", true},
+		{"deposit limits do not apply", limits.Limit{Kind: limits.Deposit, Period: limits.Day, Amount: money.MustParse("1")}, "100", "0", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := &mockBetRepo{staked: money.MustParse(tc.staked), returned: money.MustParse(tc.returned)}
+			mockPub := &mockPublisher{}
+			stakes := &mockStakes{}
+			uc := NewBetUsecase(mockRepo, mockPub, &mockSelections{}, stakes, &mockLimits{limits: []limits.Limit{tc.limit}})
+
+			err := uc.CreateBet(&domain.Bet{ID: "bet123", UserID: "user1", SelectionID: "sel1", Amount: money.MustParse("10"), Odds: 2.5}, domain.OddsPolicyReject)
+			if !tc.refused {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, limits.ErrLimitExceeded) {
+				t.Fatalf("expected ErrLimitExceeded, got %v", err)
+			}
+			if mockRepo.createCalled || stakes.captured {
+				t.Error("expected the bet not to be placed")
+			}
+			if !mockPub.rejected {
+				t.Error("expected PublishBetRejected to be called")
+			}
+		})
+	}
+}
+
+func TestCreateBetRechecksLimitsWhenStoring(t *testing.T) {
+	mockRepo := &mockBetRepo{staked: money.MustParse("40"), placedMeanwhile: money.MustParse("10")}
+	mockPub := &mockPublisher{}
+	stakes := &mockStakes{}
+	limit := limits.Limit{Kind: limits.Stake, Period: limits.Day, Amount: money.MustParse("50")}
+	uc := NewBetUsecase(mockRepo, mockPub, &mockSelections{}, stakes, &mockLimits{limits: []limits.Limit{limit}})
+
+	err := uc.CreateBet(&domain.Bet{ID: "bet123", UserID: "user1", SelectionID: "sel1", Amount: money.MustParse("10"), Odds: 2.5}, domain.OddsPolicyReject)
+	if !errors.Is(err, limits.ErrLimitExceeded) {
+		t.Fatalf("expected ErrLimitExceeded, got %v", err)
+	}
+	if !stakes.released || stakes.captured {
+		t.Error("expected the stake reservation to be released")
+	}
+	if mockRepo.queued(topology.BetCreated) {
+		t.Error("expected bet.created not to be written to the outbox")
+	}
+	if !mockPub.rejected {
+		t.Error("expected PublishBetRejected to be called")
+	}
+}
+
+func TestCreateBetRefusesSelfExcludedBettor(t *testing.T) {
+	mockRepo := &mockBetRepo{}
+	stakes := &mockStakes{}
+	uc := NewBetUsecase(mockRepo, &mockPublisher{}, &mockSelections{}, stakes, &mockLimits{})
+
+	day, _ := limits.Exclude(limits.ExcludeDay, time.Now())
+	if err := uc.RecordExclusion("user1", day); err != nil {
+		t.Fatal(err)
+	}
+	err := uc.CreateBet(&domain.Bet{ID: "bet123", UserID: "user1", SelectionID: "sel1", Amount: money.MustParse("10"), Odds: 2.5}, domain.OddsPolicyReject)
+	if !errors.Is(err, limits.ErrSelfExcluded) {
+		t.Fatalf("expected ErrSelfExcluded, got %v", err)
+	}
+	if mockRepo.createCalled || stakes.captured {
+		t.Error("expected the bet not to be placed")
+	}
+
+	// Once the exclusion is over the bettor may bet again
+	over, _ := limits.Exclude(limits.ExcludeDay, time.Now().Add(-25*time.Hour))
+	mockRepo.exclusion = &over
+	if err := uc.CreateBet(&domain.Bet{ID: "bet124", UserID: "user1", SelectionID: "sel1", Amount: money.MustParse("10"), Odds: 2.5}, domain.OddsPolicyReject); err != nil {
+		t.Errorf("unexpected error after the exclusion ended: %v", err)
+	}
+}
+
+func TestCreateBetReleasesStakeWhenInsertFails(t *testing.T) {
+	mockRepo := &mockBetRepo{createErr: errors.New("db down")}
+	mockPub := &mockPublisher{}
+	stakes := &mockStakes{}
+	uc := NewBetUsecase(mockRepo, mockPub, &mockSelections{}, stakes, &mockLimits{})
+
+	err := uc.CreateBet(&domain.Bet{ID: "bet123", UserID: "user1", SelectionID: "sel1", Amount: money.MustParse("10"), Odds: 2.5}, domain.OddsPolicyReject)
+	if err == nil {
+		t.Fatal("expected error from failed insert")
+	}
+
+	if !stakes.released || len(mockRepo.pending) != 0 {
+		t.Errorf("released %v with %d pending, want the stake released and nothing pending", stakes.released, len(mockRepo.pending))
+	}
+	if stakes.captured {
+		t.Error("expected stake reservation not to be captured")
+	}
+	if mockRepo.queued(topology.BetCreated) {
+		t.Error("expected bet.created not to be written to the outbox")
+	}
+}
+
+func TestFailedCaptureIsRetried(t *testing.T) {
+	mockRepo := &mockBetRepo{}
+	stakes := &mockStakes{captureErr: errors.New("payment_service unavailable")}
+	uc := NewBetUsecase(mockRepo, &mockPublisher{}, &mockSelections{}, stakes, &mockLimits{})
+
+	bet := &domain.Bet{ID: "bet123", UserID: "user1", SelectionID: "sel1", Amount: money.MustParse("10"), Odds: 2.5}
+	if err := uc.CreateBet(bet, domain.OddsPolicyReject); err != nil {
+		t.Fatalf("CreateBet = %v, want the bet accepted", err)
+	}
+	if p, ok := mockRepo.pending["res1"]; !ok || p.Action != domain.StakeCapture || p.BetID != "bet123" {
+		t.Fatalf("pending stakes = %+v, want the capture of res1 for bet123", mockRepo.pending)
+	}
+
+	// The capture keeps failing
+	if n, err := uc.SettleStakes(10); err != nil || n != 0 {
+		t.Errorf("SettleStakes = %d, %v, want nothing settled", n, err)
+	}
+	if _, ok := mockRepo.pending["res1"]; !ok {
+		t.Error("a failed retry forgot the capture")
+	}
+
+	stakes.captureErr = nil
+	if n, err := uc.SettleStakes(10); err != nil || n != 1 {
+		t.Errorf("SettleStakes = %d, %v, want 1 settled", n, err)
+	}
+	if !stakes.captured || len(mockRepo.pending) != 0 {
+		t.Errorf("captured %v with %d pending, want the stake captured and nothing pending", stakes.captured, len(mockRepo.pending))
+	}
+}
+
+func TestFailedReleaseIsRetried(t *testing.T) {
+	mockRepo := &mockBetRepo{createErr: errors.New("db down")}
+	stakes := &mockStakes{releaseErr: errors.New("payment_service unavailable")}
+	uc := NewBetUsecase(mockRepo, &mockPublisher{}, &mockSelections{}, stakes, &mockLimits{})
+
+	bet := &domain.Bet{ID: "bet123", UserID: "user1", SelectionID: "sel1", Amount: money.MustParse("10"), Odds: 2.5}
+	if err := uc.CreateBet(bet, domain.OddsPolicyReject); err == nil {
+		t.Fatal("expected error from failed insert")
+	}
+	if p, ok := mockRepo.pending["res1"]; !ok || p.Action != domain.StakeRelease {
+		t.Fatalf("pending stakes = %+v, want the release of res1", mockRepo.pending)
+	}
+
+	stakes.releaseErr = nil
+	if n, err := uc.SettleStakes(10); err != nil || n != 1 {
+		t.Errorf("SettleStakes = %d, %v, want 1 settled", n, err)
+	}
+	if !stakes.released || stakes.captured || len(mockRepo.pending) != 0 {
+		t.Errorf("released %v, captured %v with %d pending, want only released", stakes.released, stakes.captured, len(mockRepo.pending))
+	}
+}
+
+func TestCreateBetReplaysIdempotencyKey(t *testing.T) {
+	placed := &domain.Bet{ID: "bet1", UserID: "user1", SelectionID: "sel1", Amount: money.MustParse("10"), Odds: 2.5,
+		Status: domain.BetStatusPending, IdempotencyKey: "k1"}
+	mockRepo := &mockBetRepo{byKey: map[string]*domain.Bet{"user1/k1": placed}}
+	mockPub := &mockPublisher{}
+	stakes := &mockStakes{}
+	uc := NewBetUsecase(mockRepo, mockPub, &mockSelections{}, stakes, &mockLimits{})
+
+	bet := &domain.Bet{ID: "bet2", UserID: "user1", SelectionID: "sel1", Amount: money.MustParse("10"), Odds: 2.5, IdempotencyKey: "k1"}
+	if err := uc.CreateBet(bet, domain.OddsPolicyReject); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bet.ID != "bet1" {
+		t.Errorf("expected the original bet to be returned, got %s", bet.ID)
+	}
+	if mockRepo.createCalled || stakes.captured || mockRepo.queued(topology.BetCreated) {
+		t.Error("expected a replay not to place, fund or publish the bet again")
+	}
+
+	other := &domain.Bet{ID: "bet3", UserID: "user1", SelectionID: "sel1", Amount: money.MustParse("20"), Odds: 2.5, IdempotencyKey: "k1"}
+	if err := uc.CreateBet(other, domain.OddsPolicyReject); !errors.Is(err, domain.ErrIdempotencyKeyReused) {
+		t.Errorf("expected ErrIdempotencyKeyReused, got %v", err)
+	}
+}
+
+func TestCreateBetRejectsClosedSelection(t *testing.T) {
+	mockRepo := &mockBetRepo{}
+	mockPub := &mockPublisher{}
+	uc := NewBetUsecase(mockRepo, mockPub, &mockSelections{
+		selection: &domain.Selection{ID: "sel1", EventID: "event1", Price: 2.5},
+	}, &mockStakes{}, &mockLimits{})
+
+	err := uc.CreateBet(&domain.Bet{ID: "bet123", UserID: "user1", SelectionID: "sel1", Amount: money.MustParse("10"), Odds: 2.5}, domain.OddsPolicyReject)
+	if err != ErrSelectionClosed {
+		t.Errorf("expected ErrSelectionClosed, got %v", err)
+	}
+
+	if mockRepo.createCalled {
+		t.Error("expected Create not to be called")
+	}
+}
+
+func TestCreateBetOddsPolicy(t *testing.T) {
+	cases := []struct {
+		name      string
+		requested float64
+		policy    domain.OddsPolicy
+		wantErr   bool
+	}{
+		{"reject on drift up", 2.4, domain.OddsPolicyReject, true},
+		{"accept higher price", 2.4, domain.OddsPolicyAcceptHigher, false},
+		{"reject lower price", 2.6, domain.OddsPolicyAcceptHigher, true},
+		{"accept any price", 3.0, domain.OddsPolicyAcceptAny, false},
+		{"accept any without odds", 0, domain.OddsPolicyAcceptAny, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			uc := NewBetUsecase(&mockBetRepo{}, &mockPublisher{}, &mockSelections{}, &mockStakes{}, &mockLimits{})
+			bet := &domain.Bet{ID: "bet123", UserID: "user1", SelectionID: "sel1", Amount: money.MustParse("10"), Odds: tc.requested}
+
+			err := uc.CreateBet(bet, tc.policy)
+			if tc.wantErr {
+				oddsErr, ok := err.(*OddsChangedError)
+				if !ok {
+					t.Fatalf("expected OddsChangedError, got %v", err)
+				}
+				if oddsErr.Current != 2.5 {
+					t.Errorf("expected current odds 2.5 in error, got %.2f", oddsErr.Current)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if bet.Odds != 2.5 {
+				t.Errorf("expected bet placed at current odds 2.5, got %.2f", bet.Odds)
+			}
+		})
 	}
 }
 
 func TestUpdateBet(t *testing.T) {
 	mockRepo := &mockBetRepo{}
 	mockPub := &mockPublisher{}
-	uc := NewBetUsecase(mockRepo, mockPub)
+	uc := NewBetUsecase(mockRepo, mockPub, &mockSelections{}, &mockStakes{}, &mockLimits{})
 
-	bet := &domain.Bet{ID: "bet123", UserID: "user1"}
+	bet := placedBet("bet123")
 
 	err := uc.UpdateBet(bet)
 	if err != nil {
@@ -102,8 +522,8 @@ func TestUpdateBet(t *testing.T) {
 	if !mockRepo.updateCalled {
 		t.Error("expected Update to be called")
 	}
-	if !mockPub.updated {
-		t.Error("expected PublishBetUpdated to be called")
+	if !mockRepo.queued(topology.BetUpdated) {
+		t.Error("expected bet.updated to be written to the outbox")
 	}
 }
 
@@ -111,10 +531,10 @@ func TestDeleteBet(t *testing.T) {
 	mockRepo := &mockBetRepo{}
 	mockPub := &mockPublisher{}
 	mockRepo.getByIDFunc = func(id string) (*domain.Bet, error) {
-		return &domain.Bet{ID: id}, nil
+		return placedBet(id), nil
 	}
 
-	uc := NewBetUsecase(mockRepo, mockPub)
+	uc := NewBetUsecase(mockRepo, mockPub, &mockSelections{}, &mockStakes{}, &mockLimits{})
 
 	err := uc.DeleteBet("bet123")
 	if err != nil {
@@ -124,8 +544,8 @@ func TestDeleteBet(t *testing.T) {
 	if !mockRepo.deleteCalled {
 		t.Error("expected Delete to be called")
 	}
-	if !mockPub.deleted {
-		t.Error("expected PublishBetDeleted to be called")
+	if !mockRepo.queued(topology.BetDeleted) {
+		t.Error("expected bet.deleted to be written to the outbox")
 	}
 }
 func TestMain(m *testing.M) {
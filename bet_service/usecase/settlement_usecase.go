@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"bet_service/domain"
+	"bet_service/repository"
+	"context"
+	"fmt"
+	"log"
+	"muchway/pkg/money"
+	"time"
+)
+
+type SettlementUsecase struct {
+	betRepo  repository.BetRepository
+	creditor domain.PayoutCreditor
+}
+
+func NewSettlementUsecase(betRepo repository.BetRepository, creditor domain.PayoutCreditor) *SettlementUsecase {
+	return &SettlementUsecase{betRepo: betRepo, creditor: creditor}
+}
+
+// SettleEvent marks every pending bet on the event as won or lost and credits
+// the winners. Replaying the same result is safe: bets that are already
+// settled are left alone and a payout is only credited once per bet.
+func (u *SettlementUsecase) SettleEvent(result *domain.EventSettled) error {
+	if result.EventID == "" || (result.WinnerID == "" && len(result.WinningSelectionIDs) == 0) {
+		return fmt.Errorf("event settlement requires event_id and a winner")
+	}
+
+	bets, err := u.betRepo.GetByEventID(result.EventID)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, bet := range bets {
+		if bet.Status == domain.BetStatusPending {
+			if err := settleBet(bet, result); err != nil {
+				log.Printf("Failed to settle bet %s: %v", bet.ID, err)
+				failed++
+				continue
+			}
+
+			ok, err := u.betRepo.Settle(bet)
+			if err != nil {
+				log.Printf("Failed to settle bet %s: %v", bet.ID, err)
+				failed++
+				continue
+			}
+			if !ok {
+				// Settled concurrently by another delivery; reload to see the outcome.
+				current, err := u.betRepo.GetByID(bet.ID)
+				if err != nil {
+					log.Printf("Failed to reload bet %s: %v", bet.ID, err)
+					failed++
+					continue
+				}
+				bet = current
+			}
+			repository.RedisClient.Del(context.Background(), fmt.Sprintf("bet:%s", bet.ID))
+		}
+
+		if bet.Status == domain.BetStatusWon && bet.PaidOutAt == nil {
+			if err := u.payOut(bet); err != nil {
+				log.Printf("Failed to pay out bet %s: %v", bet.ID, err)
+				failed++
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("settlement of event %s incomplete: %d bet(s) failed", result.EventID, failed)
+	}
+	log.Printf("Settled %d bet(s) on event %s", len(bets), result.EventID)
+	return nil
+}
+
+func (u *SettlementUsecase) payOut(bet *domain.Bet) error {
+	claimed, err := u.betRepo.MarkPaidOut(bet.ID)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	if err := u.creditor.CreditPayout(bet); err != nil {
+		if unmarkErr := u.betRepo.UnmarkPaidOut(bet.ID); unmarkErr != nil {
+			log.Printf("Failed to release payout claim for bet %s: %v", bet.ID, unmarkErr)
+		}
+		return err
+	}
+	return nil
+}
+
+func settleBet(bet *domain.Bet, result *domain.EventSettled) error {
+	bet.UpdatedAt = time.Now()
+	if result.IsWinner(bet.SelectionID) {
+		payout, err := bet.Amount.MulOdds(bet.Odds)
+		if err != nil {
+			return err
+		}
+		bet.Status = domain.BetStatusWon
+		bet.Payout = payout
+		return nil
+	}
+	bet.Status = domain.BetStatusLost
+	bet.Payout = money.FromMinor(0, bet.Amount.Currency)
+	return nil
+}
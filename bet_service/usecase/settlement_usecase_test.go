@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"bet_service/domain"
+	"errors"
+	"muchway/pkg/money"
+	"testing"
+)
+
+// --- Моки ---
+
+type memBetRepo struct {
+	mockBetRepo
+	bets map[string]*domain.Bet
+}
+
+func (m *memBetRepo) GetByID(id string) (*domain.Bet, error) {
+	bet := *m.bets[id]
+	return &bet, nil
+}
+
+func (m *memBetRepo) GetByEventID(eventID string) ([]*domain.Bet, error) {
+	var out []*domain.Bet
+	for _, b := range m.bets {
+		if b.EventID == eventID {
+			bet := *b
+			out = append(out, &bet)
+		}
+	}
+	return out, nil
+}
+
+func (m *memBetRepo) Settle(bet *domain.Bet) (bool, error) {
+	stored := m.bets[bet.ID]
+	if stored.Status != domain.BetStatusPending {
+		return false, nil
+	}
+	stored.Status = bet.Status
+	stored.Payout = bet.Payout
+	return true, nil
+}
+
+func (m *memBetRepo) MarkPaidOut(id string) (bool, error) {
+	stored := m.bets[id]
+	if stored.PaidOutAt != nil {
+		return false, nil
+	}
+	now := stored.UpdatedAt
+	stored.PaidOutAt = &now
+	return true, nil
+}
+
+func (m *memBetRepo) UnmarkPaidOut(id string) error {
+	m.bets[id].PaidOutAt = nil
+	return nil
+}
+
+type mockCreditor struct {
+	credited map[string]money.Money
+	fail     bool
+}
+
+func (m *mockCreditor) CreditPayout(bet *domain.Bet) error {
+	if m.fail {
+		return errors.New("payment service unavailable")
+	}
+	m.credited[bet.ID], _ = m.credited[bet.ID].Add(bet.Payout)
+	return nil
+}
+
+func newSettlementFixture() (*memBetRepo, *mockCreditor) {
+	repo := &memBetRepo{bets: map[string]*domain.Bet{
+		"win":   {ID: "win", EventID: "ev1", SelectionID: "teamA", Amount: money.MustParse("10"), Odds: 2.5, Status: domain.BetStatusPending},
+		"lose":  {ID: "lose", EventID: "ev1", SelectionID: "teamB", Amount: money.MustParse("20"), Odds: 1.8, Status: domain.BetStatusPending},
+		"other": {ID: "other", EventID: "ev2", SelectionID: "teamA", Amount: money.MustParse("5"), Odds: 3, Status: domain.BetStatusPending},
+	}}
+	return repo, &mockCreditor{credited: map[string]money.Money{}}
+}
+
+// --- Тесты ---
+
+func TestSettleEvent(t *testing.T) {
+	repo, creditor := newSettlementFixture()
+	uc := NewSettlementUsecase(repo, creditor)
+
+	err := uc.SettleEvent(&domain.EventSettled{EventID: "ev1", WinnerID: "teamA"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := repo.bets["win"]; got.Status != domain.BetStatusWon || got.Payout != money.MustParse("25") {
+		t.Errorf("expected winning bet to be won with payout 25, got %s %s", got.Status, got.Payout)
+	}
+	if got := repo.bets["lose"]; got.Status != domain.BetStatusLost || !got.Payout.IsZero() {
+		t.Errorf("expected losing bet to be lost with no payout, got %s %s", got.Status, got.Payout)
+	}
+	if got := repo.bets["other"]; got.Status != domain.BetStatusPending {
+		t.Errorf("expected bet on another event to stay pending, got %s", got.Status)
+	}
+	if creditor.credited["win"] != money.MustParse("25") || len(creditor.credited) != 1 {
+		t.Errorf("expected a single credit of 25, got %v", creditor.credited)
+	}
+}
+
+func TestSettleEventByWinningSelections(t *testing.T) {
+	repo, creditor := newSettlementFixture()
+	uc := NewSettlementUsecase(repo, creditor)
+
+	err := uc.SettleEvent(&domain.EventSettled{EventID: "ev1", WinningSelectionIDs: []string{"teamB"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := repo.bets["lose"]; got.Status != domain.BetStatusWon || got.Payout != money.MustParse("36") {
+		t.Errorf("expected bet on teamB to be won with payout 36, got %s %s", got.Status, got.Payout)
+	}
+	if got := repo.bets["win"]; got.Status != domain.BetStatusLost {
+		t.Errorf("expected bet on teamA to be lost, got %s", got.Status)
+	}
+}
+
+func TestSettleEventReplayDoesNotPayTwice(t *testing.T) {
+	repo, creditor := newSettlementFixture()
+	uc := NewSettlementUsecase(repo, creditor)
+	result := &domain.EventSettled{EventID: "ev1", WinnerID: "teamA"}
+
+	for i := 0; i < 3; i++ {
+		if err := uc.SettleEvent(result); err != nil {
+			t.Fatalf("unexpected error on delivery %d: %v", i+1, err)
+		}
+	}
+
+	if creditor.credited["win"] != money.MustParse("25") {
+		t.Errorf("expected payout to be credited once, got %s", creditor.credited["win"])
+	}
+}
+
+func TestSettleEventRetriesFailedPayout(t *testing.T) {
+	repo, creditor := newSettlementFixture()
+	uc := NewSettlementUsecase(repo, creditor)
+	result := &domain.EventSettled{EventID: "ev1", WinnerID: "teamA"}
+
+	creditor.fail = true
+	if err := uc.SettleEvent(result); err == nil {
+		t.Fatal("expected error when payout cannot be credited")
+	}
+	if repo.bets["win"].PaidOutAt != nil {
+		t.Error("expected payout claim to be released after failed credit")
+	}
+
+	creditor.fail = false
+	if err := uc.SettleEvent(result); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if creditor.credited["win"] != money.MustParse("25") {
+		t.Errorf("expected payout to be credited on retry, got %s", creditor.credited["win"])
+	}
+}
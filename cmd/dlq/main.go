@@ -0,0 +1,60 @@
+// Command dlq inspects and replays the dead-letter queues kept by
+// muchway/pkg/consumer.
+//
+//	dlq -queue bet_service.event.settled list
+//	dlq -queue bet_service.event.settled -n 100 replay
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/streadway/amqp"
+
+	"muchway/pkg/consumer"
+)
+
+func main() {
+	url := flag.String("amqp", os.Getenv("AMQP_URL"), "RabbitMQ URL (default $AMQP_URL)")
+	queue := flag.String("queue", "", "queue whose dead letters to work on")
+	limit := flag.Int("n", 10, "maximum number of messages")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: dlq -queue <queue> [-n N] [-amqp URL] list|replay\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *url == "" || *queue == "" || flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	conn, err := amqp.Dial(*url)
+	if err != nil {
+		log.Fatal("Failed to connect to RabbitMQ:", err)
+	}
+	defer conn.Close()
+
+	switch flag.Arg(0) {
+	case "list":
+		letters, err := consumer.Inspect(conn, *queue, *limit)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for i, dl := range letters {
+			fmt.Printf("#%d attempts=%d failed_at=%s\n  error: %s\n  body:  %s\n",
+				i+1, dl.Attempts, dl.FailedAt.Format("2006-01-02 15:04:05"), dl.LastError, dl.Body)
+		}
+		fmt.Printf("%d message(s) shown from %s\n", len(letters), consumer.DeadLetterQueue(*queue))
+	case "replay":
+		n, err := consumer.Replay(conn, *queue, *limit)
+		if err != nil {
+			log.Fatalf("Replayed %d message(s) before failing: %v", n, err)
+		}
+		fmt.Printf("Replayed %d message(s) onto %s\n", n, *queue)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
+	"muchway/pkg/health"
 	userpb "muchway/user_service/proto/userpb"
 )
 
@@ -18,9 +19,11 @@ type UserClient struct {
 	conn   *grpc.ClientConn
 }
 
-// NewUserClient creates a new user service client
-func NewUserClient(address string) (*UserClient, error) {
-	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// NewUserClient creates a new user service client. Extra options, such as
+// per-RPC credentials, are passed on to grpc.Dial.
+func NewUserClient(address string, opts ...grpc.DialOption) (*UserClient, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.Dial(address, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to user service: %w", err)
 	}
@@ -37,7 +40,13 @@ func (c *UserClient) Close() error {
 	return c.conn.Close()
 }
 
-// GetAllUserEmails retrieves all user emails from the user service
+// Check reports whether the user service is reachable and serving
+func (c *UserClient) Check(ctx context.Context) error {
+	return health.GRPC(c.conn, userpb.UserService_ServiceDesc.ServiceName)(ctx)
+}
+
+// GetAllUserEmails retrieves the emails of all users who may be sent
+// marketing, leaving out those who have excluded themselves
 func (c *UserClient) GetAllUserEmails(ctx context.Context) ([]string, error) {
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
@@ -53,7 +62,7 @@ func (c *UserClient) GetAllUserEmails(ctx context.Context) ([]string, error) {
 
 	var emails []string
 	for _, user := range resp.Users {
-		if user.Email != "" {
+		if user.Email != "" && !user.Excluded {
 			emails = append(emails, user.Email)
 		}
 	}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	"muchway/pkg/config"
+)
+
+// Config is the event service's configuration, loaded by config.MustLoad.
+type Config struct {
+	GRPCAddr string `yaml:"grpc_addr" env:"GRPC_ADDR" default:":50053" usage:"address the gRPC server listens on"`
+	HTTPAddr string `yaml:"http_addr" env:"HTTP_ADDR" default:":8080" usage:"address the REST API listens on"`
+	JWKSURL  string `yaml:"jwks_url" env:"JWKS_URL" default:"http://localhost:8051/.well-known/jwks.json" usage:"where the user service publishes its signing keys"`
+
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" default:"30s" usage:"how long shutdown waits for work in flight"`
+	HealthInterval  time.Duration `yaml:"health_interval" env:"HEALTH_INTERVAL" default:"10s" usage:"how often dependencies are checked for /readyz and gRPC health"`
+
+	UserServiceAddr     string `yaml:"user_service_addr" env:"USER_SERVICE_ADDR" default:"localhost:50051" usage:"user service gRPC address"`
+	ServiceClientSecret string `yaml:"service_client_secret" env:"SERVICE_CLIENT_SECRET" required:"true" secret:"true" usage:"secret this service authenticates to the user service with"`
+
+	Database config.Postgres `yaml:"database"`
+	RabbitMQ config.RabbitMQ `yaml:"rabbitmq"`
+	Redis    config.Redis    `yaml:"redis"`
+	SMTP     config.SMTP     `yaml:"smtp"`
+}
+
+func (c *Config) Validate() error {
+	if c.ShutdownTimeout <= 0 {
+		return errors.New("shutdown_timeout must be positive")
+	}
+	if c.HealthInterval <= 0 {
+		return errors.New("health_interval must be positive")
+	}
+	return c.SMTP.Validate()
+}
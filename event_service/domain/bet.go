@@ -1,15 +1,19 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"muchway/pkg/money"
+)
 
 type Bet struct {
-	ID        string    `json:"id"`
-	UserID    string    `json:"user_id"`
-	EventID   string    `json:"event_id"`
-	Amount    float64   `json:"amount"`
-	Odds      float64   `json:"odds"`
-	Status    string    `json:"status"`
-	Payout    float64   `json:"payout,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        string      `json:"id"`
+	UserID    string      `json:"user_id"`
+	EventID   string      `json:"event_id"`
+	Amount    money.Money `json:"amount"`
+	Odds      float64     `json:"odds"`
+	Status    string      `json:"status"`
+	Payout    money.Money `json:"payout,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
 }
@@ -2,6 +2,8 @@ package domain
 
 import "time"
 
+const EventStatusFinished = "finished"
+
 type Event struct {
 	ID        string
 	Name      string
@@ -11,3 +13,12 @@ type Event struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+// EventSettled is the event.settled message consumed by bet_service to settle
+// the bets placed on a finished event.
+type EventSettled struct {
+	EventID             string    `json:"event_id"`
+	WinnerID            string    `json:"winner_id,omitempty"`
+	WinningSelectionIDs []string  `json:"winning_selection_ids,omitempty"`
+	SettledAt           time.Time `json:"settled_at"`
+}
@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+const (
+	MarketTypeMatchWinner  = "match_winner"
+	MarketTypeOverUnder    = "over_under"
+	MarketTypeHandicap     = "handicap"
+	MarketTypeCorrectScore = "correct_score"
+
+	MarketStatusOpen      = "open"
+	MarketStatusSuspended = "suspended"
+	MarketStatusSettled   = "settled"
+
+	SelectionResultPending = "pending"
+	SelectionResultWon     = "won"
+	SelectionResultLost    = "lost"
+)
+
+// Market is a bettable proposition on an event, e.g. the match winner or
+// over/under 2.5 goals. Line holds the total or handicap for the market types
+// that need one.
+type Market struct {
+	ID         string
+	EventID    string
+	Type       string
+	Name       string
+	Line       *float64
+	Status     string
+	Selections []*Selection
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Selection is one outcome of a market with its server-controlled price.
+type Selection struct {
+	ID        string
+	MarketID  string
+	Name      string
+	Price     float64
+	Result    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,28 @@
+package grpc
+
+import (
+	"muchway/pkg/auth"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	pb "muchway/event_service/proto"
+)
+
+// Policy says who may call each EventService method. Anyone can browse
+// events and markets; only traders manage them. Health checks are open.
+var Policy = auth.Policy{
+	pb.EventService_GetEvent_FullMethodName:     {auth.Anyone},
+	pb.EventService_ListEvents_FullMethodName:   {auth.Anyone},
+	pb.EventService_GetMarket_FullMethodName:    {auth.Anyone},
+	pb.EventService_ListMarkets_FullMethodName:  {auth.Anyone},
+	pb.EventService_GetSelection_FullMethodName: {auth.Anyone},
+	healthpb.Health_Check_FullMethodName:        {auth.Anyone},
+	healthpb.Health_Watch_FullMethodName:        {auth.Anyone},
+
+	pb.EventService_CreateEvent_FullMethodName:  {auth.RoleTrader},
+	pb.EventService_UpdateEvent_FullMethodName:  {auth.RoleTrader},
+	pb.EventService_DeleteEvent_FullMethodName:  {auth.RoleTrader},
+	pb.EventService_CreateMarket_FullMethodName: {auth.RoleTrader},
+	pb.EventService_UpdateMarket_FullMethodName: {auth.RoleTrader},
+	pb.EventService_DeleteMarket_FullMethodName: {auth.RoleTrader},
+}
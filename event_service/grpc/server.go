@@ -2,6 +2,8 @@ package grpc
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"time"
@@ -12,12 +14,13 @@ import (
 )
 
 type Server struct {
-	uc usecase.EventUseCase
+	uc      usecase.EventUseCase
+	markets usecase.MarketUseCase
 	pb.UnimplementedEventServiceServer
 }
 
-func NewGRPCServer(uc usecase.EventUseCase) *Server {
-	return &Server{uc: uc}
+func NewGRPCServer(uc usecase.EventUseCase, markets usecase.MarketUseCase) *Server {
+	return &Server{uc: uc, markets: markets}
 }
 
 func toProto(e *domain.Event) *pb.Event {
@@ -57,6 +60,68 @@ func fromProto(p *pb.Event) (*domain.Event, error) {
 	}, nil
 }
 
+func selectionToProto(sel *domain.Selection) *pb.Selection {
+	return &pb.Selection{
+		Id:       sel.ID,
+		MarketId: sel.MarketID,
+		Name:     sel.Name,
+		Price:    sel.Price,
+		Result:   sel.Result,
+	}
+}
+
+func marketToProto(m *domain.Market) *pb.Market {
+	out := &pb.Market{
+		Id:        m.ID,
+		EventId:   m.EventID,
+		Type:      m.Type,
+		Name:      m.Name,
+		Line:      m.Line,
+		Status:    m.Status,
+		CreatedAt: m.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: m.UpdatedAt.Format(time.RFC3339),
+	}
+	for _, sel := range m.Selections {
+		out.Selections = append(out.Selections, selectionToProto(sel))
+	}
+	return out
+}
+
+func marketFromProto(p *pb.Market) (*domain.Market, error) {
+	if p == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "market must be provided")
+	}
+	m := &domain.Market{
+		ID:      p.Id,
+		EventID: p.EventId,
+		Type:    p.Type,
+		Name:    p.Name,
+		Line:    p.Line,
+		Status:  p.Status,
+	}
+	for _, sel := range p.Selections {
+		m.Selections = append(m.Selections, &domain.Selection{
+			ID:     sel.Id,
+			Name:   sel.Name,
+			Price:  sel.Price,
+			Result: sel.Result,
+		})
+	}
+	return m, nil
+}
+
+// marketError maps market use case errors onto gRPC status codes.
+func marketError(err error, action string) error {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidMarket):
+		return status.Errorf(codes.InvalidArgument, "could not %s: %v", action, err)
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Errorf(codes.NotFound, "could not %s: not found", action)
+	default:
+		return status.Errorf(codes.Internal, "could not %s: %v", action, err)
+	}
+}
+
 func (s *Server) CreateEvent(ctx context.Context, req *pb.CreateEventRequest) (*pb.CreateEventResponse, error) {
 	if req.Event == nil {
 		return nil, status.Errorf(codes.InvalidArgument, "event must be provided")
@@ -87,6 +152,9 @@ func (s *Server) UpdateEvent(ctx context.Context, req *pb.UpdateEventRequest) (*
 	}
 
 	updated, err := s.uc.UpdateEvent(ctx, e)
+	if errors.Is(err, usecase.ErrInvalidMarket) {
+		return nil, status.Errorf(codes.FailedPrecondition, "could not update event: %v", err)
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "could not update event: %v", err)
 	}
@@ -109,3 +177,65 @@ func (s *Server) ListEvents(ctx context.Context, req *pb.ListEventsRequest) (*pb
 	}
 	return resp, nil
 }
+
+func (s *Server) CreateMarket(ctx context.Context, req *pb.CreateMarketRequest) (*pb.CreateMarketResponse, error) {
+	m, err := marketFromProto(req.Market)
+	if err != nil {
+		return nil, err
+	}
+	saved, err := s.markets.CreateMarket(ctx, m)
+	if err != nil {
+		return nil, marketError(err, "create market")
+	}
+	return &pb.CreateMarketResponse{Market: marketToProto(saved)}, nil
+}
+
+func (s *Server) GetMarket(ctx context.Context, req *pb.GetMarketRequest) (*pb.GetMarketResponse, error) {
+	m, err := s.markets.GetMarket(ctx, req.Id)
+	if err != nil {
+		return nil, marketError(err, "get market")
+	}
+	return &pb.GetMarketResponse{Market: marketToProto(m)}, nil
+}
+
+func (s *Server) UpdateMarket(ctx context.Context, req *pb.UpdateMarketRequest) (*pb.UpdateMarketResponse, error) {
+	m, err := marketFromProto(req.Market)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := s.markets.UpdateMarket(ctx, m)
+	if err != nil {
+		return nil, marketError(err, "update market")
+	}
+	return &pb.UpdateMarketResponse{Market: marketToProto(updated)}, nil
+}
+
+func (s *Server) DeleteMarket(ctx context.Context, req *pb.DeleteMarketRequest) (*pb.DeleteMarketResponse, error) {
+	if err := s.markets.DeleteMarket(ctx, req.Id); err != nil {
+		return nil, marketError(err, "delete market")
+	}
+	return &pb.DeleteMarketResponse{}, nil
+}
+
+func (s *Server) ListMarkets(ctx context.Context, req *pb.ListMarketsRequest) (*pb.ListMarketsResponse, error) {
+	list, err := s.markets.ListMarkets(ctx, req.EventId)
+	if err != nil {
+		return nil, marketError(err, "list markets")
+	}
+	resp := &pb.ListMarketsResponse{}
+	for _, m := range list {
+		resp.Markets = append(resp.Markets, marketToProto(m))
+	}
+	return resp, nil
+}
+
+func (s *Server) GetSelection(ctx context.Context, req *pb.GetSelectionRequest) (*pb.GetSelectionResponse, error) {
+	sel, m, err := s.markets.GetSelection(ctx, req.Id)
+	if err != nil {
+		return nil, marketError(err, "get selection")
+	}
+	return &pb.GetSelectionResponse{
+		Selection: selectionToProto(sel),
+		Market:    marketToProto(m),
+	}, nil
+}
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS markets (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    event_id UUID NOT NULL REFERENCES events (id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    name TEXT NOT NULL,
+    line NUMERIC(6, 2),
+    status TEXT NOT NULL DEFAULT 'open',
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_markets_event_id ON markets (event_id);
+
+CREATE TABLE IF NOT EXISTS selections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    market_id UUID NOT NULL REFERENCES markets (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    price NUMERIC(7, 2) NOT NULL,
+    result TEXT NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_selections_market_id ON selections (market_id);
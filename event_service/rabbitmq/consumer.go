@@ -1,65 +1,39 @@
 package rabbitmq
 
 import (
-	"fmt"
-	"log"
+	"context"
 
 	"github.com/streadway/amqp"
+
+	"muchway/pkg/consumer"
 )
 
 type Consumer interface {
-	Consume(queue string, handler func([]byte)) error
-}
-
-type amqpConsumer struct {
-	ch *amqp.Channel
+	// Consume acks a message once handler returns nil; failed messages are
+	// retried and finally dead-lettered.
+	Consume(queue string, handler func([]byte) error) error
+	// Shutdown stops consuming and waits for the messages being handled.
+	Shutdown(ctx context.Context) error
+	// Check fails once a subscription has stopped other than by Shutdown.
+	Check(ctx context.Context) error
 }
 
 func NewConsumer(conn *amqp.Connection) (Consumer, error) {
-	ch, err := conn.Channel()
-	if err != nil {
-		return nil, err
-	}
-	return &amqpConsumer{ch: ch}, nil
+	return &amqpConsumer{c: consumer.New(conn)}, nil
 }
 
-func (c *amqpConsumer) Consume(queue string, handler func([]byte)) error {
-	if c.ch == nil {
-		return fmt.Errorf("channel is nil, cannot consume from queue: %s", queue)
-	}
+type amqpConsumer struct {
+	c *consumer.Consumer
+}
 
-	// ⬇️ DECLARE the queue (idempotent if already exists)
-	_, err := c.ch.QueueDeclare(
-		queue, // name
-		true,  // durable
-		false, // delete when unused
-		false, // exclusive
-		false, // no-wait
-		nil,   // arguments
-	)
-	if err != nil {
-		return fmt.Errorf("queue declare %q: %w", queue, err)
-	}
+func (c *amqpConsumer) Consume(queue string, handler func([]byte) error) error {
+	return c.c.Consume(queue, handler)
+}
 
-	log.Printf("Subscribing to queue: %s", queue)
-	msgs, err := c.ch.Consume(
-		queue, // queue
-		"",    // consumer tag
-		true,  // auto-ack
-		false, // exclusive
-		false, // no-local
-		false, // no-wait
-		nil,   // args
-	)
-	if err != nil {
-		return fmt.Errorf("consume %q: %w", queue, err)
-	}
+func (c *amqpConsumer) Shutdown(ctx context.Context) error {
+	return c.c.Shutdown(ctx)
+}
 
-	log.Printf("Successfully subscribed to queue: %s", queue)
-	go func() {
-		for m := range msgs {
-			handler(m.Body)
-		}
-	}()
-	return nil
+func (c *amqpConsumer) Check(ctx context.Context) error {
+	return c.c.Check(ctx)
 }
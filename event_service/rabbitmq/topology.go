@@ -0,0 +1,21 @@
+package rabbitmq
+
+import "muchway/pkg/topology"
+
+// BetQueues maps the bet lifecycle events event_service follows to the queue
+// receiving each; one type per queue lets a consumer tell versions apart.
+var BetQueues = map[string]string{
+	topology.BetCreated: "event_service.bet.created",
+	topology.BetUpdated: "event_service.bet.updated",
+	topology.BetDeleted: "event_service.bet.deleted",
+}
+
+// Queues returns the queues event_service declares at startup with their
+// bindings.
+func Queues() []topology.Queue {
+	var qs []topology.Queue
+	for key, name := range BetQueues {
+		qs = append(qs, topology.Queue{Name: name, Bindings: []topology.Binding{{Exchange: topology.ExchangeBets, Key: key}}})
+	}
+	return qs
+}
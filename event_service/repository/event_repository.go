@@ -6,23 +6,41 @@ import (
 	"time"
 
 	"muchway/event_service/domain"
+	"muchway/pkg/outbox"
 )
 
+// EventOutboxTable holds the messages waiting to be relayed to RabbitMQ.
+const EventOutboxTable = "event_outbox"
+
+// EventAuditTable records the calls refused for lack of permission.
+const EventAuditTable = "event_audit_log"
+
 type EventRepository interface {
-	Create(ctx context.Context, e *domain.Event) (*domain.Event, error)
+	// Create and Update write the given outbox messages in the same
+	// transaction as the event.
+	Create(ctx context.Context, e *domain.Event, msgs ...outbox.Message) (*domain.Event, error)
 	Get(ctx context.Context, id string) (*domain.Event, error)
-	Update(ctx context.Context, e *domain.Event) (*domain.Event, error)
+	Update(ctx context.Context, e *domain.Event, msgs ...outbox.Message) (*domain.Event, error)
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context) ([]*domain.Event, error)
 }
 
-type pgEventRepo struct{ db *sql.DB }
+type pgEventRepo struct {
+	db     *sql.DB
+	outbox *outbox.Store
+}
 
 func NewPostgresEventRepository(db *sql.DB) EventRepository {
-	return &pgEventRepo{db: db}
+	return &pgEventRepo{db: db, outbox: outbox.NewStore(EventOutboxTable)}
 }
 
-func (r *pgEventRepo) Create(ctx context.Context, e *domain.Event) (*domain.Event, error) {
+func (r *pgEventRepo) Create(ctx context.Context, e *domain.Event, msgs ...outbox.Message) (*domain.Event, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
 	now := time.Now()
 	e.CreatedAt, e.UpdatedAt = now, now
 	const stmt = `
@@ -30,12 +48,18 @@ func (r *pgEventRepo) Create(ctx context.Context, e *domain.Event) (*domain.Even
     VALUES ($1, $2, $3, $4, $5, $6)
     RETURNING id, created_at, updated_at
     `
-	err := r.db.QueryRowContext(ctx, stmt,
+	err = tx.QueryRowContext(ctx, stmt,
 		e.Name, e.StartTime, e.Status, e.WinnerID, e.CreatedAt, e.UpdatedAt,
 	).Scan(&e.ID, &e.CreatedAt, &e.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
+	if err := r.outbox.Add(ctx, tx, msgs...); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
 	return e, nil
 }
 
@@ -51,13 +75,25 @@ func (r *pgEventRepo) Get(ctx context.Context, id string) (*domain.Event, error)
 	return &e, nil
 }
 
-func (r *pgEventRepo) Update(ctx context.Context, e *domain.Event) (*domain.Event, error) {
+func (r *pgEventRepo) Update(ctx context.Context, e *domain.Event, msgs ...outbox.Message) (*domain.Event, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
 	e.UpdatedAt = time.Now()
-	_, err := r.db.ExecContext(ctx,
+	_, err = tx.ExecContext(ctx,
 		`UPDATE events SET name=$2,start_time=$3,status=$4,winner_id=$5,updated_at=$6 WHERE id=$1`,
 		e.ID, e.Name, e.StartTime, e.Status, e.WinnerID, e.UpdatedAt,
 	)
-	return e, err
+	if err != nil {
+		return e, err
+	}
+	if err := r.outbox.Add(ctx, tx, msgs...); err != nil {
+		return e, err
+	}
+	return e, tx.Commit()
 }
 
 func (r *pgEventRepo) Delete(ctx context.Context, id string) error {
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"muchway/event_service/domain"
+)
+
+type MarketRepository interface {
+	Create(ctx context.Context, m *domain.Market) (*domain.Market, error)
+	Get(ctx context.Context, id string) (*domain.Market, error)
+	Update(ctx context.Context, m *domain.Market) (*domain.Market, error)
+	Delete(ctx context.Context, id string) error
+	ListByEvent(ctx context.Context, eventID string) ([]*domain.Market, error)
+	GetSelection(ctx context.Context, id string) (*domain.Selection, error)
+}
+
+type pgMarketRepo struct{ db *sql.DB }
+
+func NewPostgresMarketRepository(db *sql.DB) MarketRepository {
+	return &pgMarketRepo{db: db}
+}
+
+func (r *pgMarketRepo) Create(ctx context.Context, m *domain.Market) (*domain.Market, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	m.CreatedAt, m.UpdatedAt = now, now
+	const stmt = `
+    INSERT INTO markets (event_id, type, name, line, status, created_at, updated_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+    RETURNING id
+    `
+	err = tx.QueryRowContext(ctx, stmt,
+		m.EventID, m.Type, m.Name, m.Line, m.Status, m.CreatedAt, m.UpdatedAt,
+	).Scan(&m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range m.Selections {
+		if err := insertSelection(ctx, tx, m.ID, s, now); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (r *pgMarketRepo) Get(ctx context.Context, id string) (*domain.Market, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT id,event_id,type,name,line,status,created_at,updated_at
+     FROM markets WHERE id=$1`, id,
+	)
+	var m domain.Market
+	if err := row.Scan(&m.ID, &m.EventID, &m.Type, &m.Name, &m.Line, &m.Status, &m.CreatedAt, &m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	sels, err := r.listSelections(ctx, m.ID)
+	if err != nil {
+		return nil, err
+	}
+	m.Selections = sels
+	return &m, nil
+}
+
+// Update saves the market and its selections. Selections without an ID are
+// added to the market; selections missing from m are left untouched.
+func (r *pgMarketRepo) Update(ctx context.Context, m *domain.Market) (*domain.Market, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	m.UpdatedAt = time.Now()
+	_, err = tx.ExecContext(ctx,
+		`UPDATE markets SET type=$2,name=$3,line=$4,status=$5,updated_at=$6 WHERE id=$1`,
+		m.ID, m.Type, m.Name, m.Line, m.Status, m.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range m.Selections {
+		if s.ID == "" {
+			if err := insertSelection(ctx, tx, m.ID, s, m.UpdatedAt); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		s.MarketID, s.UpdatedAt = m.ID, m.UpdatedAt
+		_, err = tx.ExecContext(ctx,
+			`UPDATE selections SET name=$3,price=$4,result=$5,updated_at=$6 WHERE id=$1 AND market_id=$2`,
+			s.ID, m.ID, s.Name, s.Price, s.Result, s.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return r.Get(ctx, m.ID)
+}
+
+func (r *pgMarketRepo) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM markets WHERE id=$1`, id)
+	return err
+}
+
+func (r *pgMarketRepo) ListByEvent(ctx context.Context, eventID string) ([]*domain.Market, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id,event_id,type,name,line,status,created_at,updated_at
+     FROM markets WHERE event_id=$1 ORDER BY created_at`, eventID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*domain.Market
+	for rows.Next() {
+		var m domain.Market
+		if err := rows.Scan(&m.ID, &m.EventID, &m.Type, &m.Name, &m.Line, &m.Status, &m.CreatedAt, &m.UpdatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, m := range out {
+		if m.Selections, err = r.listSelections(ctx, m.ID); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (r *pgMarketRepo) GetSelection(ctx context.Context, id string) (*domain.Selection, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT id,market_id,name,price,result,created_at,updated_at
+     FROM selections WHERE id=$1`, id,
+	)
+	var s domain.Selection
+	if err := row.Scan(&s.ID, &s.MarketID, &s.Name, &s.Price, &s.Result, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *pgMarketRepo) listSelections(ctx context.Context, marketID string) ([]*domain.Selection, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id,market_id,name,price,result,created_at,updated_at
+     FROM selections WHERE market_id=$1 ORDER BY created_at, name`, marketID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*domain.Selection
+	for rows.Next() {
+		var s domain.Selection
+		if err := rows.Scan(&s.ID, &s.MarketID, &s.Name, &s.Price, &s.Result, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, &s)
+	}
+	return out, rows.Err()
+}
+
+func insertSelection(ctx context.Context, tx *sql.Tx, marketID string, s *domain.Selection, now time.Time) error {
+	s.MarketID = marketID
+	s.CreatedAt, s.UpdatedAt = now, now
+	return tx.QueryRowContext(ctx,
+		`INSERT INTO selections (market_id, name, price, result, created_at, updated_at)
+     VALUES ($1, $2, $3, $4, $5, $6)
+     RETURNING id`,
+		s.MarketID, s.Name, s.Price, s.Result, s.CreatedAt, s.UpdatedAt,
+	).Scan(&s.ID)
+}
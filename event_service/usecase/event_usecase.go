@@ -3,18 +3,25 @@ package usecase
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"time"
 
 	"muchway/event_service/client"
 	"muchway/event_service/domain"
 	"muchway/event_service/email"
-	"muchway/event_service/rabbitmq"
 	"muchway/event_service/repository"
+	"muchway/pkg/events"
+	"muchway/pkg/lifecycle"
+	"muchway/pkg/outbox"
+	"muchway/pkg/topology"
 
 	"github.com/go-redis/redis/v8"
 )
 
+// eventSource stamps the messages event_service publishes.
+var eventSource = events.Source{Producer: "event_service"}
+
 type EventUseCase interface {
 	CreateEvent(ctx context.Context, e *domain.Event) (*domain.Event, error)
 	GetEvent(ctx context.Context, id string) (*domain.Event, error)
@@ -25,36 +32,48 @@ type EventUseCase interface {
 
 type eventUseCase struct {
 	repo         repository.EventRepository
-	publisher    rabbitmq.Publisher
+	markets      repository.MarketRepository
 	rdb          *redis.Client
 	userClient   *client.UserClient
 	emailService email.EmailService
+	background   *lifecycle.Group
 }
 
-func NewEventUseCase(r repository.EventRepository, p rabbitmq.Publisher, rdb *redis.Client, uc *client.UserClient, es email.EmailService) EventUseCase {
+// NewEventUseCase returns the event use case. New-event emails are sent in
+// background, which shutdown waits for.
+func NewEventUseCase(r repository.EventRepository, m repository.MarketRepository, rdb *redis.Client, uc *client.UserClient, es email.EmailService, background *lifecycle.Group) EventUseCase {
 	return &eventUseCase{
 		repo:         r,
-		publisher:    p,
+		markets:      m,
 		rdb:          rdb,
 		userClient:   uc,
 		emailService: es,
+		background:   background,
 	}
 }
 
 func (uc *eventUseCase) CreateEvent(ctx context.Context, e *domain.Event) (*domain.Event, error) {
-	saved, err := uc.repo.Create(ctx, e)
+	// The envelope is built when the outbox writes it, once the insert has
+	// assigned the event ID
+	created := outbox.Message{
+		Exchange:   topology.ExchangeEvents,
+		RoutingKey: topology.EventCreated,
+		Payload: eventSource.Deferred(topology.EventCreated, events.EventVersion, func() (string, interface{}) {
+			p := events.EventV1{ID: e.ID, Name: e.Name, StartTime: e.StartTime, Status: e.Status}
+			if e.WinnerID != nil {
+				p.WinnerID = *e.WinnerID
+			}
+			return e.ID, p
+		}),
+	}
+	saved, err := uc.repo.Create(ctx, e, created)
 	if err != nil {
 		return nil, err
 	}
 
-	// Publish event to message queue
-	if err := uc.publisher.Publish("events", "created", saved); err != nil {
-		log.Printf("publish error: %v", err)
-	}
-
 	// Send email notification to all users
 	if uc.userClient != nil && uc.emailService != nil {
-		go func() {
+		uc.background.Go(func() {
 			// Create a new context for the goroutine
 			emailCtx := context.Background()
 
@@ -76,7 +95,7 @@ func (uc *eventUseCase) CreateEvent(ctx context.Context, e *domain.Event) (*doma
 			} else {
 				log.Println("No users to send event notification to")
 			}
-		}()
+		})
 	}
 
 	return saved, nil
@@ -109,15 +128,80 @@ func (uc *eventUseCase) GetEvent(ctx context.Context, id string) (*domain.Event,
 }
 
 func (uc *eventUseCase) UpdateEvent(ctx context.Context, e *domain.Event) (*domain.Event, error) {
-	updated, err := uc.repo.Update(ctx, e)
+	var markets []*domain.Market
+	var msgs []outbox.Message
+	if e.Status == domain.EventStatusFinished {
+		var err error
+		if markets, err = uc.resultedMarkets(ctx, e.ID); err != nil {
+			return nil, err
+		}
+
+		// bet_service settles the bets placed on the event when it is told
+		// the result, which is recorded together with the status change
+		settled := domain.EventSettled{
+			EventID:   e.ID,
+			SettledAt: time.Now(),
+		}
+		if e.WinnerID != nil {
+			settled.WinnerID = *e.WinnerID
+		}
+		for _, m := range markets {
+			for _, s := range m.Selections {
+				if s.Result == domain.SelectionResultWon {
+					settled.WinningSelectionIDs = append(settled.WinningSelectionIDs, s.ID)
+				}
+			}
+		}
+		if settled.WinnerID != "" || len(settled.WinningSelectionIDs) > 0 {
+			env, err := eventSource.New(topology.EventSettled, events.EventSettledVersion, e.ID, events.EventSettledV1(settled))
+			if err != nil {
+				return nil, err
+			}
+			msgs = append(msgs, outbox.Message{Exchange: topology.ExchangeEvents, RoutingKey: topology.EventSettled, Payload: env})
+		}
+	}
+
+	updated, err := uc.repo.Update(ctx, e, msgs...)
 	if err != nil {
 		return nil, err
 	}
 	uc.rdb.Del(ctx, "event:"+e.ID)
 	log.Printf("Invalidated cache for event %s", e.ID)
+
+	// Close the markets of a finished event
+	for _, m := range markets {
+		if m.Status != domain.MarketStatusSettled {
+			m.Status = domain.MarketStatusSettled
+			if _, err := uc.markets.Update(ctx, m); err != nil {
+				log.Printf("Failed to close market %s: %v", m.ID, err)
+			}
+		}
+	}
 	return updated, nil
 }
 
+// resultedMarkets returns the markets of an event and makes sure each of them
+// has a winning selection, so no bet gets settled as lost by mistake.
+func (uc *eventUseCase) resultedMarkets(ctx context.Context, eventID string) ([]*domain.Market, error) {
+	markets, err := uc.markets.ListByEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range markets {
+		resulted := false
+		for _, s := range m.Selections {
+			if s.Result == domain.SelectionResultWon {
+				resulted = true
+				break
+			}
+		}
+		if !resulted {
+			return nil, fmt.Errorf("%w: market %s has no winning selection", ErrInvalidMarket, m.ID)
+		}
+	}
+	return markets, nil
+}
+
 func (uc *eventUseCase) DeleteEvent(ctx context.Context, id string) error {
 	if err := uc.repo.Delete(ctx, id); err != nil {
 		return err
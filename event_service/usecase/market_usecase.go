@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"muchway/event_service/domain"
+	"muchway/event_service/repository"
+)
+
+// ErrInvalidMarket is returned when a market or one of its selections fails
+// validation.
+var ErrInvalidMarket = errors.New("invalid market")
+
+type MarketUseCase interface {
+	CreateMarket(ctx context.Context, m *domain.Market) (*domain.Market, error)
+	GetMarket(ctx context.Context, id string) (*domain.Market, error)
+	UpdateMarket(ctx context.Context, m *domain.Market) (*domain.Market, error)
+	DeleteMarket(ctx context.Context, id string) error
+	ListMarkets(ctx context.Context, eventID string) ([]*domain.Market, error)
+	GetSelection(ctx context.Context, id string) (*domain.Selection, *domain.Market, error)
+}
+
+type marketUseCase struct {
+	repo   repository.MarketRepository
+	events repository.EventRepository
+}
+
+func NewMarketUseCase(r repository.MarketRepository, events repository.EventRepository) MarketUseCase {
+	return &marketUseCase{repo: r, events: events}
+}
+
+func (uc *marketUseCase) CreateMarket(ctx context.Context, m *domain.Market) (*domain.Market, error) {
+	if _, err := uc.events.Get(ctx, m.EventID); err != nil {
+		return nil, fmt.Errorf("event %s: %w", m.EventID, err)
+	}
+	if m.Status == "" {
+		m.Status = domain.MarketStatusOpen
+	}
+	for _, s := range m.Selections {
+		s.ID = ""
+		s.Result = domain.SelectionResultPending
+	}
+	if err := validateMarket(m); err != nil {
+		return nil, err
+	}
+	return uc.repo.Create(ctx, m)
+}
+
+func (uc *marketUseCase) GetMarket(ctx context.Context, id string) (*domain.Market, error) {
+	return uc.repo.Get(ctx, id)
+}
+
+func (uc *marketUseCase) UpdateMarket(ctx context.Context, m *domain.Market) (*domain.Market, error) {
+	current, err := uc.repo.Get(ctx, m.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current.Status == domain.MarketStatusSettled {
+		return nil, fmt.Errorf("%w: market %s is already settled", ErrInvalidMarket, m.ID)
+	}
+	m.EventID = current.EventID
+
+	// Validate against the full set of selections the market will have
+	merged := *m
+	merged.Selections = mergeSelections(current.Selections, m.Selections)
+	if err := validateMarket(&merged); err != nil {
+		return nil, err
+	}
+	return uc.repo.Update(ctx, m)
+}
+
+func (uc *marketUseCase) DeleteMarket(ctx context.Context, id string) error {
+	return uc.repo.Delete(ctx, id)
+}
+
+func (uc *marketUseCase) ListMarkets(ctx context.Context, eventID string) ([]*domain.Market, error) {
+	return uc.repo.ListByEvent(ctx, eventID)
+}
+
+func (uc *marketUseCase) GetSelection(ctx context.Context, id string) (*domain.Selection, *domain.Market, error) {
+	s, err := uc.repo.GetSelection(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	m, err := uc.repo.Get(ctx, s.MarketID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s, m, nil
+}
+
+func validateMarket(m *domain.Market) error {
+	switch m.Type {
+	case domain.MarketTypeMatchWinner, domain.MarketTypeCorrectScore:
+	case domain.MarketTypeOverUnder, domain.MarketTypeHandicap:
+		if m.Line == nil {
+			return fmt.Errorf("%w: %s market requires a line", ErrInvalidMarket, m.Type)
+		}
+	default:
+		return fmt.Errorf("%w: unknown market type %q", ErrInvalidMarket, m.Type)
+	}
+
+	switch m.Status {
+	case domain.MarketStatusOpen, domain.MarketStatusSuspended, domain.MarketStatusSettled:
+	default:
+		return fmt.Errorf("%w: unknown market status %q", ErrInvalidMarket, m.Status)
+	}
+
+	if m.Name == "" {
+		return fmt.Errorf("%w: name must be provided", ErrInvalidMarket)
+	}
+	if len(m.Selections) < 2 {
+		return fmt.Errorf("%w: at least two selections are required", ErrInvalidMarket)
+	}
+	for _, s := range m.Selections {
+		if s.Name == "" {
+			return fmt.Errorf("%w: selection name must be provided", ErrInvalidMarket)
+		}
+		if s.Price <= 1 {
+			return fmt.Errorf("%w: price of %q must be greater than 1", ErrInvalidMarket, s.Name)
+		}
+		switch s.Result {
+		case domain.SelectionResultPending, domain.SelectionResultWon, domain.SelectionResultLost:
+		default:
+			return fmt.Errorf("%w: unknown result %q for %q", ErrInvalidMarket, s.Result, s.Name)
+		}
+	}
+	return nil
+}
+
+func mergeSelections(current, updates []*domain.Selection) []*domain.Selection {
+	byID := make(map[string]*domain.Selection, len(updates))
+	var added []*domain.Selection
+	for _, s := range updates {
+		if s.Result == "" {
+			s.Result = domain.SelectionResultPending
+		}
+		if s.ID == "" {
+			added = append(added, s)
+			continue
+		}
+		byID[s.ID] = s
+	}
+
+	out := make([]*domain.Selection, 0, len(current)+len(added))
+	for _, s := range current {
+		if u, ok := byID[s.ID]; ok {
+			out = append(out, u)
+			continue
+		}
+		out = append(out, s)
+	}
+	return append(out, added...)
+}
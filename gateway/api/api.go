@@ -0,0 +1,182 @@
+// Package api is the HTTP/JSON API the frontend talks to. Each route
+// translates to a call on one of the gRPC services, forwarding the caller's
+// access token, and is described in an OpenAPI document generated from the
+// route table.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"bet_service/muchway/bet_service/proto/betpb"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	eventpb "muchway/event_service/proto"
+	paymentpb "muchway/payment_service/pb"
+	"muchway/pkg/auth"
+	"muchway/pkg/clientip"
+	"muchway/user_service/proto/userpb"
+)
+
+// Prefix is where the current version of the API is mounted.
+const Prefix = "/api/v1"
+
+// maxBodySize caps request bodies.
+const maxBodySize = 1 << 20
+
+// Clients are the services the API translates to.
+type Clients struct {
+	Users    userpb.UserServiceClient
+	Events   eventpb.EventServiceClient
+	Bets     betpb.BetServiceClient
+	Payments paymentpb.PaymentServiceClient
+}
+
+type Config struct {
+	// SecureCookies marks the session cookies Secure, so browsers only send
+	// them over HTTPS. Browsers treat localhost as secure either way.
+	SecureCookies bool
+	// Proxies in front of the gateway, such as a load balancer, are trusted
+	// to report the browser's address in X-Forwarded-For. The address is
+	// passed on to the services, which limit logins per address.
+	Proxies clientip.Proxies
+}
+
+type API struct {
+	clients  Clients
+	verifier *auth.Verifier
+	cfg      Config
+	routes   []Route
+}
+
+func New(clients Clients, verifier *auth.Verifier, cfg Config) *API {
+	a := &API{clients: clients, verifier: verifier, cfg: cfg}
+	a.routes = append(a.routes, a.authRoutes()...)
+	a.routes = append(a.routes, a.eventRoutes()...)
+	a.routes = append(a.routes, a.betRoutes()...)
+	a.routes = append(a.routes, a.paymentRoutes()...)
+	return a
+}
+
+// Route is one operation of the API.
+type Route struct {
+	Method string
+	// Path is relative to Prefix, with parameters in braces: /bets/{id}.
+	Path    string
+	Tag     string
+	Summary string
+	// Auth routes need a signed-in user. Other routes still forward a valid
+	// token when there is one.
+	Auth bool
+	// Params are the query and header parameters the route reads.
+	Params []Param
+	// Request and Response are zero values of the JSON bodies, used to
+	// describe the route. Nil means there is no body.
+	Request  any
+	Response any
+	// Status is the status of a successful response, 200 if unset.
+	Status int
+	Handle func(w http.ResponseWriter, r *http.Request) (any, error)
+}
+
+type Param struct {
+	Name string
+	// In is "query" or "header".
+	In          string
+	Type        string
+	Description string
+}
+
+// Routes returns the route table.
+func (a *API) Routes() []Route {
+	return a.routes
+}
+
+// Handler serves the API and its OpenAPI document below Prefix.
+func (a *API) Handler() http.Handler {
+	r := mux.NewRouter()
+	api := r.PathPrefix(Prefix).Subrouter()
+	for _, route := range a.routes {
+		api.Handle(route.Path, a.serve(route)).Methods(route.Method)
+	}
+	doc := OpenAPI(a.routes)
+	api.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, doc)
+	}).Methods(http.MethodGet)
+
+	api.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, status.Error(codes.NotFound, "no such route"))
+	})
+	api.MethodNotAllowedHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, status.Error(codes.Unimplemented, "method not allowed"))
+	})
+	return r
+}
+
+func (a *API) serve(route Route) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+		client := a.cfg.Proxies.Resolve(r.RemoteAddr, r.Header.Values(clientip.Header))
+		r = r.WithContext(metadata.AppendToOutgoingContext(r.Context(), clientip.Header, client))
+		ctx, err := a.authenticate(r, route.Auth)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		body, err := route.Handle(w, r.WithContext(ctx))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		code := route.Status
+		if code == 0 {
+			code = http.StatusOK
+		}
+		if body == nil {
+			w.WriteHeader(code)
+			return
+		}
+		writeJSON(w, code, body)
+	})
+}
+
+// decode reads the JSON request body into v.
+func decode(r *http.Request, v any) error {
+	return decodeBody(r, v, false)
+}
+
+// decodeOptional is decode for a body the caller may leave out.
+func decodeOptional(r *http.Request, v any) error {
+	return decodeBody(r, v, true)
+}
+
+func decodeBody(r *http.Request, v any, optional bool) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	err := dec.Decode(v)
+	if err == io.EOF && optional {
+		return nil
+	}
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid request body: "+err.Error())
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// userID returns the signed-in user of an Auth route.
+func userID(ctx context.Context) string {
+	claims, _ := auth.FromContext(ctx)
+	return claims.Subject
+}
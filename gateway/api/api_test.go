@@ -0,0 +1,252 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"bet_service/muchway/bet_service/proto/betpb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"muchway/pkg/auth"
+	"muchway/pkg/clientip"
+	"muchway/pkg/money"
+	"muchway/user_service/proto/userpb"
+)
+
+// fakeUsers and fakeBets implement only the calls a test makes; any other
+// call panics on the nil embedded client.
+type fakeUsers struct {
+	userpb.UserServiceClient
+	login func(context.Context, *userpb.LoginRequest) (*userpb.LoginResponse, error)
+}
+
+func (f fakeUsers) Login(ctx context.Context, req *userpb.LoginRequest, _ ...grpc.CallOption) (*userpb.LoginResponse, error) {
+	return f.login(ctx, req)
+}
+
+type fakeBets struct {
+	betpb.BetServiceClient
+	create func(context.Context, *betpb.CreateBetRequest) (*betpb.CreateBetResponse, error)
+}
+
+func (f fakeBets) CreateBet(ctx context.Context, req *betpb.CreateBetRequest, _ ...grpc.CallOption) (*betpb.CreateBetResponse, error) {
+	return f.create(ctx, req)
+}
+
+func newTestAPI(t *testing.T, clients Clients) (*API, func(sub string) string) {
+	t.Helper()
+	key, err := auth.GenerateKey(time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier := auth.NewVerifier(auth.StaticKeySet{Keys: &auth.JWKS{Keys: []auth.JWK{key.JWK()}}}, "user_service")
+	sign := func(sub string) string {
+		token, err := auth.Sign(key, &auth.Claims{
+			Issuer: "user_service", Subject: sub, Role: auth.RoleBettor, Type: auth.TokenTypeAccess,
+			IssuedAt: time.Now().Unix(), ExpiresAt: time.Now().Add(time.Minute).Unix(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+	return New(clients, verifier, Config{SecureCookies: true}), sign
+}
+
+func decodeError(t *testing.T, rec *httptest.ResponseRecorder) Error {
+	t.Helper()
+	var body ErrorBody
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("error body: %v", err)
+	}
+	return body.Error
+}
+
+func TestLoginSetsSessionCookies(t *testing.T) {
+	a, _ := newTestAPI(t, Clients{Users: fakeUsers{login: func(_ context.Context, req *userpb.LoginRequest) (*userpb.LoginResponse, error) {
+		if req.Password != "secret123" {
+			return nil, status.Error(codes.Unauthenticated, "invalid email or password")
+		}
+		return &userpb.LoginResponse{
+			User:   &userpb.UserProfile{Id: 7, Email: req.Email},
+			Tokens: &userpb.TokenPair{AccessToken: "access", RefreshToken: "refresh", AccessTokenExpiresAt: time.Now().Add(time.Minute).Unix()},
+		}, nil
+	}}})
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(`{"email":"a@b.c","password":"secret123"}`))
+	a.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	cookies := map[string]*http.Cookie{}
+	for _, c := range rec.Result().Cookies() {
+		cookies[c.Name] = c
+	}
+	if c := cookies[accessCookie]; c == nil || c.Value != "access" || !c.HttpOnly || !c.Secure || c.SameSite != http.SameSiteStrictMode {
+		t.Errorf("access cookie = %+v", c)
+	}
+	if c := cookies[refreshCookie]; c == nil || c.Value != "refresh" || c.Path != refreshPath {
+		t.Errorf("refresh cookie = %+v", c)
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(`{"email":"a@b.c","password":"wrong"}`))
+	a.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want 401", rec.Code)
+	}
+	if e := decodeError(t, rec); e.Code != "unauthenticated" || e.Message != "invalid email or password" {
+		t.Errorf("error = %+v", e)
+	}
+}
+
+func TestLoginForwardsTheClientAddress(t *testing.T) {
+	var forwarded []string
+	a, _ := newTestAPI(t, Clients{Users: fakeUsers{login: func(ctx context.Context, req *userpb.LoginRequest) (*userpb.LoginResponse, error) {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		forwarded = md.Get(clientip.Header)
+		return nil, status.Error(codes.Unauthenticated, "invalid email or password")
+	}}})
+	proxies, _ := clientip.ParseProxies([]string{"10.0.0.0/8"})
+
+	cases := []struct {
+		name    string
+		proxies clientip.Proxies
+		remote  string
+		header  string
+		want    string
+	}{
+		{"browser", nil, "203.0.113.7:5000", "", "203.0.113.7"},
+		{"browser forging the header", nil, "203.0.113.7:5000", "198.51.100.1", "203.0.113.7"},
+		{"behind a trusted load balancer", proxies, "10.0.0.2:5000", "203.0.113.7", "203.0.113.7"},
+	}
+	for _, c := range cases {
+		a.cfg.Proxies = c.proxies
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", strings.NewReader(`{"email":"a@b.c","password":"x"}`))
+		req.RemoteAddr = c.remote
+		if c.header != "" {
+			req.Header.Set("X-Forwarded-For", c.header)
+		}
+		a.Handler().ServeHTTP(httptest.NewRecorder(), req)
+		if len(forwarded) != 1 || forwarded[0] != c.want {
+			t.Errorf("%s: forwarded %s %q, want %q", c.name, clientip.Header, forwarded, c.want)
+		}
+	}
+}
+
+func TestPlaceBetForwardsTheCaller(t *testing.T) {
+	var forwarded string
+	a, sign := newTestAPI(t, Clients{Bets: fakeBets{create: func(ctx context.Context, req *betpb.CreateBetRequest) (*betpb.CreateBetResponse, error) {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		forwarded = strings.Join(md.Get("authorization"), "")
+		if req.Bet.UserId != "7" || req.IdempotencyKey != "k1" || req.OddsChangePolicy != betpb.OddsChangePolicy_ODDS_CHANGE_POLICY_ACCEPT_HIGHER {
+			t.Errorf("request = %+v", req)
+		}
+		req.Bet.Id, req.Bet.Status = "b1", "pending"
+		return &betpb.CreateBetResponse{Bet: req.Bet}, nil
+	}}})
+	token := sign("7")
+
+	body := `{"selection_id":"s1","stake":{"amount":"5.00","currency":"USD"},"odds":2.5,"odds_change":"accept_higher"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/bets", strings.NewReader(body))
+	req.AddCookie(&http.Cookie{Name: accessCookie, Value: token})
+	req.Header.Set("Idempotency-Key", "k1")
+	rec := httptest.NewRecorder()
+	a.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	if forwarded != "Bearer "+token {
+		t.Errorf("forwarded authorization %q", forwarded)
+	}
+	var bet Bet
+	if err := json.NewDecoder(rec.Body).Decode(&bet); err != nil {
+		t.Fatal(err)
+	}
+	if bet.ID != "b1" || bet.Stake != money.MustParse("5.00") {
+		t.Errorf("bet = %+v", bet)
+	}
+
+	// The same cookie from another site is refused
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/bets", strings.NewReader(body))
+	req.AddCookie(&http.Cookie{Name: accessCookie, Value: token})
+	req.Header.Set("Origin", "https://evil.example")
+	rec = httptest.NewRecorder()
+	a.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("cross-site status = %d, want 403", rec.Code)
+	}
+
+	// And without a token there is nothing to forward
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/bets", strings.NewReader(body))
+	rec = httptest.NewRecorder()
+	a.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("anonymous status = %d, want 401", rec.Code)
+	}
+}
+
+func TestErrorMapping(t *testing.T) {
+	st, _ := status.New(codes.Aborted, "odds changed").WithDetails(&betpb.OddsChanged{SelectionId: "s1", RequestedOdds: 2.5, CurrentOdds: 2.1})
+	tests := []struct {
+		err     error
+		status  int
+		code    string
+		message string
+	}{
+		{status.Error(codes.FailedPrecondition, "daily deposit limit reached"), http.StatusUnprocessableEntity, "failed_precondition", "daily deposit limit reached"},
+		{status.Error(codes.NotFound, "bet not found"), http.StatusNotFound, "not_found", "bet not found"},
+		{status.Error(codes.Internal, "pq: connection refused"), http.StatusInternalServerError, "internal", "internal error"},
+		{st.Err(), http.StatusConflict, "aborted", "odds changed"},
+	}
+	for _, tt := range tests {
+		code, body := toError(tt.err)
+		if code != tt.status || body.Error.Code != tt.code || body.Error.Message != tt.message {
+			t.Errorf("toError(%v) = %d %+v", tt.err, code, body.Error)
+		}
+	}
+
+	_, body := toError(st.Err())
+	if len(body.Error.Details) != 1 || !strings.Contains(string(body.Error.Details[0]), `"currentOdds":2.1`) {
+		t.Errorf("details = %s", body.Error.Details)
+	}
+}
+
+func TestOpenAPIDescribesEveryRoute(t *testing.T) {
+	a, _ := newTestAPI(t, Clients{})
+	doc := OpenAPI(a.Routes())
+	paths := doc["paths"].(map[string]map[string]any)
+	for _, r := range a.Routes() {
+		if _, ok := paths[Prefix+r.Path][strings.ToLower(r.Method)]; !ok {
+			t.Errorf("%s %s is not described", r.Method, r.Path)
+		}
+	}
+
+	// Every reference resolves
+	b, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas := doc["components"].(map[string]any)["schemas"].(map[string]any)
+	for _, part := range strings.Split(string(b), `"$ref":"#/components/schemas/`)[1:] {
+		name := part[:strings.IndexByte(part, '"')]
+		if _, ok := schemas[name]; !ok {
+			t.Errorf("unresolved reference to %s", name)
+		}
+	}
+
+	bet := schemas["PlaceBetRequest"].(map[string]any)
+	if got := strings.Join(bet["required"].([]string), ","); got != "event_id,odds,selection_id,stake" {
+		t.Errorf("PlaceBetRequest required = %s", got)
+	}
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"muchway/pkg/auth"
+	"muchway/user_service/proto/userpb"
+)
+
+// Browsers keep the tokens in HttpOnly cookies; other clients send the
+// access token as "Authorization: Bearer <token>" and keep the refresh token
+// themselves.
+const (
+	accessCookie  = "access_token"
+	refreshCookie = "refresh_token"
+	// refreshPath limits the refresh cookie to the routes that use it.
+	refreshPath = Prefix + "/auth"
+)
+
+// authenticate verifies the caller's access token and returns a context
+// that carries its claims and forwards the token to the services. A route
+// without Auth goes ahead without a token, or with one that fails to
+// verify, as if the caller were anonymous.
+func (a *API) authenticate(r *http.Request, required bool) (context.Context, error) {
+	ctx := r.Context()
+	token, fromCookie := bearerToken(r)
+	if token == "" {
+		if required {
+			return nil, status.Error(codes.Unauthenticated, "sign in first")
+		}
+		return ctx, nil
+	}
+	claims, err := a.verifier.Verify(ctx, token)
+	if err != nil {
+		if !required {
+			return ctx, nil
+		}
+		if errors.Is(err, auth.ErrExpiredToken) {
+			return nil, status.Error(codes.Unauthenticated, "token has expired")
+		}
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	// Cookies are sent with cross-site requests too; SameSite stops most of
+	// them, and this stops the rest from changing anything
+	if fromCookie && r.Method != http.MethodGet && !sameOrigin(r) {
+		return nil, status.Error(codes.PermissionDenied, "cross-site request refused")
+	}
+	ctx = auth.NewContext(ctx, claims)
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token), nil
+}
+
+// bearerToken returns the access token from the Authorization header or,
+// failing that, the cookie, and whether it came from the cookie.
+func bearerToken(r *http.Request) (string, bool) {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && token != "" {
+		return token, false
+	}
+	if c, err := r.Cookie(accessCookie); err == nil && c.Value != "" {
+		return c.Value, true
+	}
+	return "", false
+}
+
+// sameOrigin reports whether a request comes from a page of this site.
+// Requests without an Origin header come from same-origin navigation or
+// from clients other than browsers.
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Host == r.Host
+}
+
+func (a *API) setSession(w http.ResponseWriter, tokens *userpb.TokenPair) {
+	http.SetCookie(w, a.cookie(accessCookie, Prefix, tokens.GetAccessToken(), time.Unix(tokens.GetAccessTokenExpiresAt(), 0)))
+	http.SetCookie(w, a.cookie(refreshCookie, refreshPath, tokens.GetRefreshToken(), time.Unix(tokens.GetRefreshTokenExpiresAt(), 0)))
+}
+
+func (a *API) setAccessToken(w http.ResponseWriter, token string, expiresAt int64) {
+	http.SetCookie(w, a.cookie(accessCookie, Prefix, token, time.Unix(expiresAt, 0)))
+}
+
+func (a *API) clearSession(w http.ResponseWriter) {
+	for name, path := range map[string]string{accessCookie: Prefix, refreshCookie: refreshPath} {
+		c := a.cookie(name, path, "", time.Unix(0, 0))
+		c.MaxAge = -1
+		http.SetCookie(w, c)
+	}
+}
+
+func (a *API) cookie(name, path, value string, expires time.Time) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   a.cfg.SecureCookies,
+		SameSite: http.SameSiteStrictMode,
+	}
+}
+
+// refreshToken returns the refresh token from the body or the cookie.
+func refreshToken(r *http.Request, body string) string {
+	if body != "" {
+		return body
+	}
+	if c, err := r.Cookie(refreshCookie); err == nil {
+		return c.Value
+	}
+	return ""
+}
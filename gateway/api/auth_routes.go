@@ -0,0 +1,162 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"muchway/user_service/proto/userpb"
+)
+
+func (a *API) authRoutes() []Route {
+	return []Route{
+		{
+			Method: http.MethodPost, Path: "/auth/signup", Tag: "auth",
+			Summary: "Create an account",
+			Request: SignupRequest{}, Response: User{}, Status: http.StatusCreated,
+			Handle: a.signup,
+		},
+		{
+			Method: http.MethodPost, Path: "/auth/login", Tag: "auth",
+			Summary: "Log in with email and password",
+			Request: LoginRequest{}, Response: LoginResult{},
+			Handle: a.login,
+		},
+		{
+			Method: http.MethodPost, Path: "/auth/login/mfa", Tag: "auth",
+			Summary: "Finish a login with a two-factor code",
+			Request: CompleteLoginRequest{}, Response: LoginResult{},
+			Handle: a.completeLogin,
+		},
+		{
+			Method: http.MethodPost, Path: "/auth/refresh", Tag: "auth",
+			Summary: "Exchange the refresh token for new tokens",
+			Request: RefreshRequest{}, Response: Tokens{},
+			Handle: a.refresh,
+		},
+		{
+			Method: http.MethodPost, Path: "/auth/logout", Tag: "auth",
+			Summary: "Log out and revoke the refresh token",
+			Request: RefreshRequest{}, Status: http.StatusNoContent,
+			Handle: a.logout,
+		},
+		{
+			Method: http.MethodPost, Path: "/auth/step-up", Tag: "auth", Auth: true,
+			Summary: "Prove a second factor, as large withdrawals require",
+			Request: StepUpRequest{}, Response: StepUpResult{},
+			Handle: a.stepUp,
+		},
+		{
+			Method: http.MethodGet, Path: "/me", Tag: "auth", Auth: true,
+			Summary:  "The signed-in user",
+			Response: User{},
+			Handle:   a.me,
+		},
+	}
+}
+
+func (a *API) signup(w http.ResponseWriter, r *http.Request) (any, error) {
+	var req SignupRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	resp, err := a.clients.Users.CreateUser(r.Context(), &userpb.CreateUserRequest{User: &userpb.User{
+		Email:    req.Email,
+		Username: req.Username,
+		Password: req.Password,
+	}})
+	if err != nil {
+		return nil, err
+	}
+	return toUser(resp.GetUser()), nil
+}
+
+func (a *API) login(w http.ResponseWriter, r *http.Request) (any, error) {
+	var req LoginRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	resp, err := a.clients.Users.Login(r.Context(), &userpb.LoginRequest{Email: req.Email, Password: req.Password})
+	if err != nil {
+		return nil, err
+	}
+	return a.loginResult(w, resp), nil
+}
+
+func (a *API) completeLogin(w http.ResponseWriter, r *http.Request) (any, error) {
+	var req CompleteLoginRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	resp, err := a.clients.Users.CompleteLogin(r.Context(), &userpb.CompleteLoginRequest{MfaChallenge: req.MFAChallenge, Code: req.Code})
+	if err != nil {
+		return nil, err
+	}
+	return a.loginResult(w, resp), nil
+}
+
+func (a *API) loginResult(w http.ResponseWriter, resp *userpb.LoginResponse) *LoginResult {
+	if resp.GetMfaRequired() {
+		return &LoginResult{MFARequired: true, MFAChallenge: resp.GetMfaChallenge()}
+	}
+	a.setSession(w, resp.GetTokens())
+	return &LoginResult{User: toUser(resp.GetUser()), Tokens: toTokens(resp.GetTokens())}
+}
+
+func (a *API) refresh(w http.ResponseWriter, r *http.Request) (any, error) {
+	var req RefreshRequest
+	if err := decodeOptional(r, &req); err != nil {
+		return nil, err
+	}
+	token := refreshToken(r, req.RefreshToken)
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "sign in first")
+	}
+	resp, err := a.clients.Users.RefreshToken(r.Context(), &userpb.RefreshTokenRequest{RefreshToken: token})
+	if err != nil {
+		return nil, err
+	}
+	a.setSession(w, resp.GetTokens())
+	return toTokens(resp.GetTokens()), nil
+}
+
+func (a *API) logout(w http.ResponseWriter, r *http.Request) (any, error) {
+	var req RefreshRequest
+	if err := decodeOptional(r, &req); err != nil {
+		return nil, err
+	}
+	a.clearSession(w)
+	token := refreshToken(r, req.RefreshToken)
+	if token == "" {
+		return nil, nil
+	}
+	_, err := a.clients.Users.Logout(r.Context(), &userpb.LogoutRequest{RefreshToken: token})
+	return nil, err
+}
+
+func (a *API) stepUp(w http.ResponseWriter, r *http.Request) (any, error) {
+	var req StepUpRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	resp, err := a.clients.Users.StepUp(r.Context(), &userpb.StepUpRequest{Code: req.Code})
+	if err != nil {
+		return nil, err
+	}
+	a.setAccessToken(w, resp.GetAccessToken(), resp.GetExpiresAt())
+	return &StepUpResult{AccessToken: resp.GetAccessToken(), ExpiresAt: resp.GetExpiresAt()}, nil
+}
+
+func (a *API) me(w http.ResponseWriter, r *http.Request) (any, error) {
+	id, err := strconv.ParseInt(userID(r.Context()), 10, 64)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, "not a user account")
+	}
+	resp, err := a.clients.Users.GetUserByID(r.Context(), &userpb.GetUserByIDRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	return toUser(resp.GetUser()), nil
+}
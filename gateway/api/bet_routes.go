@@ -0,0 +1,99 @@
+package api
+
+import (
+	"net/http"
+
+	"bet_service/muchway/bet_service/proto/betpb"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"muchway/pkg/money"
+)
+
+// idempotencyKey lets a client retry a request that moves money without
+// doing it twice.
+var idempotencyKey = Param{
+	Name:        "Idempotency-Key",
+	In:          "header",
+	Type:        "string",
+	Description: "Retrying with the same key returns the original result instead of repeating the operation.",
+}
+
+var oddsChangePolicies = map[string]betpb.OddsChangePolicy{
+	"":              betpb.OddsChangePolicy_ODDS_CHANGE_POLICY_REJECT,
+	"reject":        betpb.OddsChangePolicy_ODDS_CHANGE_POLICY_REJECT,
+	"accept_higher": betpb.OddsChangePolicy_ODDS_CHANGE_POLICY_ACCEPT_HIGHER,
+	"accept_any":    betpb.OddsChangePolicy_ODDS_CHANGE_POLICY_ACCEPT_ANY,
+}
+
+func (a *API) betRoutes() []Route {
+	return []Route{
+		{
+			Method: http.MethodPost, Path: "/bets", Tag: "bets", Auth: true,
+			Summary: "Place a bet",
+			Params:  []Param{idempotencyKey},
+			Request: PlaceBetRequest{}, Response: Bet{}, Status: http.StatusCreated,
+			Handle: a.placeBet,
+		},
+		{
+			Method: http.MethodGet, Path: "/bets", Tag: "bets", Auth: true,
+			Summary:  "List the signed-in user's bets",
+			Response: []Bet{},
+			Handle:   a.listBets,
+		},
+		{
+			Method: http.MethodGet, Path: "/bets/{id}", Tag: "bets", Auth: true,
+			Summary:  "Get a bet",
+			Response: Bet{},
+			Handle:   a.getBet,
+		},
+	}
+}
+
+func (a *API) placeBet(w http.ResponseWriter, r *http.Request) (any, error) {
+	var req PlaceBetRequest
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+	policy, ok := oddsChangePolicies[req.OddsChange]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, `odds_change must be "reject", "accept_higher" or "accept_any"`)
+	}
+	resp, err := a.clients.Bets.CreateBet(r.Context(), &betpb.CreateBetRequest{
+		Bet: &betpb.Bet{
+			UserId:      userID(r.Context()),
+			EventId:     req.EventID,
+			SelectionId: req.SelectionID,
+			Amount:      money.ToProto(req.Stake),
+			Odds:        req.Odds,
+		},
+		OddsChangePolicy: policy,
+		IdempotencyKey:   r.Header.Get(idempotencyKey.Name),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toBet(resp.GetBet()), nil
+}
+
+func (a *API) listBets(w http.ResponseWriter, r *http.Request) (any, error) {
+	resp, err := a.clients.Bets.GetBetsByUserID(r.Context(), &betpb.GetBetsByUserIDRequest{UserId: userID(r.Context())})
+	if err != nil {
+		return nil, err
+	}
+	bets := make([]Bet, 0, len(resp.GetBets()))
+	for _, b := range resp.GetBets() {
+		bets = append(bets, toBet(b))
+	}
+	return bets, nil
+}
+
+func (a *API) getBet(w http.ResponseWriter, r *http.Request) (any, error) {
+	resp, err := a.clients.Bets.GetBetByID(r.Context(), &betpb.GetBetByIDRequest{Id: mux.Vars(r)["id"]})
+	if err != nil {
+		return nil, err
+	}
+	return toBet(resp.GetBet()), nil
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrorBody is what every failed request returns.
+type ErrorBody struct {
+	Error Error `json:"error"`
+}
+
+type Error struct {
+	// Code is the gRPC status code in snake case, such as "not_found".
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Details carry structured information, such as the current odds when
+	// a bet was refused because the price moved.
+	Details []json.RawMessage `json:"details,omitempty"`
+}
+
+// httpStatus maps gRPC status codes to HTTP statuses.
+var httpStatus = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499,
+	codes.Unknown:            http.StatusInternalServerError,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusUnprocessableEntity,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Internal:           http.StatusInternalServerError,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.DataLoss:           http.StatusInternalServerError,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+// toError converts err, normally a gRPC status, to the error body and its
+// HTTP status. Internal errors are not described to the caller.
+func toError(err error) (int, ErrorBody) {
+	st, _ := status.FromError(err)
+	code, ok := httpStatus[st.Code()]
+	if !ok {
+		code = http.StatusInternalServerError
+	}
+	body := Error{Code: codeName(st.Code()), Message: st.Message()}
+	if code == http.StatusInternalServerError {
+		body.Message = "internal error"
+	} else {
+		for _, d := range st.Details() {
+			m, ok := d.(proto.Message)
+			if !ok {
+				continue
+			}
+			if b, err := protojson.Marshal(m); err == nil {
+				body.Details = append(body.Details, b)
+			}
+		}
+	}
+	return code, ErrorBody{Error: body}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	code, body := toError(err)
+	writeJSON(w, code, body)
+}
+
+// codeName turns codes.FailedPrecondition into "failed_precondition".
+func codeName(c codes.Code) string {
+	var b strings.Builder
+	for i, r := range c.String() {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
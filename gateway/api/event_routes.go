@@ -0,0 +1,78 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	eventpb "muchway/event_service/proto"
+)
+
+func (a *API) eventRoutes() []Route {
+	return []Route{
+		{
+			Method: http.MethodGet, Path: "/events", Tag: "events",
+			Summary:  "List events",
+			Response: []Event{},
+			Handle:   a.listEvents,
+		},
+		{
+			Method: http.MethodGet, Path: "/events/{id}", Tag: "events",
+			Summary:  "Get an event",
+			Response: Event{},
+			Handle:   a.getEvent,
+		},
+		{
+			Method: http.MethodGet, Path: "/events/{id}/markets", Tag: "events",
+			Summary:  "List the markets of an event with their selections",
+			Response: []Market{},
+			Handle:   a.listMarkets,
+		},
+		{
+			Method: http.MethodGet, Path: "/markets/{id}", Tag: "events",
+			Summary:  "Get a market with its selections",
+			Response: Market{},
+			Handle:   a.getMarket,
+		},
+	}
+}
+
+func (a *API) listEvents(w http.ResponseWriter, r *http.Request) (any, error) {
+	resp, err := a.clients.Events.ListEvents(r.Context(), &eventpb.ListEventsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]Event, 0, len(resp.GetEvents()))
+	for _, e := range resp.GetEvents() {
+		events = append(events, toEvent(e))
+	}
+	return events, nil
+}
+
+func (a *API) getEvent(w http.ResponseWriter, r *http.Request) (any, error) {
+	resp, err := a.clients.Events.GetEvent(r.Context(), &eventpb.GetEventRequest{Id: mux.Vars(r)["id"]})
+	if err != nil {
+		return nil, err
+	}
+	return toEvent(resp.GetEvent()), nil
+}
+
+func (a *API) listMarkets(w http.ResponseWriter, r *http.Request) (any, error) {
+	resp, err := a.clients.Events.ListMarkets(r.Context(), &eventpb.ListMarketsRequest{EventId: mux.Vars(r)["id"]})
+	if err != nil {
+		return nil, err
+	}
+	markets := make([]Market, 0, len(resp.GetMarkets()))
+	for _, m := range resp.GetMarkets() {
+		markets = append(markets, toMarket(m))
+	}
+	return markets, nil
+}
+
+func (a *API) getMarket(w http.ResponseWriter, r *http.Request) (any, error) {
+	resp, err := a.clients.Events.GetMarket(r.Context(), &eventpb.GetMarketRequest{Id: mux.Vars(r)["id"]})
+	if err != nil {
+		return nil, err
+	}
+	return toMarket(resp.GetMarket()), nil
+}
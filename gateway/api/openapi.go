@@ -0,0 +1,197 @@
+package api
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"muchway/pkg/money"
+)
+
+var pathParam = regexp.MustCompile(`\{(\w+)\}`)
+
+var moneyType = reflect.TypeOf(money.Money{})
+
+// OpenAPI describes the routes as an OpenAPI 3 document. Schemas are derived
+// from the Go types of the request and response bodies and their json tags:
+// fields without omitempty are required.
+func OpenAPI(routes []Route) map[string]any {
+	schemas := map[string]any{
+		"Money": map[string]any{
+			"type":     "object",
+			"required": []string{"amount", "currency"},
+			"properties": map[string]any{
+				"amount":   map[string]any{"type": "string", "example": "12.50"},
+				"currency": map[string]any{"type": "string", "example": "USD"},
+			},
+		},
+	}
+	schemaOf(reflect.TypeOf(ErrorBody{}), schemas)
+
+	paths := map[string]map[string]any{}
+	for _, route := range routes {
+		path := Prefix + route.Path
+		if paths[path] == nil {
+			paths[path] = map[string]any{}
+		}
+		paths[path][strings.ToLower(route.Method)] = operation(route, schemas)
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "MuchWayBet API",
+			"version": strings.TrimPrefix(Prefix, "/api/"),
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"bearer": map[string]any{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+				"cookie": map[string]any{"type": "apiKey", "in": "cookie", "name": accessCookie},
+			},
+		},
+	}
+}
+
+func operation(route Route, schemas map[string]any) map[string]any {
+	op := map[string]any{
+		"operationId": operationID(route),
+		"summary":     route.Summary,
+		"tags":        []string{route.Tag},
+	}
+
+	var params []any
+	for _, m := range pathParam.FindAllStringSubmatch(route.Path, -1) {
+		params = append(params, map[string]any{
+			"name": m[1], "in": "path", "required": true,
+			"schema": map[string]any{"type": "string"},
+		})
+	}
+	for _, p := range route.Params {
+		params = append(params, map[string]any{
+			"name": p.Name, "in": p.In, "description": p.Description,
+			"schema": map[string]any{"type": p.Type},
+		})
+	}
+	if params != nil {
+		op["parameters"] = params
+	}
+
+	if route.Request != nil {
+		op["requestBody"] = map[string]any{
+			"required": true,
+			"content":  jsonContent(schemaOf(reflect.TypeOf(route.Request), schemas)),
+		}
+	}
+
+	code := route.Status
+	if code == 0 {
+		code = http.StatusOK
+	}
+	success := map[string]any{"description": http.StatusText(code)}
+	if route.Response != nil {
+		success["content"] = jsonContent(schemaOf(reflect.TypeOf(route.Response), schemas))
+	}
+	op["responses"] = map[string]any{
+		strconv.Itoa(code): success,
+		"default": map[string]any{
+			"description": "Error",
+			"content":     jsonContent(ref("ErrorBody")),
+		},
+	}
+
+	if route.Auth {
+		op["security"] = []any{
+			map[string]any{"bearer": []string{}},
+			map[string]any{"cookie": []string{}},
+		}
+	}
+	return op
+}
+
+// operationID names an operation after its method and path, such as
+// "get_events_id_markets".
+func operationID(route Route) string {
+	return strings.ToLower(route.Method) + strings.NewReplacer("/", "_", "{", "", "}", "", "-", "_").Replace(route.Path)
+}
+
+// schemaOf returns the schema of t. Named structs are added to schemas and
+// referred to.
+func schemaOf(t reflect.Type, schemas map[string]any) map[string]any {
+	if t == moneyType {
+		return ref("Money")
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := schemaOf(t.Elem(), schemas)
+		if _, isRef := s["$ref"]; isRef {
+			return map[string]any{"allOf": []any{s}, "nullable": true}
+		}
+		s["nullable"] = true
+		return s
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json writes []byte as base64, json.RawMessage as is
+			return map[string]any{}
+		}
+		return map[string]any{"type": "array", "items": schemaOf(t.Elem(), schemas)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaOf(t.Elem(), schemas)}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer", "format": "int" + strconv.Itoa(t.Bits())}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number", "format": "double"}
+	case reflect.Struct:
+		if _, seen := schemas[t.Name()]; !seen {
+			// Placeholder first, in case the type refers to itself
+			schemas[t.Name()] = map[string]any{}
+			schemas[t.Name()] = structSchema(t, schemas)
+		}
+		return ref(t.Name())
+	}
+	return map[string]any{}
+}
+
+func structSchema(t reflect.Type, schemas map[string]any) map[string]any {
+	props := map[string]any{}
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		props[name] = schemaOf(f.Type, schemas)
+		if !strings.Contains(opts, "omitempty") {
+			required = append(required, name)
+		}
+	}
+	s := map[string]any{"type": "object", "properties": props}
+	if required != nil {
+		sort.Strings(required)
+		s["required"] = required
+	}
+	return s
+}
+
+func ref(name string) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + name}
+}
+
+func jsonContent(schema map[string]any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
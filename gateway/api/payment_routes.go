@@ -0,0 +1,130 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	paymentpb "muchway/payment_service/pb"
+	"muchway/pkg/money"
+)
+
+func (a *API) paymentRoutes() []Route {
+	return []Route{
+		{
+			Method: http.MethodPost, Path: "/payments/deposits", Tag: "payments", Auth: true,
+			Summary: "Deposit into the wallet",
+			Params:  []Param{idempotencyKey},
+			Request: PaymentRequest{}, Response: Payment{}, Status: http.StatusCreated,
+			Handle: a.payment("deposit"),
+		},
+		{
+			Method: http.MethodPost, Path: "/payments/withdrawals", Tag: "payments", Auth: true,
+			Summary: "Withdraw from the wallet. Large amounts need a recent step-up and a verified identity.",
+			Params:  []Param{idempotencyKey},
+			Request: PaymentRequest{}, Response: Payment{}, Status: http.StatusCreated,
+			Handle: a.payment("withdraw"),
+		},
+		{
+			Method: http.MethodGet, Path: "/payments/{id}", Tag: "payments", Auth: true,
+			Summary:  "Get a payment",
+			Response: Payment{},
+			Handle:   a.getPayment,
+		},
+		{
+			Method: http.MethodGet, Path: "/wallet", Tag: "wallet", Auth: true,
+			Summary:  "The signed-in user's balances",
+			Response: Wallet{},
+			Handle:   a.wallet,
+		},
+		{
+			Method: http.MethodGet, Path: "/wallet/ledger", Tag: "wallet", Auth: true,
+			Summary: "The signed-in user's ledger entries",
+			Params: []Param{
+				{Name: "limit", In: "query", Type: "integer", Description: "At most this many entries."},
+				{Name: "offset", In: "query", Type: "integer", Description: "Skip this many entries."},
+			},
+			Response: []LedgerEntry{},
+			Handle:   a.ledger,
+		},
+	}
+}
+
+func (a *API) payment(paymentType string) func(w http.ResponseWriter, r *http.Request) (any, error) {
+	return func(w http.ResponseWriter, r *http.Request) (any, error) {
+		var req PaymentRequest
+		if err := decode(r, &req); err != nil {
+			return nil, err
+		}
+		resp, err := a.clients.Payments.CreatePayment(r.Context(), &paymentpb.CreatePaymentRequest{
+			UserId:         userID(r.Context()),
+			Type:           paymentType,
+			Amount:         money.ToProto(req.Amount),
+			IdempotencyKey: r.Header.Get(idempotencyKey.Name),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return toPayment(resp), nil
+	}
+}
+
+func (a *API) getPayment(w http.ResponseWriter, r *http.Request) (any, error) {
+	resp, err := a.clients.Payments.GetPayment(r.Context(), &paymentpb.GetPaymentRequest{Id: mux.Vars(r)["id"]})
+	if err != nil {
+		return nil, err
+	}
+	return toPayment(resp), nil
+}
+
+func (a *API) wallet(w http.ResponseWriter, r *http.Request) (any, error) {
+	resp, err := a.clients.Payments.GetBalance(r.Context(), &paymentpb.GetBalanceRequest{UserId: userID(r.Context())})
+	if err != nil {
+		return nil, err
+	}
+	return Wallet{
+		Wallet:  money.FromProto(resp.GetWallet()),
+		Pending: money.FromProto(resp.GetPending()),
+		Bonus:   money.FromProto(resp.GetBonus()),
+	}, nil
+}
+
+func (a *API) ledger(w http.ResponseWriter, r *http.Request) (any, error) {
+	limit, err := queryInt(r, "limit")
+	if err != nil {
+		return nil, err
+	}
+	offset, err := queryInt(r, "offset")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := a.clients.Payments.ListLedgerEntries(r.Context(), &paymentpb.ListLedgerEntriesRequest{
+		UserId: userID(r.Context()),
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]LedgerEntry, 0, len(resp.GetEntries()))
+	for _, e := range resp.GetEntries() {
+		entries = append(entries, toLedgerEntry(e))
+	}
+	return entries, nil
+}
+
+// queryInt reads a non-negative integer query parameter, zero if absent.
+func queryInt(r *http.Request, name string) (int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 || n > 1<<20 {
+		return 0, status.Errorf(codes.InvalidArgument, "%s must be a non-negative integer", name)
+	}
+	return n, nil
+}
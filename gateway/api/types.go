@@ -0,0 +1,234 @@
+package api
+
+import (
+	"bet_service/muchway/bet_service/proto/betpb"
+
+	eventpb "muchway/event_service/proto"
+	paymentpb "muchway/payment_service/pb"
+	"muchway/pkg/money"
+	"muchway/user_service/proto/userpb"
+)
+
+// The JSON bodies of the API. Times are Unix seconds and amounts are
+// {"amount": "12.50", "currency": "USD"}.
+
+type User struct {
+	ID            int64       `json:"id"`
+	Username      string      `json:"username"`
+	Email         string      `json:"email"`
+	Role          string      `json:"role"`
+	Balance       money.Money `json:"balance"`
+	EmailVerified bool        `json:"email_verified"`
+}
+
+type SignupRequest struct {
+	Email    string `json:"email"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type CompleteLoginRequest struct {
+	MFAChallenge string `json:"mfa_challenge"`
+	Code         string `json:"code"`
+}
+
+// LoginResult carries the tokens, or an MFA challenge to answer at
+// /auth/login/mfa. Browsers can ignore the tokens, which are set as cookies
+// as well.
+type LoginResult struct {
+	User         *User   `json:"user,omitempty"`
+	Tokens       *Tokens `json:"tokens,omitempty"`
+	MFARequired  bool    `json:"mfa_required"`
+	MFAChallenge string  `json:"mfa_challenge,omitempty"`
+}
+
+type Tokens struct {
+	AccessToken           string `json:"access_token"`
+	RefreshToken          string `json:"refresh_token"`
+	AccessTokenExpiresAt  int64  `json:"access_token_expires_at"`
+	RefreshTokenExpiresAt int64  `json:"refresh_token_expires_at"`
+}
+
+// RefreshRequest may leave out the refresh token when it is in a cookie.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+type StepUpRequest struct {
+	Code string `json:"code"`
+}
+
+type StepUpResult struct {
+	AccessToken string `json:"access_token"`
+	ExpiresAt   int64  `json:"expires_at"`
+}
+
+type Event struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	StartTime string `json:"start_time"`
+	Status    string `json:"status"`
+	WinnerID  string `json:"winner_id,omitempty"`
+}
+
+type Market struct {
+	ID         string      `json:"id"`
+	EventID    string      `json:"event_id"`
+	Type       string      `json:"type"`
+	Name       string      `json:"name"`
+	Line       *float64    `json:"line,omitempty"`
+	Status     string      `json:"status"`
+	Selections []Selection `json:"selections"`
+}
+
+type Selection struct {
+	ID       string  `json:"id"`
+	MarketID string  `json:"market_id"`
+	Name     string  `json:"name"`
+	Price    float64 `json:"price"`
+	Result   string  `json:"result,omitempty"`
+}
+
+type Bet struct {
+	ID          string      `json:"id"`
+	EventID     string      `json:"event_id"`
+	SelectionID string      `json:"selection_id"`
+	Stake       money.Money `json:"stake"`
+	Odds        float64     `json:"odds"`
+	Status      string      `json:"status"`
+	Payout      money.Money `json:"payout"`
+}
+
+// PlaceBetRequest places a bet at odds, the price the bettor saw.
+// OddsChange says what to do if the price has moved since: "reject" (the
+// default), "accept_higher" or "accept_any".
+type PlaceBetRequest struct {
+	EventID     string      `json:"event_id"`
+	SelectionID string      `json:"selection_id"`
+	Stake       money.Money `json:"stake"`
+	Odds        float64     `json:"odds"`
+	OddsChange  string      `json:"odds_change,omitempty"`
+}
+
+type Payment struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	Amount    money.Money `json:"amount"`
+	Status    string      `json:"status"`
+	CreatedAt int64       `json:"created_at"`
+}
+
+type PaymentRequest struct {
+	Amount money.Money `json:"amount"`
+}
+
+type Wallet struct {
+	Wallet  money.Money `json:"wallet"`
+	Pending money.Money `json:"pending"`
+	Bonus   money.Money `json:"bonus"`
+}
+
+type LedgerEntry struct {
+	ID        string       `json:"id"`
+	Kind      string       `json:"kind"`
+	Reference string       `json:"reference"`
+	Lines     []LedgerLine `json:"lines"`
+	CreatedAt int64        `json:"created_at"`
+}
+
+type LedgerLine struct {
+	AccountType string      `json:"account_type"`
+	Debit       money.Money `json:"debit"`
+	Credit      money.Money `json:"credit"`
+}
+
+func toUser(u *userpb.UserProfile) *User {
+	if u == nil {
+		return nil
+	}
+	return &User{
+		ID:            u.GetId(),
+		Username:      u.GetUsername(),
+		Email:         u.GetEmail(),
+		Role:          u.GetRole(),
+		Balance:       money.FromProto(u.GetBalance()),
+		EmailVerified: u.GetEmailVerified(),
+	}
+}
+
+func toTokens(t *userpb.TokenPair) *Tokens {
+	if t == nil {
+		return nil
+	}
+	return &Tokens{
+		AccessToken:           t.GetAccessToken(),
+		RefreshToken:          t.GetRefreshToken(),
+		AccessTokenExpiresAt:  t.GetAccessTokenExpiresAt(),
+		RefreshTokenExpiresAt: t.GetRefreshTokenExpiresAt(),
+	}
+}
+
+func toEvent(e *eventpb.Event) Event {
+	return Event{ID: e.GetId(), Name: e.GetName(), StartTime: e.GetStartTime(), Status: e.GetStatus(), WinnerID: e.GetWinnerId()}
+}
+
+func toMarket(m *eventpb.Market) Market {
+	out := Market{
+		ID:         m.GetId(),
+		EventID:    m.GetEventId(),
+		Type:       m.GetType(),
+		Name:       m.GetName(),
+		Line:       m.Line,
+		Status:     m.GetStatus(),
+		Selections: []Selection{},
+	}
+	for _, s := range m.GetSelections() {
+		out.Selections = append(out.Selections, Selection{
+			ID:       s.GetId(),
+			MarketID: s.GetMarketId(),
+			Name:     s.GetName(),
+			Price:    s.GetPrice(),
+			Result:   s.GetResult(),
+		})
+	}
+	return out
+}
+
+func toBet(b *betpb.Bet) Bet {
+	return Bet{
+		ID:          b.GetId(),
+		EventID:     b.GetEventId(),
+		SelectionID: b.GetSelectionId(),
+		Stake:       money.FromProto(b.GetAmount()),
+		Odds:        b.GetOdds(),
+		Status:      b.GetStatus(),
+		Payout:      money.FromProto(b.GetPayout()),
+	}
+}
+
+func toPayment(p *paymentpb.PaymentResponse) Payment {
+	return Payment{
+		ID:        p.GetId(),
+		Type:      p.GetType(),
+		Amount:    money.FromProto(p.GetAmount()),
+		Status:    p.GetStatus(),
+		CreatedAt: p.GetCreatedAt(),
+	}
+}
+
+func toLedgerEntry(e *paymentpb.LedgerEntry) LedgerEntry {
+	out := LedgerEntry{ID: e.GetId(), Kind: e.GetKind(), Reference: e.GetReference(), CreatedAt: e.GetCreatedAt(), Lines: []LedgerLine{}}
+	for _, l := range e.GetLines() {
+		out.Lines = append(out.Lines, LedgerLine{
+			AccountType: l.GetAccountType(),
+			Debit:       money.FromProto(l.GetDebit()),
+			Credit:      money.FromProto(l.GetCredit()),
+		})
+	}
+	return out
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"muchway/pkg/clientip"
+)
+
+// Config is the gateway's configuration, loaded by config.MustLoad.
+type Config struct {
+	Addr          string `yaml:"addr" env:"GATEWAY_ADDR" default:":8000" usage:"address the gateway listens on"`
+	FrontendDir   string `yaml:"frontend_dir" env:"FRONTEND_DIR" default:"../frontend" usage:"directory of the static frontend"`
+	JWKSURL       string `yaml:"jwks_url" env:"JWKS_URL" default:"http://localhost:8051/.well-known/jwks.json" usage:"where the user service publishes its signing keys"`
+	SecureCookies bool   `yaml:"secure_cookies" env:"COOKIE_SECURE" default:"true" usage:"mark session cookies Secure, so they are only sent over HTTPS"`
+
+	TrustedProxies []string `yaml:"trusted_proxies" env:"TRUSTED_PROXIES" usage:"addresses or networks of proxies in front of the gateway trusted to report the client address in X-Forwarded-For"`
+
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" default:"30s" usage:"how long shutdown waits for work in flight"`
+
+	Services struct {
+		User    string `yaml:"user" env:"USER_SERVICE_ADDR" default:"localhost:50051" usage:"user service gRPC address"`
+		Bet     string `yaml:"bet" env:"BET_SERVICE_ADDR" default:"localhost:50052" usage:"bet service gRPC address"`
+		Event   string `yaml:"event" env:"EVENT_SERVICE_ADDR" default:"localhost:50053" usage:"event service gRPC address"`
+		Payment string `yaml:"payment" env:"PAYMENT_SERVICE_ADDR" default:"localhost:50054" usage:"payment service gRPC address"`
+	} `yaml:"services"`
+}
+
+func (c *Config) Validate() error {
+	if c.ShutdownTimeout <= 0 {
+		return errors.New("shutdown_timeout must be positive")
+	}
+	if _, err := clientip.ParseProxies(c.TrustedProxies); err != nil {
+		return fmt.Errorf("trusted_proxies: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+module gateway
+
+go 1.23.4
+
+require (
+	bet_service v0.0.0-00010101000000-000000000000
+	github.com/gorilla/mux v1.8.1
+	google.golang.org/grpc v1.72.0
+	google.golang.org/protobuf v1.36.6
+	muchway v0.0.0-00010101000000-000000000000
+)
+
+require (
+	golang.org/x/net v0.35.0 // indirect
+	golang.org/x/sys v0.32.0 // indirect
+	golang.org/x/text v0.24.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250218202821-56aae31c358a // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace (
+	bet_service => ../bet_service
+	muchway => ../
+)
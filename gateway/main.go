@@ -0,0 +1,68 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"bet_service/muchway/bet_service/proto/betpb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"gateway/api"
+
+	eventpb "muchway/event_service/proto"
+	paymentpb "muchway/payment_service/pb"
+	"muchway/pkg/auth"
+	"muchway/pkg/clientip"
+	"muchway/pkg/config"
+	"muchway/pkg/lifecycle"
+	"muchway/user_service/proto/userpb"
+)
+
+func main() {
+	var cfg Config
+	if args := config.MustLoad(&cfg); len(args) > 0 {
+		log.Fatalf("Unexpected argument %q", args[0])
+	}
+	app := lifecycle.New(cfg.ShutdownTimeout)
+
+	users := dial(app, "user", cfg.Services.User)
+	bets := dial(app, "bet", cfg.Services.Bet)
+	events := dial(app, "event", cfg.Services.Event)
+	payments := dial(app, "payment", cfg.Services.Payment)
+
+	verifier := auth.NewVerifier(auth.NewRemoteKeySet(cfg.JWKSURL), "user_service")
+	proxies, _ := clientip.ParseProxies(cfg.TrustedProxies) // checked by Validate
+	gateway := api.New(api.Clients{
+		Users:    userpb.NewUserServiceClient(users),
+		Events:   eventpb.NewEventServiceClient(events),
+		Bets:     betpb.NewBetServiceClient(bets),
+		Payments: paymentpb.NewPaymentServiceClient(payments),
+	}, verifier, api.Config{SecureCookies: cfg.SecureCookies, Proxies: proxies})
+
+	mux := http.NewServeMux()
+	mux.Handle(api.Prefix+"/", gateway.Handler())
+	mux.Handle("/", http.FileServer(http.Dir(cfg.FrontendDir)))
+
+	server := &http.Server{
+		Addr:              cfg.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	app.HTTP("Gateway serving the frontend and "+api.Prefix, server)
+	if err := app.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// dial connects to a backend service; the connection closes at shutdown.
+func dial(app *lifecycle.Runner, name, addr string) *grpc.ClientConn {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to connect to %s service: %v", name, err)
+	}
+	app.Close(name+" service connection", conn.Close)
+	return conn
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
+	"muchway/pkg/health"
+	"muchway/pkg/limits"
+	"muchway/pkg/money"
 	userpb "muchway/user_service/proto/userpb"
 )
 
@@ -18,9 +22,11 @@ type UserClient struct {
 	conn   *grpc.ClientConn
 }
 
-// NewUserClient creates a new user service client
-func NewUserClient(address string) (*UserClient, error) {
-	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// NewUserClient creates a new user service client. Extra options, such as
+// per-RPC credentials, are passed on to grpc.Dial.
+func NewUserClient(address string, opts ...grpc.DialOption) (*UserClient, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.Dial(address, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to user service: %w", err)
 	}
@@ -37,6 +43,11 @@ func (c *UserClient) Close() error {
 	return c.conn.Close()
 }
 
+// Check reports whether the user service is reachable and serving
+func (c *UserClient) Check(ctx context.Context) error {
+	return health.GRPC(c.conn, userpb.UserService_ServiceDesc.ServiceName)(ctx)
+}
+
 // GetUserEmail retrieves a user's email by their ID
 func (c *UserClient) GetUserEmail(userID string) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -61,3 +72,78 @@ func (c *UserClient) GetUserEmail(userID string) (string, error) {
 	log.Printf("Retrieved email for user %s: %s", userID, resp.User.Email)
 	return resp.User.Email, nil
 }
+
+// GetLimits returns the responsible gambling limits the user has set
+func (c *UserClient) GetLimits(userID string) ([]limits.Limit, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var id int64
+	if _, err := fmt.Sscanf(userID, "%d", &id); err != nil {
+		return nil, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	resp, err := c.client.GetLimits(ctx, &userpb.GetLimitsRequest{UserId: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get limits: %w", err)
+	}
+
+	ls := make([]limits.Limit, 0, len(resp.Limits))
+	for _, l := range resp.Limits {
+		ls = append(ls, limits.FromProto(l))
+	}
+	return ls, nil
+}
+
+// KYCVerified reports whether the user's identity has been verified
+func (c *UserClient) KYCVerified(userID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var id int64
+	if _, err := fmt.Sscanf(userID, "%d", &id); err != nil {
+		return false, fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	resp, err := c.client.GetKYC(ctx, &userpb.GetKYCRequest{UserId: id})
+	if err != nil {
+		return false, fmt.Errorf("failed to get KYC status: %w", err)
+	}
+	return resp.GetKyc().GetStatus() == userpb.KYCStatus_KYC_STATUS_VERIFIED, nil
+}
+
+// SyncBalance reports the user's wallet balance at the given ledger version.
+// The user service ignores a version older than the one it holds.
+func (c *UserClient) SyncBalance(userID string, balance money.Money, version int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var id int64
+	if _, err := fmt.Sscanf(userID, "%d", &id); err != nil {
+		return fmt.Errorf("invalid user ID format: %w", err)
+	}
+
+	_, err := c.client.SyncBalance(ctx, &userpb.SyncBalanceRequest{UserId: id, Balance: money.ToProto(balance), Version: version})
+	if err != nil {
+		return fmt.Errorf("failed to sync balance: %w", err)
+	}
+	return nil
+}
+
+// GetBalances returns the balance the user service holds for each user,
+// keyed by user ID.
+func (c *UserClient) GetBalances() (map[string]money.Money, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	resp, err := c.client.GetAllUsers(ctx, &userpb.GetAllUsersRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	balances := make(map[string]money.Money, len(resp.Users))
+	for _, u := range resp.Users {
+		balances[strconv.FormatInt(u.Id, 10)] = money.FromProto(u.Balance)
+	}
+	return balances, nil
+}
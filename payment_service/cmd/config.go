@@ -0,0 +1,47 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	"muchway/pkg/config"
+	"muchway/pkg/money"
+)
+
+// Config is the payment service's configuration, loaded by config.MustLoad.
+type Config struct {
+	GRPCAddr   string `yaml:"grpc_addr" env:"GRPC_ADDR" default:":50054" usage:"address the gRPC server listens on"`
+	HealthAddr string `yaml:"health_addr" env:"HEALTH_ADDR" default:":8054" usage:"address /healthz and /readyz are served on"`
+	JWKSURL    string `yaml:"jwks_url" env:"JWKS_URL" default:"http://localhost:8051/.well-known/jwks.json" usage:"where the user service publishes its signing keys"`
+
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" default:"30s" usage:"how long shutdown waits for work in flight"`
+	HealthInterval  time.Duration `yaml:"health_interval" env:"HEALTH_INTERVAL" default:"10s" usage:"how often dependencies are checked for /readyz and gRPC health"`
+
+	UserServiceAddr     string `yaml:"user_service_addr" env:"USER_SERVICE_ADDR" default:"localhost:50051" usage:"user service gRPC address"`
+	ServiceClientSecret string `yaml:"service_client_secret" env:"SERVICE_CLIENT_SECRET" required:"true" secret:"true" usage:"secret this service authenticates to the user service with"`
+
+	Database config.Postgres `yaml:"database"`
+	RabbitMQ config.RabbitMQ `yaml:"rabbitmq"`
+	Redis    config.Redis    `yaml:"redis"`
+	SMTP     config.SMTP     `yaml:"smtp"`
+
+	WithdrawalMFAThreshold money.Money   `yaml:"withdrawal_mfa_threshold" env:"WITHDRAWAL_MFA_THRESHOLD" default:"100.00" usage:"withdrawals totalling more than this over 24 hours need a fresh second factor"`
+	KYCWithdrawalThreshold money.Money   `yaml:"kyc_withdrawal_threshold" env:"KYC_WITHDRAWAL_THRESHOLD" default:"1000.00" usage:"withdrawals totalling more than this over 24 hours need a verified identity"`
+	IdempotencyRetention   time.Duration `yaml:"idempotency_retention" env:"IDEMPOTENCY_KEY_RETENTION" default:"24h" usage:"how long idempotency keys are honoured"`
+}
+
+func (c *Config) Validate() error {
+	if c.WithdrawalMFAThreshold.IsNegative() || c.KYCWithdrawalThreshold.IsNegative() {
+		return errors.New("withdrawal thresholds must not be negative")
+	}
+	if c.IdempotencyRetention <= 0 {
+		return errors.New("idempotency_retention must be positive")
+	}
+	if c.ShutdownTimeout <= 0 {
+		return errors.New("shutdown_timeout must be positive")
+	}
+	if c.HealthInterval <= 0 {
+		return errors.New("health_interval must be positive")
+	}
+	return c.SMTP.Validate()
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"muchway/pkg/money"
+	"time"
+)
+
+// Account types. Wallet, pending and bonus accounts belong to a user and can
+// never go negative; house and external are system accounts without an owner.
+// External is the counterpart of money entering or leaving the platform.
+const (
+	AccountTypeWallet   = "wallet"
+	AccountTypePending  = "pending"
+	AccountTypeBonus    = "bonus"
+	AccountTypeHouse    = "house"
+	AccountTypeExternal = "external"
+)
+
+// WalletCurrency is the currency of every ledger account. Balances are
+// stored without a currency, so amounts in any other are refused.
+const WalletCurrency = money.DefaultCurrency
+
+// Journal entry kinds
+const (
+	EntryKindOpeningBalance = "opening_balance"
+	EntryKindDeposit        = "deposit"
+	EntryKindWithdrawal     = "withdrawal"
+	EntryKindPayout         = "payout"
+	EntryKindStakeReserve   = "stake_reserve"
+	EntryKindStakeCapture   = "stake_capture"
+	EntryKindStakeRelease   = "stake_release"
+)
+
+var ErrUnbalancedEntry = errors.New("journal entry is not balanced")
+
+// ErrOpeningBalancesImported is returned when the opening balances have
+// already been imported.
+var ErrOpeningBalancesImported = errors.New("opening balances have already been imported")
+
+// Account is a ledger account. Its balance is materialised from the journal
+// as credits minus debits.
+type Account struct {
+	ID        string      `json:"id"`
+	OwnerID   string      `json:"owner_id"`
+	Type      string      `json:"type"`
+	Balance   money.Money `json:"balance"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+// IsUserAccount reports whether the account type belongs to a user.
+func IsUserAccount(accountType string) bool {
+	return accountType == AccountTypeWallet || accountType == AccountTypePending || accountType == AccountTypeBonus
+}
+
+// JournalEntry is an immutable, balanced set of debit and credit lines.
+type JournalEntry struct {
+	ID        string        `json:"id"`
+	Kind      string        `json:"kind"`
+	Reference string        `json:"reference"`
+	Lines     []JournalLine `json:"lines"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// JournalLine debits or credits one account. Accounts are addressed by owner
+// and type; the repository resolves them to account IDs.
+type JournalLine struct {
+	AccountID   string      `json:"account_id"`
+	OwnerID     string      `json:"owner_id"`
+	AccountType string      `json:"account_type"`
+	Debit       money.Money `json:"debit"`
+	Credit      money.Money `json:"credit"`
+}
+
+// Balance is the set of account balances of a user. WalletVersion counts
+// the postings to the wallet, ordering copies of its balance.
+type Balance struct {
+	UserID        string      `json:"user_id"`
+	Wallet        money.Money `json:"wallet"`
+	Pending       money.Money `json:"pending"`
+	Bonus         money.Money `json:"bonus"`
+	WalletVersion int64       `json:"wallet_version"`
+}
+
+// Transfer builds a two-line entry moving amount from one account to another.
+// An empty owner addresses a system account.
+func Transfer(kind, reference, fromOwner, fromType, toOwner, toType string, amount money.Money) *JournalEntry {
+	return &JournalEntry{
+		Kind:      kind,
+		Reference: reference,
+		Lines: []JournalLine{
+			{OwnerID: fromOwner, AccountType: fromType, Debit: amount},
+			{OwnerID: toOwner, AccountType: toType, Credit: amount},
+		},
+	}
+}
+
+// Validate checks that every line moves a positive amount in one direction
+// and that debits equal credits in a single currency.
+func (e *JournalEntry) Validate() error {
+	if len(e.Lines) < 2 {
+		return fmt.Errorf("%w: at least two lines are required", ErrUnbalancedEntry)
+	}
+	currency := e.Lines[0].Debit.Currency
+	if e.Lines[0].Debit.IsZero() {
+		currency = e.Lines[0].Credit.Currency
+	}
+	debits, credits := money.FromMinor(0, currency), money.FromMinor(0, currency)
+	for _, l := range e.Lines {
+		if l.Debit.IsNegative() || l.Credit.IsNegative() || l.Debit.IsZero() == l.Credit.IsZero() {
+			return fmt.Errorf("%w: each line must either debit or credit a positive amount", ErrUnbalancedEntry)
+		}
+		var err error
+		if l.Debit.IsPositive() {
+			debits, err = debits.Add(l.Debit)
+		} else {
+			credits, err = credits.Add(l.Credit)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrUnbalancedEntry, err)
+		}
+	}
+	if debits != credits {
+		return fmt.Errorf("%w: debits %s != credits %s", ErrUnbalancedEntry, debits, credits)
+	}
+	return nil
+}
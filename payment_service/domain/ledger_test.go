@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"muchway/pkg/money"
+	"testing"
+)
+
+func TestTransferIsBalanced(t *testing.T) {
+	e := Transfer(EntryKindDeposit, "p1", "", AccountTypeExternal, "42", AccountTypeWallet, money.MustParse("10.10"))
+	if err := e.Validate(); err != nil {
+		t.Fatalf("expected balanced entry, got %v", err)
+	}
+}
+
+func TestValidateRejectsUnbalancedEntries(t *testing.T) {
+	five := money.MustParse("5")
+	cases := map[string][]JournalLine{
+		"single line":       {{Debit: five}},
+		"debit != credit":   {{Debit: five}, {Credit: money.MustParse("4.99")}},
+		"both sides":        {{Debit: five, Credit: five}, {Credit: money.Money{}}},
+		"negative":          {{Debit: five.Neg()}, {Credit: five.Neg()}},
+		"currency mismatch": {{Debit: five}, {Credit: money.FromMinor(500, "EUR")}},
+	}
+	for name, lines := range cases {
+		e := &JournalEntry{Kind: EntryKindDeposit, Lines: lines}
+		if err := e.Validate(); !errors.Is(err, ErrUnbalancedEntry) {
+			t.Errorf("%s: expected ErrUnbalancedEntry, got %v", name, err)
+		}
+	}
+}
@@ -1,13 +1,43 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"muchway/pkg/money"
+	"time"
+)
+
+var (
+	// ErrDuplicateIdempotencyKey is returned by the repository when another
+	// payment already holds the idempotency key.
+	ErrDuplicateIdempotencyKey = errors.New("idempotency key already used")
+	// ErrIdempotencyKeyReused is returned when a key is replayed with a
+	// different request.
+	ErrIdempotencyKeyReused = errors.New("idempotency key was used for a different payment")
+	// ErrKYCRequired is returned for a withdrawal too large for a user whose
+	// identity is not verified.
+	ErrKYCRequired = errors.New("identity verification required")
+	// ErrInvalidUserID is returned for a user ID that is not a user
+	// service account ID.
+	ErrInvalidUserID = errors.New("invalid user ID")
+	// ErrUnsupportedCurrency is returned for an amount that is not in
+	// WalletCurrency.
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+)
+
+// WithdrawalWindow is the rolling period over which a user's withdrawals are
+// added up before comparing them with the KYC and step-up thresholds, so that
+// a large withdrawal cannot be split into small ones.
+const WithdrawalWindow = 24 * time.Hour
 
 type Payment struct {
-	ID        string    `json:"id"`
-	UserID    string    `json:"user_id"`
-	Type      string    `json:"type"`
-	Amount    float64   `json:"amount"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        string      `json:"id"`
+	UserID    string      `json:"user_id"`
+	Type      string      `json:"type"`
+	Amount    money.Money `json:"amount"`
+	Status    string      `json:"status"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	// IdempotencyKey is optional. A repeat with the same key returns the
+	// original payment instead of moving money again.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
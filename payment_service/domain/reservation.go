@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"muchway/pkg/money"
+	"time"
+)
+
+const (
+	ReservationStatusReserved = "reserved"
+	ReservationStatusCaptured = "captured"
+	ReservationStatusReleased = "released"
+)
+
+var (
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	ErrReservationNotFound = errors.New("reservation not found")
+	ErrReservationClosed   = errors.New("reservation is no longer reserved")
+)
+
+// Reservation holds funds taken from a user's balance until the operation
+// they back (e.g. a bet) is confirmed by a capture or undone by a release.
+type Reservation struct {
+	ID        string      `json:"id"`
+	UserID    string      `json:"user_id"`
+	Amount    money.Money `json:"amount"`
+	Reference string      `json:"reference"`
+	Status    string      `json:"status"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
@@ -2,12 +2,13 @@ package email
 
 import (
 	"fmt"
+	"muchway/pkg/money"
 	"net/smtp"
 )
 
 // EmailService defines the interface for sending emails
 type EmailService interface {
-	SendPaymentConfirmation(to, userID string, amount float64, paymentType, status string) error
+	SendPaymentConfirmation(to, userID string, amount money.Money, paymentType, status string) error
 }
 
 // Config holds email configuration
@@ -31,7 +32,7 @@ func NewEmailService(config Config) EmailService {
 }
 
 // SendPaymentConfirmation sends a confirmation email for a payment
-func (s *emailService) SendPaymentConfirmation(to, userID string, amount float64, paymentType, status string) error {
+func (s *emailService) SendPaymentConfirmation(to, userID string, amount money.Money, paymentType, status string) error {
 	// SMTP server configuration
 	smtpHost := s.config.SMTPHost
 	smtpPort := s.config.SMTPPort
@@ -51,7 +52,7 @@ func (s *emailService) SendPaymentConfirmation(to, userID string, amount float64
 		<body>
 			<h2>Deposit Confirmation</h2>
 			<p>Dear MuchWayBet User,</p>
-			<p>Your deposit of <strong>%.2f</strong> has been processed successfully.</p>
+			<p>Your deposit of <strong>%s</strong> has been processed successfully.</p>
 			<p>Transaction Status: <strong>%s</strong></p>
 			<p>Thank you for using MuchWayBet!</p>
 			<p>Best regards,<br>The MuchWayBet Team</p>
@@ -65,7 +66,7 @@ func (s *emailService) SendPaymentConfirmation(to, userID string, amount float64
 		<body>
 			<h2>Withdrawal Confirmation</h2>
 			<p>Dear MuchWayBet User,</p>
-			<p>Your withdrawal of <strong>%.2f</strong> has been processed.</p>
+			<p>Your withdrawal of <strong>%s</strong> has been processed.</p>
 			<p>Transaction Status: <strong>%s</strong></p>
 			<p>Thank you for using MuchWayBet!</p>
 			<p>Best regards,<br>The MuchWayBet Team</p>
@@ -79,7 +80,7 @@ func (s *emailService) SendPaymentConfirmation(to, userID string, amount float64
 		<body>
 			<h2>Payment Notification</h2>
 			<p>Dear MuchWayBet User,</p>
-			<p>A payment of <strong>%.2f</strong> has been processed for your account.</p>
+			<p>A payment of <strong>%s</strong> has been processed for your account.</p>
 			<p>Payment Type: <strong>%s</strong></p>
 			<p>Transaction Status: <strong>%s</strong></p>
 			<p>Thank you for using MuchWayBet!</p>
@@ -92,9 +93,9 @@ func (s *emailService) SendPaymentConfirmation(to, userID string, amount float64
 	// Format the body with the payment details
 	var body string
 	if paymentType == "deposit" || paymentType == "withdraw" {
-		body = fmt.Sprintf(bodyTemplate, amount, status)
+		body = fmt.Sprintf(bodyTemplate, amount.Format(), status)
 	} else {
-		body = fmt.Sprintf(bodyTemplate, amount, paymentType, status)
+		body = fmt.Sprintf(bodyTemplate, amount.Format(), paymentType, status)
 	}
 
 	// Compose message
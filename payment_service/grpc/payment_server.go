@@ -2,27 +2,55 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"log"
+	"muchway/payment_service/domain"
 	pb "muchway/payment_service/pb"
 	"muchway/payment_service/usecase"
+	"muchway/pkg/auth"
+	"muchway/pkg/limits"
+	"muchway/pkg/money"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type PaymentServer struct {
 	pb.UnimplementedPaymentServiceServer
-	uc *usecase.PaymentUsecase
+	uc    *usecase.PaymentUsecase
+	authz *auth.Authorizer
+	cfg   Config
+}
+
+// Config holds the checks PaymentServer applies on top of the policy.
+type Config struct {
+	// WithdrawalMFAMaxAge is how recently a bettor must have proved a second
+	// factor for a withdrawal above usecase.Config.WithdrawalMFAThreshold.
+	WithdrawalMFAMaxAge time.Duration
 }
 
-func NewPaymentServer(uc *usecase.PaymentUsecase) *PaymentServer {
-	return &PaymentServer{uc: uc}
+func NewPaymentServer(uc *usecase.PaymentUsecase, authz *auth.Authorizer, cfg Config) *PaymentServer {
+	return &PaymentServer{uc: uc, authz: authz, cfg: cfg}
 }
 
 func (s *PaymentServer) CreatePayment(ctx context.Context, req *pb.CreatePaymentRequest) (*pb.PaymentResponse, error) {
-	log.Printf("Processing %s payment of %.2f for user %s", req.Type, req.Amount, req.UserId)
+	if err := s.authz.RequireOwner(ctx, req.UserId, auth.RoleFinance); err != nil {
+		return nil, err
+	}
+	// Winnings are credited by bet_service when it settles a bet; anyone
+	// else may only deposit or withdraw
+	if req.Type == "payout" {
+		if err := s.authz.RequireRole(ctx, auth.RoleService); err != nil {
+			return nil, err
+		}
+	}
+	log.Printf("Processing %s payment of %s for user %s", req.Type, money.FromProto(req.Amount), req.UserId)
 
-	p, err := s.uc.ProcessPayment(req.UserId, req.Amount, req.Type)
+	p, err := s.uc.ProcessPayment(req.UserId, money.FromProto(req.Amount), req.Type, req.IdempotencyKey, s.stepUp(ctx))
 	if err != nil {
 		log.Printf("Payment processing failed: %v", err)
-		return nil, err
+		return nil, paymentError(err)
 	}
 
 	log.Printf("Payment processed successfully: ID=%s, Status=%s", p.ID, p.Status)
@@ -30,23 +58,35 @@ func (s *PaymentServer) CreatePayment(ctx context.Context, req *pb.CreatePayment
 		Id:        p.ID,
 		UserId:    p.UserID,
 		Type:      p.Type,
-		Amount:    p.Amount,
+		Amount:    money.ToProto(p.Amount),
 		Status:    p.Status,
 		CreatedAt: p.CreatedAt.Unix(),
 		UpdatedAt: p.UpdatedAt.Unix(),
 	}, nil
 }
 
+// stepUp asks a bettor for a recent second factor for large withdrawals.
+// Staff and services are not asked.
+func (s *PaymentServer) stepUp(ctx context.Context) usecase.StepUp {
+	if auth.IsStaff(ctx, auth.RoleFinance) {
+		return nil
+	}
+	return func() error { return auth.RequireFreshMFA(ctx, s.cfg.WithdrawalMFAMaxAge) }
+}
+
 func (s *PaymentServer) GetPayment(ctx context.Context, req *pb.GetPaymentRequest) (*pb.PaymentResponse, error) {
 	p, err := s.uc.GetPaymentByID(req.Id)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.authz.RequireOwner(ctx, p.UserID, auth.RoleFinance); err != nil {
+		return nil, err
+	}
 	return &pb.PaymentResponse{
 		Id:        p.ID,
 		UserId:    p.UserID,
 		Type:      p.Type,
-		Amount:    p.Amount,
+		Amount:    money.ToProto(p.Amount),
 		Status:    p.Status,
 		CreatedAt: p.CreatedAt.Unix(),
 		UpdatedAt: p.UpdatedAt.Unix(),
@@ -64,7 +104,7 @@ func (s *PaymentServer) GetAllPayments(ctx context.Context, req *pb.Empty) (*pb.
 			Id:        p.ID,
 			UserId:    p.UserID,
 			Type:      p.Type,
-			Amount:    p.Amount,
+			Amount:    money.ToProto(p.Amount),
 			Status:    p.Status,
 			CreatedAt: p.CreatedAt.Unix(),
 			UpdatedAt: p.UpdatedAt.Unix(),
@@ -80,3 +120,112 @@ func (s *PaymentServer) DeletePayment(ctx context.Context, req *pb.DeletePayment
 	}
 	return &pb.Empty{}, nil
 }
+
+func (s *PaymentServer) ReserveFunds(ctx context.Context, req *pb.ReserveFundsRequest) (*pb.ReservationResponse, error) {
+	res, err := s.uc.ReserveFunds(req.UserId, money.FromProto(req.Amount), req.Reference)
+	if err != nil {
+		log.Printf("Reservation failed for %s: %v", req.Reference, err)
+		return nil, reservationError(err)
+	}
+	return toReservationResponse(res), nil
+}
+
+func (s *PaymentServer) CaptureFunds(ctx context.Context, req *pb.CaptureFundsRequest) (*pb.ReservationResponse, error) {
+	res, err := s.uc.CaptureFunds(req.ReservationId)
+	if err != nil {
+		return nil, reservationError(err)
+	}
+	return toReservationResponse(res), nil
+}
+
+func (s *PaymentServer) ReleaseFunds(ctx context.Context, req *pb.ReleaseFundsRequest) (*pb.ReservationResponse, error) {
+	res, err := s.uc.ReleaseFunds(req.ReservationId)
+	if err != nil {
+		return nil, reservationError(err)
+	}
+	return toReservationResponse(res), nil
+}
+
+func (s *PaymentServer) GetBalance(ctx context.Context, req *pb.GetBalanceRequest) (*pb.BalanceResponse, error) {
+	if err := s.authz.RequireOwner(ctx, req.UserId, auth.RoleFinance); err != nil {
+		return nil, err
+	}
+	b, err := s.uc.GetBalance(req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.BalanceResponse{
+		UserId:  b.UserID,
+		Wallet:  money.ToProto(b.Wallet),
+		Pending: money.ToProto(b.Pending),
+		Bonus:   money.ToProto(b.Bonus),
+	}, nil
+}
+
+func (s *PaymentServer) ListLedgerEntries(ctx context.Context, req *pb.ListLedgerEntriesRequest) (*pb.LedgerEntriesResponse, error) {
+	if err := s.authz.RequireOwner(ctx, req.UserId, auth.RoleFinance); err != nil {
+		return nil, err
+	}
+	entries, err := s.uc.ListLedgerEntries(req.UserId, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, err
+	}
+	res := &pb.LedgerEntriesResponse{}
+	for _, e := range entries {
+		entry := &pb.LedgerEntry{
+			Id:        e.ID,
+			Kind:      e.Kind,
+			Reference: e.Reference,
+			CreatedAt: e.CreatedAt.Unix(),
+		}
+		for _, l := range e.Lines {
+			entry.Lines = append(entry.Lines, &pb.LedgerLine{
+				AccountId:   l.AccountID,
+				OwnerId:     l.OwnerID,
+				AccountType: l.AccountType,
+				Debit:       money.ToProto(l.Debit),
+				Credit:      money.ToProto(l.Credit),
+			})
+		}
+		res.Entries = append(res.Entries, entry)
+	}
+	return res, nil
+}
+
+func toReservationResponse(res *domain.Reservation) *pb.ReservationResponse {
+	return &pb.ReservationResponse{
+		Id:        res.ID,
+		UserId:    res.UserID,
+		Amount:    money.ToProto(res.Amount),
+		Reference: res.Reference,
+		Status:    res.Status,
+		CreatedAt: res.CreatedAt.Unix(),
+		UpdatedAt: res.UpdatedAt.Unix(),
+	}
+}
+
+func paymentError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrIdempotencyKeyReused), errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrUnsupportedCurrency):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrInsufficientBalance), errors.Is(err, limits.ErrLimitExceeded),
+		errors.Is(err, limits.ErrSelfExcluded), errors.Is(err, domain.ErrKYCRequired):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
+
+func reservationError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInsufficientBalance), errors.Is(err, domain.ErrReservationClosed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrReservationNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidUserID), errors.Is(err, domain.ErrUnsupportedCurrency):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return err
+	}
+}
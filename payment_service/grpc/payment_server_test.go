@@ -0,0 +1,109 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"muchway/payment_service/domain"
+	pb "muchway/payment_service/pb"
+	"muchway/payment_service/repository"
+	"muchway/payment_service/usecase"
+	"muchway/pkg/auth"
+	"muchway/pkg/money"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreatePaymentRefusesPayoutsToOthersThanServices(t *testing.T) {
+	s := NewPaymentServer(nil, auth.NewAuthorizer(Policy, nil), Config{})
+	cases := []struct {
+		name   string
+		claims *auth.Claims
+	}{
+		{"bettor", &auth.Claims{Subject: "42", Role: auth.RoleBettor}},
+		{"finance", &auth.Claims{Subject: "7", Role: auth.RoleFinance}},
+		{"admin", &auth.Claims{Subject: "1", Role: auth.RoleAdmin}},
+	}
+	for _, c := range cases {
+		ctx := auth.NewContext(context.Background(), c.claims)
+		_, err := s.CreatePayment(ctx, &pb.CreatePaymentRequest{
+			UserId: "42",
+			Type:   "payout",
+			Amount: money.ToProto(money.MustParse("100.00")),
+		})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("%s payout: got %v, want PermissionDenied", c.name, err)
+		}
+	}
+}
+
+func TestOnlyWalletCurrencyIsAccepted(t *testing.T) {
+	// Neither request reaches the repository
+	s := NewPaymentServer(usecase.NewPaymentUsecase(nil, nil, nil, usecase.Config{}), auth.NewAuthorizer(Policy, nil), Config{})
+	euros := money.ToProto(money.FromMinor(10000, "EUR"))
+
+	ctx := auth.NewContext(context.Background(), &auth.Claims{Subject: "42", Role: auth.RoleBettor})
+	if _, err := s.CreatePayment(ctx, &pb.CreatePaymentRequest{UserId: "42", Type: "deposit", Amount: euros}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("deposit in EUR: got %v, want InvalidArgument", err)
+	}
+
+	ctx = auth.NewContext(context.Background(), &auth.Claims{Subject: "service:bet_service", Role: auth.RoleService})
+	if _, err := s.ReserveFunds(ctx, &pb.ReserveFundsRequest{UserId: "42", Amount: euros, Reference: "bet:1"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("stake in EUR: got %v, want InvalidArgument", err)
+	}
+}
+
+// withdrawals is a repository that only knows the user's recent withdrawals.
+type withdrawals struct {
+	repository.PaymentRepository
+	total money.Money
+}
+
+func (w withdrawals) SumPayments(string, string, time.Time) (money.Money, error) { return w.total, nil }
+
+func (w withdrawals) Create(p *domain.Payment, check repository.LimitCheck) error {
+	if check != nil {
+		return check(w.SumPayments)
+	}
+	return nil
+}
+
+func (w withdrawals) PostPayment(p *domain.Payment) (bool, error) {
+	p.Status = "completed"
+	return true, nil
+}
+
+func TestStepUpThresholdCountsRecentWithdrawals(t *testing.T) {
+	cfg := usecase.Config{KYCWithdrawalThreshold: money.MustParse("1000.00"), WithdrawalMFAThreshold: money.MustParse("100.00")}
+	cases := []struct {
+		name      string
+		role      string
+		withdrawn string
+		amount    string
+		mfaAt     time.Time
+		want      codes.Code
+	}{
+		{"under the threshold", auth.RoleBettor, "60.00", "40.00", time.Time{}, codes.OK},
+		{"over the threshold with earlier withdrawals", auth.RoleBettor, "60.00", "40.01", time.Time{}, codes.PermissionDenied},
+		{"over the threshold after a second factor", auth.RoleBettor, "60.00", "40.01", time.Now(), codes.OK},
+		{"over the threshold with a stale second factor", auth.RoleBettor, "60.00", "40.01", time.Now().Add(-time.Hour), codes.PermissionDenied},
+		{"over the threshold for finance", auth.RoleFinance, "60.00", "40.01", time.Time{}, codes.OK},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			uc := usecase.NewPaymentUsecase(withdrawals{total: money.MustParse(c.withdrawn)}, nil, nil, cfg)
+			s := NewPaymentServer(uc, auth.NewAuthorizer(Policy, nil), Config{WithdrawalMFAMaxAge: 5 * time.Minute})
+			claims := &auth.Claims{Subject: "42", Role: c.role}
+			if !c.mfaAt.IsZero() {
+				claims.MFAAt = c.mfaAt.Unix()
+			}
+			ctx := auth.NewContext(context.Background(), claims)
+			_, err := s.CreatePayment(ctx, &pb.CreatePaymentRequest{UserId: "42", Type: "withdraw", Amount: money.ToProto(money.MustParse(c.amount))})
+			if status.Code(err) != c.want {
+				t.Errorf("CreatePayment = %v, want %v", err, c.want)
+			}
+		})
+	}
+}
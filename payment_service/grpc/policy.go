@@ -0,0 +1,26 @@
+package grpc
+
+import (
+	pb "muchway/payment_service/pb"
+	"muchway/pkg/auth"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Policy says who may call each PaymentService method. Bettors see and move
+// only their own money, which PaymentServer checks; reservations are made
+// by bet_service on their behalf, and only it credits payouts, which
+// PaymentServer checks. Health checks are open.
+var Policy = auth.Policy{
+	pb.PaymentService_CreatePayment_FullMethodName:     {auth.RoleBettor, auth.RoleFinance, auth.RoleService},
+	pb.PaymentService_GetPayment_FullMethodName:        {auth.RoleBettor, auth.RoleFinance, auth.RoleService},
+	pb.PaymentService_GetAllPayments_FullMethodName:    {auth.RoleFinance},
+	pb.PaymentService_ReserveFunds_FullMethodName:      {auth.RoleService},
+	pb.PaymentService_CaptureFunds_FullMethodName:      {auth.RoleService},
+	pb.PaymentService_ReleaseFunds_FullMethodName:      {auth.RoleService},
+	pb.PaymentService_GetBalance_FullMethodName:        {auth.RoleBettor, auth.RoleFinance, auth.RoleService},
+	pb.PaymentService_ListLedgerEntries_FullMethodName: {auth.RoleBettor, auth.RoleFinance},
+
+	healthpb.Health_Check_FullMethodName: {auth.Anyone},
+	healthpb.Health_Watch_FullMethodName: {auth.Anyone},
+}
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS reservations (
+    id UUID PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    amount NUMERIC(10, 2) NOT NULL,
+    reference TEXT NOT NULL UNIQUE,
+    status TEXT NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
@@ -0,0 +1,77 @@
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    id UUID PRIMARY KEY,
+    owner_id TEXT NOT NULL DEFAULT '',
+    type TEXT NOT NULL,
+    balance NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    UNIQUE (owner_id, type)
+);
+
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id UUID PRIMARY KEY,
+    kind TEXT NOT NULL,
+    reference TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_journal_entries_reference ON journal_entries (reference);
+
+CREATE TABLE IF NOT EXISTS journal_lines (
+    id BIGSERIAL PRIMARY KEY,
+    entry_id UUID NOT NULL REFERENCES journal_entries (id),
+    account_id UUID NOT NULL REFERENCES ledger_accounts (id),
+    debit NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    credit NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    CHECK (debit >= 0 AND credit >= 0 AND (debit = 0) <> (credit = 0))
+);
+CREATE INDEX IF NOT EXISTS idx_journal_lines_account_id ON journal_lines (account_id);
+
+-- The journal is append-only
+CREATE OR REPLACE FUNCTION forbid_journal_changes() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'journal is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS journal_entries_immutable ON journal_entries;
+CREATE TRIGGER journal_entries_immutable BEFORE UPDATE OR DELETE ON journal_entries
+    FOR EACH ROW EXECUTE FUNCTION forbid_journal_changes();
+DROP TRIGGER IF EXISTS journal_lines_immutable ON journal_lines;
+CREATE TRIGGER journal_lines_immutable BEFORE UPDATE OR DELETE ON journal_lines
+    FOR EACH ROW EXECUTE FUNCTION forbid_journal_changes();
+
+INSERT INTO ledger_accounts (id, owner_id, type, balance, created_at, updated_at)
+VALUES (gen_random_uuid(), '', 'house', 0, now(), now()),
+       (gen_random_uuid(), '', 'external', 0, now(), now())
+ON CONFLICT (owner_id, type) DO NOTHING;
+
+-- Carry users.balance over as opening balance entries when upgrading a
+-- database shared with the user service. A payment service with its own
+-- database starts with an empty ledger.
+DO $migrate$
+BEGIN
+IF to_regclass('users') IS NOT NULL
+   AND NOT EXISTS (SELECT 1 FROM journal_entries WHERE kind = 'opening_balance') THEN
+    INSERT INTO ledger_accounts (id, owner_id, type, balance, created_at, updated_at)
+    SELECT gen_random_uuid(), id::text, 'wallet', 0, now(), now() FROM users
+    ON CONFLICT (owner_id, type) DO NOTHING;
+
+    INSERT INTO journal_entries (id, kind, reference, created_at)
+    SELECT gen_random_uuid(), 'opening_balance', 'user:' || id::text, now() FROM users WHERE balance > 0;
+
+    INSERT INTO journal_lines (entry_id, account_id, debit, credit)
+    SELECT e.id, a.id, 0, u.balance
+    FROM users u
+    JOIN journal_entries e ON e.kind = 'opening_balance' AND e.reference = 'user:' || u.id::text
+    JOIN ledger_accounts a ON a.owner_id = u.id::text AND a.type = 'wallet'
+    UNION ALL
+    SELECT e.id, x.id, u.balance, 0
+    FROM users u
+    JOIN journal_entries e ON e.kind = 'opening_balance' AND e.reference = 'user:' || u.id::text
+    JOIN ledger_accounts x ON x.owner_id = '' AND x.type = 'external';
+
+    UPDATE ledger_accounts a
+    SET balance = COALESCE((SELECT SUM(l.credit) - SUM(l.debit) FROM journal_lines l WHERE l.account_id = a.id), 0);
+END IF;
+END
+$migrate$;
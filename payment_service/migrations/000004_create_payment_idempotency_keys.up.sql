@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS payment_idempotency_keys (
+    user_id TEXT NOT NULL,
+    key TEXT NOT NULL,
+    payment_id UUID NOT NULL REFERENCES payments (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, key)
+);
+CREATE INDEX IF NOT EXISTS idx_payment_idempotency_keys_created_at ON payment_idempotency_keys (created_at);
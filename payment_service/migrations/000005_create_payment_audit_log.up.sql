@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS payment_audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    method TEXT NOT NULL,
+    subject TEXT NOT NULL DEFAULT '',
+    role TEXT NOT NULL DEFAULT '',
+    reason TEXT NOT NULL,
+    peer TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS idx_payment_audit_log_subject ON payment_audit_log (subject, occurred_at);
@@ -0,0 +1,8 @@
+-- Self-exclusions announced by user_service on user.excluded. until is NULL
+-- for a permanent exclusion.
+CREATE TABLE IF NOT EXISTS payment_exclusions (
+    user_id TEXT PRIMARY KEY,
+    since TIMESTAMPTZ NOT NULL,
+    until TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
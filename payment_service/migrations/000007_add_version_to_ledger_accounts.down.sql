@@ -0,0 +1 @@
+ALTER TABLE ledger_accounts DROP COLUMN IF EXISTS version;
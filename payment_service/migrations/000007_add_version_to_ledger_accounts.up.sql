@@ -0,0 +1,3 @@
+-- version counts the postings to an account, so that copies of its balance
+-- held elsewhere can tell a newer balance from an older one.
+ALTER TABLE ledger_accounts ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 0;
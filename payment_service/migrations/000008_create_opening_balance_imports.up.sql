@@ -0,0 +1,15 @@
+-- opening_balance_imports records the one-time import of the balances the
+-- user service held before the ledger took over. It has at most one row.
+-- Until it has one, no balance is reported back to the user service.
+CREATE TABLE IF NOT EXISTS opening_balance_imports (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    users INTEGER NOT NULL,
+    imported_at TIMESTAMPTZ NOT NULL
+);
+
+-- A database shared with the user service got its opening balances from
+-- 000003 already
+INSERT INTO opening_balance_imports (users, imported_at)
+SELECT COUNT(*), now() FROM journal_entries WHERE kind = 'opening_balance'
+HAVING COUNT(*) > 0
+ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,9 @@
+// Package migrations embeds the service's schema migrations.
+package migrations
+
+import "embed"
+
+// FS holds the migration files, applied by package migrate.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -9,6 +9,7 @@ package pb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	moneypb "muchway/pkg/money/moneypb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -26,7 +27,7 @@ type PaymentResponse struct {
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
-	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Amount        *moneypb.Money         `protobuf:"bytes,8,opt,name=amount,proto3" json:"amount,omitempty"`
 	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
 	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     int64                  `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
@@ -85,11 +86,11 @@ func (x *PaymentResponse) GetType() string {
 	return ""
 }
 
-func (x *PaymentResponse) GetAmount() float64 {
+func (x *PaymentResponse) GetAmount() *moneypb.Money {
 	if x != nil {
 		return x.Amount
 	}
-	return 0
+	return nil
 }
 
 func (x *PaymentResponse) GetStatus() string {
@@ -114,12 +115,15 @@ func (x *PaymentResponse) GetUpdatedAt() int64 {
 }
 
 type CreatePaymentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
-	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Type   string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Amount *moneypb.Money         `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Optional. Repeating a request with the same key returns the original
+	// payment instead of creating another one.
+	IdempotencyKey string `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CreatePaymentRequest) Reset() {
@@ -166,11 +170,18 @@ func (x *CreatePaymentRequest) GetType() string {
 	return ""
 }
 
-func (x *CreatePaymentRequest) GetAmount() float64 {
+func (x *CreatePaymentRequest) GetAmount() *moneypb.Money {
 	if x != nil {
 		return x.Amount
 	}
-	return 0
+	return nil
+}
+
+func (x *CreatePaymentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
 }
 
 type GetPaymentRequest struct {
@@ -341,38 +352,699 @@ func (x *PaymentsResponse) GetPayments() []*PaymentResponse {
 	return nil
 }
 
+type ReserveFundsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount        *moneypb.Money         `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reference     string                 `protobuf:"bytes,3,opt,name=reference,proto3" json:"reference,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveFundsRequest) Reset() {
+	*x = ReserveFundsRequest{}
+	mi := &file_proto_payment_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveFundsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveFundsRequest) ProtoMessage() {}
+
+func (x *ReserveFundsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveFundsRequest.ProtoReflect.Descriptor instead.
+func (*ReserveFundsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReserveFundsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReserveFundsRequest) GetAmount() *moneypb.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *ReserveFundsRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+type CaptureFundsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptureFundsRequest) Reset() {
+	*x = CaptureFundsRequest{}
+	mi := &file_proto_payment_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureFundsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureFundsRequest) ProtoMessage() {}
+
+func (x *CaptureFundsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureFundsRequest.ProtoReflect.Descriptor instead.
+func (*CaptureFundsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CaptureFundsRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type ReleaseFundsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseFundsRequest) Reset() {
+	*x = ReleaseFundsRequest{}
+	mi := &file_proto_payment_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseFundsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseFundsRequest) ProtoMessage() {}
+
+func (x *ReleaseFundsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseFundsRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseFundsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReleaseFundsRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+type ReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount        *moneypb.Money         `protobuf:"bytes,8,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reference     string                 `protobuf:"bytes,4,opt,name=reference,proto3" json:"reference,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     int64                  `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReservationResponse) Reset() {
+	*x = ReservationResponse{}
+	mi := &file_proto_payment_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReservationResponse) ProtoMessage() {}
+
+func (x *ReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReservationResponse.ProtoReflect.Descriptor instead.
+func (*ReservationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payment_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ReservationResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReservationResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReservationResponse) GetAmount() *moneypb.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *ReservationResponse) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *ReservationResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ReservationResponse) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ReservationResponse) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type GetBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceRequest) Reset() {
+	*x = GetBalanceRequest{}
+	mi := &file_proto_payment_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceRequest) ProtoMessage() {}
+
+func (x *GetBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetBalanceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type BalanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Wallet        *moneypb.Money         `protobuf:"bytes,5,opt,name=wallet,proto3" json:"wallet,omitempty"`
+	Pending       *moneypb.Money         `protobuf:"bytes,6,opt,name=pending,proto3" json:"pending,omitempty"`
+	Bonus         *moneypb.Money         `protobuf:"bytes,7,opt,name=bonus,proto3" json:"bonus,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BalanceResponse) Reset() {
+	*x = BalanceResponse{}
+	mi := &file_proto_payment_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceResponse) ProtoMessage() {}
+
+func (x *BalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceResponse.ProtoReflect.Descriptor instead.
+func (*BalanceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payment_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BalanceResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BalanceResponse) GetWallet() *moneypb.Money {
+	if x != nil {
+		return x.Wallet
+	}
+	return nil
+}
+
+func (x *BalanceResponse) GetPending() *moneypb.Money {
+	if x != nil {
+		return x.Pending
+	}
+	return nil
+}
+
+func (x *BalanceResponse) GetBonus() *moneypb.Money {
+	if x != nil {
+		return x.Bonus
+	}
+	return nil
+}
+
+type ListLedgerEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLedgerEntriesRequest) Reset() {
+	*x = ListLedgerEntriesRequest{}
+	mi := &file_proto_payment_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLedgerEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLedgerEntriesRequest) ProtoMessage() {}
+
+func (x *ListLedgerEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLedgerEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListLedgerEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListLedgerEntriesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListLedgerEntriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListLedgerEntriesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type LedgerLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	OwnerId       string                 `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	AccountType   string                 `protobuf:"bytes,3,opt,name=account_type,json=accountType,proto3" json:"account_type,omitempty"`
+	Debit         *moneypb.Money         `protobuf:"bytes,6,opt,name=debit,proto3" json:"debit,omitempty"`
+	Credit        *moneypb.Money         `protobuf:"bytes,7,opt,name=credit,proto3" json:"credit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerLine) Reset() {
+	*x = LedgerLine{}
+	mi := &file_proto_payment_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerLine) ProtoMessage() {}
+
+func (x *LedgerLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerLine.ProtoReflect.Descriptor instead.
+func (*LedgerLine) Descriptor() ([]byte, []int) {
+	return file_proto_payment_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *LedgerLine) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *LedgerLine) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *LedgerLine) GetAccountType() string {
+	if x != nil {
+		return x.AccountType
+	}
+	return ""
+}
+
+func (x *LedgerLine) GetDebit() *moneypb.Money {
+	if x != nil {
+		return x.Debit
+	}
+	return nil
+}
+
+func (x *LedgerLine) GetCredit() *moneypb.Money {
+	if x != nil {
+		return x.Credit
+	}
+	return nil
+}
+
+type LedgerEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Reference     string                 `protobuf:"bytes,3,opt,name=reference,proto3" json:"reference,omitempty"`
+	Lines         []*LedgerLine          `protobuf:"bytes,4,rep,name=lines,proto3" json:"lines,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerEntry) Reset() {
+	*x = LedgerEntry{}
+	mi := &file_proto_payment_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerEntry) ProtoMessage() {}
+
+func (x *LedgerEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerEntry.ProtoReflect.Descriptor instead.
+func (*LedgerEntry) Descriptor() ([]byte, []int) {
+	return file_proto_payment_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *LedgerEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetLines() []*LedgerLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *LedgerEntry) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type LedgerEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*LedgerEntry         `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerEntriesResponse) Reset() {
+	*x = LedgerEntriesResponse{}
+	mi := &file_proto_payment_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerEntriesResponse) ProtoMessage() {}
+
+func (x *LedgerEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerEntriesResponse.ProtoReflect.Descriptor instead.
+func (*LedgerEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payment_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *LedgerEntriesResponse) GetEntries() []*LedgerEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
 var File_proto_payment_proto protoreflect.FileDescriptor
 
 const file_proto_payment_proto_rawDesc = "" +
 	"\n" +
-	"\x13proto/payment.proto\x12\x02pb\"\xbc\x01\n" +
+	"\x13proto/payment.proto\x12\x02pb\x1a\x11money/money.proto\"\xca\x01\n" +
 	"\x0fPaymentResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
-	"\x04type\x18\x03 \x01(\tR\x04type\x12\x16\n" +
-	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x16\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12$\n" +
+	"\x06amount\x18\b \x01(\v2\f.money.MoneyR\x06amount\x12\x16\n" +
 	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\x03R\tupdatedAt\"[\n" +
+	"updated_at\x18\a \x01(\x03R\tupdatedAt\"\x92\x01\n" +
 	"\x14CreatePaymentRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
-	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
-	"\x06amount\x18\x03 \x01(\x01R\x06amount\"#\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12$\n" +
+	"\x06amount\x18\x04 \x01(\v2\f.money.MoneyR\x06amount\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\"#\n" +
 	"\x11GetPaymentRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"&\n" +
 	"\x14DeletePaymentRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\a\n" +
 	"\x05Empty\"C\n" +
 	"\x10PaymentsResponse\x12/\n" +
-	"\bpayments\x18\x01 \x03(\v2\x13.pb.PaymentResponseR\bpayments2\xf3\x01\n" +
+	"\bpayments\x18\x01 \x03(\v2\x13.pb.PaymentResponseR\bpayments\"r\n" +
+	"\x13ReserveFundsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
+	"\x06amount\x18\x04 \x01(\v2\f.money.MoneyR\x06amount\x12\x1c\n" +
+	"\treference\x18\x03 \x01(\tR\treference\"<\n" +
+	"\x13CaptureFundsRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\"<\n" +
+	"\x13ReleaseFundsRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\"\xd8\x01\n" +
+	"\x13ReservationResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12$\n" +
+	"\x06amount\x18\b \x01(\v2\f.money.MoneyR\x06amount\x12\x1c\n" +
+	"\treference\x18\x04 \x01(\tR\treference\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\x03R\tupdatedAt\",\n" +
+	"\x11GetBalanceRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x9c\x01\n" +
+	"\x0fBalanceResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
+	"\x06wallet\x18\x05 \x01(\v2\f.money.MoneyR\x06wallet\x12&\n" +
+	"\apending\x18\x06 \x01(\v2\f.money.MoneyR\apending\x12\"\n" +
+	"\x05bonus\x18\a \x01(\v2\f.money.MoneyR\x05bonus\"a\n" +
+	"\x18ListLedgerEntriesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\xb3\x01\n" +
+	"\n" +
+	"LedgerLine\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\tR\aownerId\x12!\n" +
+	"\faccount_type\x18\x03 \x01(\tR\vaccountType\x12\"\n" +
+	"\x05debit\x18\x06 \x01(\v2\f.money.MoneyR\x05debit\x12$\n" +
+	"\x06credit\x18\a \x01(\v2\f.money.MoneyR\x06credit\"\x94\x01\n" +
+	"\vLedgerEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1c\n" +
+	"\treference\x18\x03 \x01(\tR\treference\x12$\n" +
+	"\x05lines\x18\x04 \x03(\v2\x0e.pb.LedgerLineR\x05lines\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\"B\n" +
+	"\x15LedgerEntriesResponse\x12)\n" +
+	"\aentries\x18\x01 \x03(\v2\x0f.pb.LedgerEntryR\aentries2\xc1\x04\n" +
 	"\x0ePaymentService\x12>\n" +
 	"\rCreatePayment\x12\x18.pb.CreatePaymentRequest\x1a\x13.pb.PaymentResponse\x128\n" +
 	"\n" +
 	"GetPayment\x12\x15.pb.GetPaymentRequest\x1a\x13.pb.PaymentResponse\x121\n" +
 	"\x0eGetAllPayments\x12\t.pb.Empty\x1a\x14.pb.PaymentsResponse\x124\n" +
-	"\rDeletePayment\x12\x18.pb.DeletePaymentRequest\x1a\t.pb.EmptyB\x05Z\x03/pbb\x06proto3"
+	"\rDeletePayment\x12\x18.pb.DeletePaymentRequest\x1a\t.pb.Empty\x12@\n" +
+	"\fReserveFunds\x12\x17.pb.ReserveFundsRequest\x1a\x17.pb.ReservationResponse\x12@\n" +
+	"\fCaptureFunds\x12\x17.pb.CaptureFundsRequest\x1a\x17.pb.ReservationResponse\x12@\n" +
+	"\fReleaseFunds\x12\x17.pb.ReleaseFundsRequest\x1a\x17.pb.ReservationResponse\x128\n" +
+	"\n" +
+	"GetBalance\x12\x15.pb.GetBalanceRequest\x1a\x13.pb.BalanceResponse\x12L\n" +
+	"\x11ListLedgerEntries\x12\x1c.pb.ListLedgerEntriesRequest\x1a\x19.pb.LedgerEntriesResponseB\x05Z\x03/pbb\x06proto3"
 
 var (
 	file_proto_payment_proto_rawDescOnce sync.Once
@@ -386,30 +1058,62 @@ func file_proto_payment_proto_rawDescGZIP() []byte {
 	return file_proto_payment_proto_rawDescData
 }
 
-var file_proto_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_proto_payment_proto_goTypes = []any{
-	(*PaymentResponse)(nil),      // 0: pb.PaymentResponse
-	(*CreatePaymentRequest)(nil), // 1: pb.CreatePaymentRequest
-	(*GetPaymentRequest)(nil),    // 2: pb.GetPaymentRequest
-	(*DeletePaymentRequest)(nil), // 3: pb.DeletePaymentRequest
-	(*Empty)(nil),                // 4: pb.Empty
-	(*PaymentsResponse)(nil),     // 5: pb.PaymentsResponse
+	(*PaymentResponse)(nil),          // 0: pb.PaymentResponse
+	(*CreatePaymentRequest)(nil),     // 1: pb.CreatePaymentRequest
+	(*GetPaymentRequest)(nil),        // 2: pb.GetPaymentRequest
+	(*DeletePaymentRequest)(nil),     // 3: pb.DeletePaymentRequest
+	(*Empty)(nil),                    // 4: pb.Empty
+	(*PaymentsResponse)(nil),         // 5: pb.PaymentsResponse
+	(*ReserveFundsRequest)(nil),      // 6: pb.ReserveFundsRequest
+	(*CaptureFundsRequest)(nil),      // 7: pb.CaptureFundsRequest
+	(*ReleaseFundsRequest)(nil),      // 8: pb.ReleaseFundsRequest
+	(*ReservationResponse)(nil),      // 9: pb.ReservationResponse
+	(*GetBalanceRequest)(nil),        // 10: pb.GetBalanceRequest
+	(*BalanceResponse)(nil),          // 11: pb.BalanceResponse
+	(*ListLedgerEntriesRequest)(nil), // 12: pb.ListLedgerEntriesRequest
+	(*LedgerLine)(nil),               // 13: pb.LedgerLine
+	(*LedgerEntry)(nil),              // 14: pb.LedgerEntry
+	(*LedgerEntriesResponse)(nil),    // 15: pb.LedgerEntriesResponse
+	(*moneypb.Money)(nil),            // 16: money.Money
 }
 var file_proto_payment_proto_depIdxs = []int32{
-	0, // 0: pb.PaymentsResponse.payments:type_name -> pb.PaymentResponse
-	1, // 1: pb.PaymentService.CreatePayment:input_type -> pb.CreatePaymentRequest
-	2, // 2: pb.PaymentService.GetPayment:input_type -> pb.GetPaymentRequest
-	4, // 3: pb.PaymentService.GetAllPayments:input_type -> pb.Empty
-	3, // 4: pb.PaymentService.DeletePayment:input_type -> pb.DeletePaymentRequest
-	0, // 5: pb.PaymentService.CreatePayment:output_type -> pb.PaymentResponse
-	0, // 6: pb.PaymentService.GetPayment:output_type -> pb.PaymentResponse
-	5, // 7: pb.PaymentService.GetAllPayments:output_type -> pb.PaymentsResponse
-	4, // 8: pb.PaymentService.DeletePayment:output_type -> pb.Empty
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	16, // 0: pb.PaymentResponse.amount:type_name -> money.Money
+	16, // 1: pb.CreatePaymentRequest.amount:type_name -> money.Money
+	0,  // 2: pb.PaymentsResponse.payments:type_name -> pb.PaymentResponse
+	16, // 3: pb.ReserveFundsRequest.amount:type_name -> money.Money
+	16, // 4: pb.ReservationResponse.amount:type_name -> money.Money
+	16, // 5: pb.BalanceResponse.wallet:type_name -> money.Money
+	16, // 6: pb.BalanceResponse.pending:type_name -> money.Money
+	16, // 7: pb.BalanceResponse.bonus:type_name -> money.Money
+	16, // 8: pb.LedgerLine.debit:type_name -> money.Money
+	16, // 9: pb.LedgerLine.credit:type_name -> money.Money
+	13, // 10: pb.LedgerEntry.lines:type_name -> pb.LedgerLine
+	14, // 11: pb.LedgerEntriesResponse.entries:type_name -> pb.LedgerEntry
+	1,  // 12: pb.PaymentService.CreatePayment:input_type -> pb.CreatePaymentRequest
+	2,  // 13: pb.PaymentService.GetPayment:input_type -> pb.GetPaymentRequest
+	4,  // 14: pb.PaymentService.GetAllPayments:input_type -> pb.Empty
+	3,  // 15: pb.PaymentService.DeletePayment:input_type -> pb.DeletePaymentRequest
+	6,  // 16: pb.PaymentService.ReserveFunds:input_type -> pb.ReserveFundsRequest
+	7,  // 17: pb.PaymentService.CaptureFunds:input_type -> pb.CaptureFundsRequest
+	8,  // 18: pb.PaymentService.ReleaseFunds:input_type -> pb.ReleaseFundsRequest
+	10, // 19: pb.PaymentService.GetBalance:input_type -> pb.GetBalanceRequest
+	12, // 20: pb.PaymentService.ListLedgerEntries:input_type -> pb.ListLedgerEntriesRequest
+	0,  // 21: pb.PaymentService.CreatePayment:output_type -> pb.PaymentResponse
+	0,  // 22: pb.PaymentService.GetPayment:output_type -> pb.PaymentResponse
+	5,  // 23: pb.PaymentService.GetAllPayments:output_type -> pb.PaymentsResponse
+	4,  // 24: pb.PaymentService.DeletePayment:output_type -> pb.Empty
+	9,  // 25: pb.PaymentService.ReserveFunds:output_type -> pb.ReservationResponse
+	9,  // 26: pb.PaymentService.CaptureFunds:output_type -> pb.ReservationResponse
+	9,  // 27: pb.PaymentService.ReleaseFunds:output_type -> pb.ReservationResponse
+	11, // 28: pb.PaymentService.GetBalance:output_type -> pb.BalanceResponse
+	15, // 29: pb.PaymentService.ListLedgerEntries:output_type -> pb.LedgerEntriesResponse
+	21, // [21:30] is the sub-list for method output_type
+	12, // [12:21] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_proto_payment_proto_init() }
@@ -423,7 +1127,7 @@ func file_proto_payment_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_payment_proto_rawDesc), len(file_proto_payment_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
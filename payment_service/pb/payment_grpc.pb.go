@@ -19,10 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PaymentService_CreatePayment_FullMethodName  = "/pb.PaymentService/CreatePayment"
-	PaymentService_GetPayment_FullMethodName     = "/pb.PaymentService/GetPayment"
-	PaymentService_GetAllPayments_FullMethodName = "/pb.PaymentService/GetAllPayments"
-	PaymentService_DeletePayment_FullMethodName  = "/pb.PaymentService/DeletePayment"
+	PaymentService_CreatePayment_FullMethodName     = "/pb.PaymentService/CreatePayment"
+	PaymentService_GetPayment_FullMethodName        = "/pb.PaymentService/GetPayment"
+	PaymentService_GetAllPayments_FullMethodName    = "/pb.PaymentService/GetAllPayments"
+	PaymentService_DeletePayment_FullMethodName     = "/pb.PaymentService/DeletePayment"
+	PaymentService_ReserveFunds_FullMethodName      = "/pb.PaymentService/ReserveFunds"
+	PaymentService_CaptureFunds_FullMethodName      = "/pb.PaymentService/CaptureFunds"
+	PaymentService_ReleaseFunds_FullMethodName      = "/pb.PaymentService/ReleaseFunds"
+	PaymentService_GetBalance_FullMethodName        = "/pb.PaymentService/GetBalance"
+	PaymentService_ListLedgerEntries_FullMethodName = "/pb.PaymentService/ListLedgerEntries"
 )
 
 // PaymentServiceClient is the client API for PaymentService service.
@@ -33,6 +38,11 @@ type PaymentServiceClient interface {
 	GetPayment(ctx context.Context, in *GetPaymentRequest, opts ...grpc.CallOption) (*PaymentResponse, error)
 	GetAllPayments(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PaymentsResponse, error)
 	DeletePayment(ctx context.Context, in *DeletePaymentRequest, opts ...grpc.CallOption) (*Empty, error)
+	ReserveFunds(ctx context.Context, in *ReserveFundsRequest, opts ...grpc.CallOption) (*ReservationResponse, error)
+	CaptureFunds(ctx context.Context, in *CaptureFundsRequest, opts ...grpc.CallOption) (*ReservationResponse, error)
+	ReleaseFunds(ctx context.Context, in *ReleaseFundsRequest, opts ...grpc.CallOption) (*ReservationResponse, error)
+	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*BalanceResponse, error)
+	ListLedgerEntries(ctx context.Context, in *ListLedgerEntriesRequest, opts ...grpc.CallOption) (*LedgerEntriesResponse, error)
 }
 
 type paymentServiceClient struct {
@@ -83,6 +93,56 @@ func (c *paymentServiceClient) DeletePayment(ctx context.Context, in *DeletePaym
 	return out, nil
 }
 
+func (c *paymentServiceClient) ReserveFunds(ctx context.Context, in *ReserveFundsRequest, opts ...grpc.CallOption) (*ReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReservationResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ReserveFunds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) CaptureFunds(ctx context.Context, in *CaptureFundsRequest, opts ...grpc.CallOption) (*ReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReservationResponse)
+	err := c.cc.Invoke(ctx, PaymentService_CaptureFunds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ReleaseFunds(ctx context.Context, in *ReleaseFundsRequest, opts ...grpc.CallOption) (*ReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReservationResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ReleaseFunds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*BalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BalanceResponse)
+	err := c.cc.Invoke(ctx, PaymentService_GetBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ListLedgerEntries(ctx context.Context, in *ListLedgerEntriesRequest, opts ...grpc.CallOption) (*LedgerEntriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LedgerEntriesResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ListLedgerEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PaymentServiceServer is the server API for PaymentService service.
 // All implementations must embed UnimplementedPaymentServiceServer
 // for forward compatibility.
@@ -91,6 +151,11 @@ type PaymentServiceServer interface {
 	GetPayment(context.Context, *GetPaymentRequest) (*PaymentResponse, error)
 	GetAllPayments(context.Context, *Empty) (*PaymentsResponse, error)
 	DeletePayment(context.Context, *DeletePaymentRequest) (*Empty, error)
+	ReserveFunds(context.Context, *ReserveFundsRequest) (*ReservationResponse, error)
+	CaptureFunds(context.Context, *CaptureFundsRequest) (*ReservationResponse, error)
+	ReleaseFunds(context.Context, *ReleaseFundsRequest) (*ReservationResponse, error)
+	GetBalance(context.Context, *GetBalanceRequest) (*BalanceResponse, error)
+	ListLedgerEntries(context.Context, *ListLedgerEntriesRequest) (*LedgerEntriesResponse, error)
 	mustEmbedUnimplementedPaymentServiceServer()
 }
 
@@ -113,6 +178,21 @@ func (UnimplementedPaymentServiceServer) GetAllPayments(context.Context, *Empty)
 func (UnimplementedPaymentServiceServer) DeletePayment(context.Context, *DeletePaymentRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeletePayment not implemented")
 }
+func (UnimplementedPaymentServiceServer) ReserveFunds(context.Context, *ReserveFundsRequest) (*ReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveFunds not implemented")
+}
+func (UnimplementedPaymentServiceServer) CaptureFunds(context.Context, *CaptureFundsRequest) (*ReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CaptureFunds not implemented")
+}
+func (UnimplementedPaymentServiceServer) ReleaseFunds(context.Context, *ReleaseFundsRequest) (*ReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseFunds not implemented")
+}
+func (UnimplementedPaymentServiceServer) GetBalance(context.Context, *GetBalanceRequest) (*BalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (UnimplementedPaymentServiceServer) ListLedgerEntries(context.Context, *ListLedgerEntriesRequest) (*LedgerEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLedgerEntries not implemented")
+}
 func (UnimplementedPaymentServiceServer) mustEmbedUnimplementedPaymentServiceServer() {}
 func (UnimplementedPaymentServiceServer) testEmbeddedByValue()                        {}
 
@@ -206,6 +286,96 @@ func _PaymentService_DeletePayment_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PaymentService_ReserveFunds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveFundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ReserveFunds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ReserveFunds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ReserveFunds(ctx, req.(*ReserveFundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_CaptureFunds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureFundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).CaptureFunds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_CaptureFunds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).CaptureFunds(ctx, req.(*CaptureFundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ReleaseFunds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseFundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ReleaseFunds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ReleaseFunds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ReleaseFunds(ctx, req.(*ReleaseFundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_GetBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetBalance(ctx, req.(*GetBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ListLedgerEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLedgerEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ListLedgerEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ListLedgerEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ListLedgerEntries(ctx, req.(*ListLedgerEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PaymentService_ServiceDesc is the grpc.ServiceDesc for PaymentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +399,26 @@ var PaymentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeletePayment",
 			Handler:    _PaymentService_DeletePayment_Handler,
 		},
+		{
+			MethodName: "ReserveFunds",
+			Handler:    _PaymentService_ReserveFunds_Handler,
+		},
+		{
+			MethodName: "CaptureFunds",
+			Handler:    _PaymentService_CaptureFunds_Handler,
+		},
+		{
+			MethodName: "ReleaseFunds",
+			Handler:    _PaymentService_ReleaseFunds_Handler,
+		},
+		{
+			MethodName: "GetBalance",
+			Handler:    _PaymentService_GetBalance_Handler,
+		},
+		{
+			MethodName: "ListLedgerEntries",
+			Handler:    _PaymentService_ListLedgerEntries_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/payment.proto",
@@ -1,79 +1,98 @@
 package rabbitmq
 
 import (
-	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"muchway/payment_service/domain"
 	"muchway/payment_service/usecase"
-
-	"github.com/streadway/amqp"
+	"muchway/pkg/consumer"
+	"muchway/pkg/events"
+	"muchway/pkg/limits"
+	"muchway/pkg/money"
+	"muchway/pkg/topology"
+	"strconv"
 )
 
+// PaymentEvent is a payment request. Unversioned messages and version 1 of
+// payment.requested share this shape.
 type PaymentEvent struct {
-	OrderID     string  `json:"order_id"`
-	UserID      string  `json:"user_id"`
-	Amount      float64 `json:"amount"`
-	PaymentType string  `json:"payment_type"`
+	OrderID     string      `json:"order_id"`
+	UserID      string      `json:"user_id"`
+	Amount      money.Money `json:"amount"`
+	PaymentType string      `json:"payment_type"`
+	// IdempotencyKey guards against redelivery; when it is missing the order
+	// ID is used instead.
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
 
-func StartConsumer(conn *amqp.Connection, uc *usecase.PaymentUsecase, queue string) {
-	ch, err := conn.Channel()
-	if err != nil {
-		log.Fatal(err)
+// StartConsumer processes payment events from queue. A payment that fails for
+// a transient reason is retried; one that can never succeed is dead-lettered.
+func StartConsumer(c *consumer.Consumer, uc *usecase.PaymentUsecase, queue string) error {
+	handle := func(env *events.Envelope) error {
+		return handlePaymentEvent(uc, env)
 	}
+	return c.Consume(queue, events.Handle(topology.PaymentRequested, events.Versions{0: handle, 1: handle}))
+}
 
-	_, err = ch.QueueDeclare(
-		queue,
-		true,
-		false,
-		false,
-		false,
-		nil,
-	)
-	if err != nil {
-		log.Fatal("Failed to declare queue:", err)
+// StartExclusionConsumer records the self-exclusions user_service announces
+// on queue, so deposits from excluded users are refused.
+func StartExclusionConsumer(c *consumer.Consumer, uc *usecase.PaymentUsecase, queue string) error {
+	handle := func(env *events.Envelope) error {
+		var excluded events.UserExcludedV1
+		if err := env.Unmarshal(&excluded); err != nil {
+			log.Println("Failed to unmarshal user.excluded:", err)
+			return consumer.Permanent(err)
+		}
+		return uc.RecordExclusion(strconv.FormatInt(excluded.ID, 10), limits.Exclusion{Since: excluded.Since, Until: excluded.Until})
 	}
+	return c.Consume(queue, events.Handle(topology.UserExcluded, events.Versions{1: handle}))
+}
 
-	msgs, err := ch.Consume(queue, "", true, false, false, false, nil)
-	if err != nil {
-		log.Fatal("Failed to start consuming:", err)
+func handlePaymentEvent(uc *usecase.PaymentUsecase, env *events.Envelope) error {
+	var ev PaymentEvent
+	if err := env.Unmarshal(&ev); err != nil {
+		log.Println("Failed to unmarshal payment event:", err)
+		return consumer.Permanent(err)
 	}
 
-	log.Println("Started consuming from queue:", queue)
-
-	for d := range msgs {
-		var ev PaymentEvent
-		err := json.Unmarshal(d.Body, &ev)
-		if err != nil {
-			log.Println("Failed to unmarshal payment event:", err)
-			continue
-		}
-
-		if ev.PaymentType == "" {
-			ev.PaymentType = "deposit"
-		}
+	if ev.PaymentType == "" {
+		ev.PaymentType = "deposit"
+	}
 
-		if ev.PaymentType != "deposit" && ev.PaymentType != "withdraw" {
-			log.Printf("Invalid payment type: %s. Must be 'deposit' or 'withdraw'", ev.PaymentType)
-			continue
-		}
+	if ev.PaymentType != "deposit" && ev.PaymentType != "withdraw" {
+		log.Printf("Invalid payment type: %s. Must be 'deposit' or 'withdraw'", ev.PaymentType)
+		return consumer.Permanent(fmt.Errorf("invalid payment type %q", ev.PaymentType))
+	}
 
-		log.Printf("Processing %s of %.2f for user %s (Order: %s)",
-			ev.PaymentType, ev.Amount, ev.UserID, ev.OrderID)
+	log.Printf("Processing %s of %s for user %s (Order: %s)",
+		ev.PaymentType, ev.Amount, ev.UserID, ev.OrderID)
 
-		payment, err := uc.ProcessPayment(ev.UserID, ev.Amount, ev.PaymentType)
-		if err != nil {
-			log.Printf("Failed to process payment: %v", err)
-			continue
-		}
+	key := ev.IdempotencyKey
+	if key == "" && ev.OrderID != "" {
+		key = "order:" + ev.OrderID
+	}
 
-		updatedPayment, err := uc.GetPaymentByID(payment.ID)
-		if err != nil {
-			log.Printf("Error retrieving payment after processing: %v", err)
-		} else {
-			log.Printf("Payment status after processing: %s", updatedPayment.Status)
+	// Requests on the bus come from services, which need no second factor
+	payment, err := uc.ProcessPayment(ev.UserID, ev.Amount, ev.PaymentType, key, nil)
+	if err != nil {
+		log.Printf("Failed to process payment: %v", err)
+		if errors.Is(err, domain.ErrInsufficientBalance) || errors.Is(err, domain.ErrIdempotencyKeyReused) ||
+			errors.Is(err, limits.ErrLimitExceeded) || errors.Is(err, limits.ErrSelfExcluded) ||
+			errors.Is(err, domain.ErrKYCRequired) || errors.Is(err, domain.ErrUnsupportedCurrency) {
+			return consumer.Permanent(err)
 		}
+		return err
+	}
 
-		log.Printf("Successfully processed %s payment (ID: %s) for order: %s",
-			ev.PaymentType, payment.ID, ev.OrderID)
+	updatedPayment, err := uc.GetPaymentByID(payment.ID)
+	if err != nil {
+		log.Printf("Error retrieving payment after processing: %v", err)
+	} else {
+		log.Printf("Payment status after processing: %s", updatedPayment.Status)
 	}
+
+	log.Printf("Successfully processed %s payment (ID: %s) for order: %s",
+		ev.PaymentType, payment.ID, ev.OrderID)
+	return nil
 }
@@ -0,0 +1,17 @@
+package rabbitmq
+
+import "muchway/pkg/topology"
+
+// Queues consumed by payment_service.
+const (
+	// QueuePaymentRequests receives deposit and withdrawal requests.
+	QueuePaymentRequests = "payment_service.payment.requested"
+	QueueUserExcluded    = "payment_service.user.excluded"
+)
+
+// Queues lists the queues payment_service declares at startup with their
+// bindings.
+var Queues = []topology.Queue{
+	{Name: QueuePaymentRequests, Bindings: []topology.Binding{{Exchange: topology.ExchangePayments, Key: topology.PaymentRequested}}},
+	{Name: QueueUserExcluded, Bindings: []topology.Binding{{Exchange: topology.ExchangeUsers, Key: topology.UserExcluded}}},
+}
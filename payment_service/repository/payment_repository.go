@@ -1,12 +1,71 @@
 package repository
 
-import "muchway/payment_service/domain"
+import (
+	"muchway/payment_service/domain"
+	"muchway/pkg/limits"
+	"muchway/pkg/money"
+	"time"
+)
+
+// SumFunc adds up the user's payments of a type made since the given time,
+// leaving out failed ones.
+type SumFunc func(userID, paymentType string, since time.Time) (money.Money, error)
+
+// LimitCheck refuses a payment that the user's earlier payments leave no
+// room for.
+type LimitCheck func(sum SumFunc) error
 
 type PaymentRepository interface {
-	Create(payment *domain.Payment) error
+	// Create stores the payment together with its idempotency key, if any. It
+	// returns domain.ErrDuplicateIdempotencyKey when the key is taken. A
+	// non-nil check runs first, in the same transaction and with the user's
+	// payments locked, so that two payments cannot both fit under a limit
+	// only one of them fits under; its error is returned as is.
+	Create(payment *domain.Payment, check LimitCheck) error
+	// FindByIdempotencyKey returns the payment created with the user's key,
+	// or nil if there is none.
+	FindByIdempotencyKey(userID, key string) (*domain.Payment, error)
+	// PurgeIdempotencyKeys forgets keys created before the given time.
+	PurgeIdempotencyKeys(before time.Time) (int64, error)
 	GetByID(id string) (*domain.Payment, error)
 	GetAll() ([]*domain.Payment, error)
+	// SumPayments adds up the user's payments of a type made since the given
+	// time, leaving out failed ones.
+	SumPayments(userID, paymentType string, since time.Time) (money.Money, error)
 	DeleteByID(id string) error
-	UpdateUserBalance(userID string, amount float64, operation string) error
 	UpdateStatus(id string, status string) error
+
+	// SaveExclusion records a user's self-exclusion. An exclusion that ends
+	// sooner than the one on record is ignored, so redelivered messages
+	// cannot shorten it.
+	SaveExclusion(userID string, e limits.Exclusion) error
+	// GetExclusion returns the user's latest exclusion, or nil if there is
+	// none.
+	GetExclusion(userID string) (*limits.Exclusion, error)
+
+	// PostPayment records the journal entry that moves the money of a
+	// pending deposit, withdrawal or payout and marks it completed, in one
+	// transaction. If the entry cannot be posted, the payment is marked
+	// failed and its idempotency key released instead. A payment that is no
+	// longer pending is left alone. It reports whether this call posted the
+	// payment, and updates its status.
+	PostPayment(payment *domain.Payment) (bool, error)
+	GetBalance(userID string) (*domain.Balance, error)
+	// ListLedgerEntries returns the user's journal entries, newest first.
+	ListLedgerEntries(userID string, limit, offset int) ([]*domain.JournalEntry, error)
+	// ImportOpeningBalances posts an opening balance entry for each user with
+	// a positive balance and records that the import has run, all at once.
+	// It returns domain.ErrOpeningBalancesImported if it has run before.
+	ImportOpeningBalances(balances map[string]money.Money) (int, error)
+	// OpeningBalancesImported reports whether ImportOpeningBalances has run.
+	OpeningBalancesImported() (bool, error)
+
+	// CreateReservation moves the amount from the user's wallet to pending
+	// and records the hold. A reservation with the same reference is returned
+	// as is instead.
+	CreateReservation(r *domain.Reservation) error
+	GetReservation(id string) (*domain.Reservation, error)
+	CaptureReservation(id string) (*domain.Reservation, error)
+	// ReleaseReservation cancels the hold and moves the amount back to the wallet.
+	ReleaseReservation(id string) (*domain.Reservation, error)
 }
@@ -0,0 +1,337 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"muchway/payment_service/domain"
+	"muchway/pkg/money"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostPayment records the journal entry of a pending deposit, withdrawal or
+// payout and completes the payment. The payment ID is used as the entry
+// reference. The payment row stays locked throughout, so a retry resuming the
+// same payment waits and then finds it no longer pending.
+func (r *PostgresPaymentRepository) PostPayment(p *domain.Payment) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRow(`SELECT status FROM payments WHERE id = $1 FOR UPDATE`, p.ID).Scan(&p.Status); err != nil {
+		return false, err
+	}
+	if p.Status != "pending" {
+		return false, nil
+	}
+
+	if _, err := tx.Exec(`SAVEPOINT posting`); err != nil {
+		return false, err
+	}
+	entry, err := paymentEntry(p)
+	if err == nil {
+		err = post(tx, entry)
+	}
+	if err != nil {
+		// Keep the payment as failed and let its key be used again
+		if failErr := failPayment(tx, p.ID); failErr != nil {
+			log.Printf("Failed to mark payment %s failed: %v", p.ID, failErr)
+		} else {
+			p.Status = "failed"
+		}
+		return false, err
+	}
+
+	now := time.Now()
+	if _, err := tx.Exec(`UPDATE payments SET status = 'completed', updated_at = $1 WHERE id = $2`, now, p.ID); err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	p.Status, p.UpdatedAt = "completed", now
+	log.Printf("Posted %s of %s for user %s (entry %s)", entry.Kind, p.Amount, p.UserID, entry.ID)
+	return true, nil
+}
+
+// paymentEntry returns the journal entry that moves the money of a payment.
+func paymentEntry(p *domain.Payment) (*domain.JournalEntry, error) {
+	userID, err := ownerID(p.UserID)
+	if err != nil {
+		return nil, err
+	}
+	switch p.Type {
+	case "deposit":
+		return domain.Transfer(domain.EntryKindDeposit, p.ID, "", domain.AccountTypeExternal, userID, domain.AccountTypeWallet, p.Amount), nil
+	case "withdraw":
+		return domain.Transfer(domain.EntryKindWithdrawal, p.ID, userID, domain.AccountTypeWallet, "", domain.AccountTypeExternal, p.Amount), nil
+	case "payout":
+		return domain.Transfer(domain.EntryKindPayout, p.ID, "", domain.AccountTypeHouse, userID, domain.AccountTypeWallet, p.Amount), nil
+	default:
+		return nil, fmt.Errorf("unsupported operation: %s", p.Type)
+	}
+}
+
+// failPayment undoes what was posted since the savepoint, then marks the
+// payment failed and frees its idempotency key.
+func failPayment(tx *sql.Tx, paymentID string) error {
+	if _, err := tx.Exec(`ROLLBACK TO SAVEPOINT posting`); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`UPDATE payments SET status = 'failed', updated_at = $1 WHERE id = $2`, time.Now(), paymentID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM payment_idempotency_keys WHERE payment_id = $1`, paymentID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresPaymentRepository) GetBalance(userID string) (*domain.Balance, error) {
+	id, err := ownerID(userID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.Query(`SELECT type, balance, version FROM ledger_accounts WHERE owner_id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	b := &domain.Balance{UserID: id}
+	for rows.Next() {
+		var accountType string
+		var balance money.Money
+		var version int64
+		if err := rows.Scan(&accountType, &balance, &version); err != nil {
+			return nil, err
+		}
+		switch accountType {
+		case domain.AccountTypeWallet:
+			b.Wallet = balance
+			b.WalletVersion = version
+		case domain.AccountTypePending:
+			b.Pending = balance
+		case domain.AccountTypeBonus:
+			b.Bonus = balance
+		}
+	}
+	return b, rows.Err()
+}
+
+func (r *PostgresPaymentRepository) ListLedgerEntries(userID string, limit, offset int) ([]*domain.JournalEntry, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	id, err := ownerID(userID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(`
+		SELECT e.id, e.kind, e.reference, e.created_at
+		FROM journal_entries e
+		WHERE EXISTS (
+			SELECT 1 FROM journal_lines l JOIN ledger_accounts a ON a.id = l.account_id
+			WHERE l.entry_id = e.id AND a.owner_id = $1
+		)
+		ORDER BY e.created_at DESC, e.id
+		LIMIT $2 OFFSET $3`, id, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*domain.JournalEntry
+	for rows.Next() {
+		e := &domain.JournalEntry{}
+		if err := rows.Scan(&e.ID, &e.Kind, &e.Reference, &e.CreatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		lines, err := tx.Query(`
+			SELECT l.account_id, a.owner_id, a.type, l.debit, l.credit
+			FROM journal_lines l JOIN ledger_accounts a ON a.id = l.account_id
+			WHERE l.entry_id = $1 ORDER BY l.id`, e.ID)
+		if err != nil {
+			return nil, err
+		}
+		for lines.Next() {
+			var l domain.JournalLine
+			if err := lines.Scan(&l.AccountID, &l.OwnerID, &l.AccountType, &l.Debit, &l.Credit); err != nil {
+				lines.Close()
+				return nil, err
+			}
+			e.Lines = append(e.Lines, l)
+		}
+		lines.Close()
+		if err := lines.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// ImportOpeningBalances carries the balances the user service held over to
+// the ledger, moving each from the external account to the user's wallet.
+// The marker row is inserted first, so a concurrent import waits for this
+// one and then finds it done.
+func (r *PostgresPaymentRepository) ImportOpeningBalances(balances map[string]money.Money) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(`INSERT INTO opening_balance_imports (users, imported_at) VALUES (0, $1) ON CONFLICT (id) DO NOTHING`, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return 0, err
+	} else if n == 0 {
+		return 0, domain.ErrOpeningBalancesImported
+	}
+
+	userIDs := make([]string, 0, len(balances))
+	for userID := range balances {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Strings(userIDs)
+
+	imported := 0
+	for _, userID := range userIDs {
+		balance := balances[userID]
+		if balance.IsNegative() {
+			return 0, fmt.Errorf("user %s has a negative balance of %s", userID, balance)
+		}
+		if !balance.IsPositive() {
+			continue
+		}
+		id, err := ownerID(userID)
+		if err != nil {
+			return 0, err
+		}
+		entry := domain.Transfer(domain.EntryKindOpeningBalance, "user:"+id, "", domain.AccountTypeExternal, id, domain.AccountTypeWallet, balance)
+		if err := post(tx, entry); err != nil {
+			return 0, fmt.Errorf("posting the opening balance of user %s: %w", id, err)
+		}
+		imported++
+	}
+
+	if _, err := tx.Exec(`UPDATE opening_balance_imports SET users = $1`, imported); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	log.Printf("Imported the opening balances of %d user(s)", imported)
+	return imported, nil
+}
+
+func (r *PostgresPaymentRepository) OpeningBalancesImported() (bool, error) {
+	var imported bool
+	err := r.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM opening_balance_imports)`).Scan(&imported)
+	return imported, err
+}
+
+// post writes a balanced journal entry inside tx and applies it to the
+// materialised account balances. Accounts are locked in ID order so that
+// concurrent postings cannot deadlock, and user accounts may not go negative.
+func post(tx *sql.Tx, entry *domain.JournalEntry) error {
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	deltas := make(map[string]int64)
+	types := make(map[string]string)
+	for i := range entry.Lines {
+		l := &entry.Lines[i]
+		id, err := accountID(tx, l.OwnerID, l.AccountType)
+		if err != nil {
+			return err
+		}
+		l.AccountID = id
+		deltas[id] += l.Credit.Minor - l.Debit.Minor
+		types[id] = l.AccountType
+	}
+
+	ids := make([]string, 0, len(deltas))
+	for id := range deltas {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	now := time.Now()
+	for _, id := range ids {
+		var balance money.Money
+		if err := tx.QueryRow(`SELECT balance FROM ledger_accounts WHERE id = $1 FOR UPDATE`, id).Scan(&balance); err != nil {
+			return err
+		}
+		newBalance := money.FromMinor(balance.Minor+deltas[id], balance.Currency)
+		if domain.IsUserAccount(types[id]) && newBalance.IsNegative() {
+			log.Printf("Insufficient %s balance on account %s: %s < %s", types[id], id, balance, money.FromMinor(-deltas[id], ""))
+			return domain.ErrInsufficientBalance
+		}
+		if _, err := tx.Exec(`UPDATE ledger_accounts SET balance = $1, updated_at = $2, version = version + 1 WHERE id = $3`, newBalance, now, id); err != nil {
+			return err
+		}
+	}
+
+	entry.ID = uuid.New().String()
+	entry.CreatedAt = now
+	if _, err := tx.Exec(`INSERT INTO journal_entries (id, kind, reference, created_at) VALUES ($1, $2, $3, $4)`,
+		entry.ID, entry.Kind, entry.Reference, entry.CreatedAt); err != nil {
+		return err
+	}
+	for _, l := range entry.Lines {
+		if _, err := tx.Exec(`INSERT INTO journal_lines (entry_id, account_id, debit, credit) VALUES ($1, $2, $3, $4)`,
+			entry.ID, l.AccountID, l.Debit, l.Credit); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// accountID returns the ID of the owner's account of the given type, opening
+// it on first use.
+func accountID(tx *sql.Tx, ownerID, accountType string) (string, error) {
+	now := time.Now()
+	_, err := tx.Exec(`INSERT INTO ledger_accounts (id, owner_id, type, balance, created_at, updated_at)
+		VALUES ($1, $2, $3, 0, $4, $4) ON CONFLICT (owner_id, type) DO NOTHING`,
+		uuid.New().String(), ownerID, accountType, now)
+	if err != nil {
+		return "", err
+	}
+	var id string
+	err = tx.QueryRow(`SELECT id FROM ledger_accounts WHERE owner_id = $1 AND type = $2`, ownerID, accountType).Scan(&id)
+	return id, err
+}
+
+// ownerID returns the owner of a user's ledger accounts, the user's ID in
+// canonical form. Users live in the user service's database, so the ID is
+// only checked for form.
+func ownerID(userID string) (string, error) {
+	id, err := strconv.ParseInt(strings.TrimSpace(userID), 10, 64)
+	if err != nil || id <= 0 {
+		return "", fmt.Errorf("%w: %q", domain.ErrInvalidUserID, userID)
+	}
+	return strconv.FormatInt(id, 10), nil
+}
@@ -3,12 +3,19 @@ package postgres
 import (
 	"database/sql"
 	"errors"
-	"fmt"
 	"log"
 	"muchway/payment_service/domain"
+	"muchway/payment_service/repository"
+	"muchway/pkg/limits"
+	"muchway/pkg/money"
 	"time"
+
+	"github.com/lib/pq"
 )
 
+// AuditTable records the calls refused for lack of permission.
+const AuditTable = "payment_audit_log"
+
 type PostgresPaymentRepository struct {
 	db *sql.DB
 }
@@ -17,11 +24,65 @@ func NewPostgresPaymentRepository(db *sql.DB) *PostgresPaymentRepository {
 	return &PostgresPaymentRepository{db: db}
 }
 
-func (r *PostgresPaymentRepository) Create(p *domain.Payment) error {
+func (r *PostgresPaymentRepository) Create(p *domain.Payment, check repository.LimitCheck) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if check != nil {
+		// Payments of the same user wait here until this one is stored
+		if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('payments:' || $1))`, p.UserID); err != nil {
+			return err
+		}
+		if err := check(func(userID, paymentType string, since time.Time) (money.Money, error) {
+			return sumPayments(tx, userID, paymentType, since)
+		}); err != nil {
+			return err
+		}
+	}
+
 	query := `INSERT INTO payments (id, user_id, type, amount, status, created_at, updated_at)
 			  VALUES ($1, $2, $3, $4, $5, $6, $7)`
-	_, err := r.db.Exec(query, p.ID, p.UserID, p.Type, p.Amount, p.Status, p.CreatedAt, p.UpdatedAt)
-	return err
+	if _, err := tx.Exec(query, p.ID, p.UserID, p.Type, p.Amount, p.Status, p.CreatedAt, p.UpdatedAt); err != nil {
+		return err
+	}
+
+	if p.IdempotencyKey != "" {
+		_, err := tx.Exec(`INSERT INTO payment_idempotency_keys (user_id, key, payment_id, created_at)
+			  VALUES ($1, $2, $3, $4)`, p.UserID, p.IdempotencyKey, p.ID, p.CreatedAt)
+		if isUniqueViolation(err) {
+			return domain.ErrDuplicateIdempotencyKey
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresPaymentRepository) FindByIdempotencyKey(userID, key string) (*domain.Payment, error) {
+	query := `SELECT p.id, p.user_id, p.type, p.amount, p.status, p.created_at, p.updated_at, k.key
+			  FROM payment_idempotency_keys k JOIN payments p ON p.id = k.payment_id
+			  WHERE k.user_id = $1 AND k.key = $2`
+	p := &domain.Payment{}
+	err := r.db.QueryRow(query, userID, key).Scan(&p.ID, &p.UserID, &p.Type, &p.Amount, &p.Status, &p.CreatedAt, &p.UpdatedAt, &p.IdempotencyKey)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (r *PostgresPaymentRepository) PurgeIdempotencyKeys(before time.Time) (int64, error) {
+	res, err := r.db.Exec(`DELETE FROM payment_idempotency_keys WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
 }
 
 func (r *PostgresPaymentRepository) GetByID(id string) (*domain.Payment, error) {
@@ -55,6 +116,44 @@ func (r *PostgresPaymentRepository) GetAll() ([]*domain.Payment, error) {
 	return payments, nil
 }
 
+func (r *PostgresPaymentRepository) SumPayments(userID, paymentType string, since time.Time) (money.Money, error) {
+	return sumPayments(r.db, userID, paymentType, since)
+}
+
+func sumPayments(q interface {
+	QueryRow(query string, args ...any) *sql.Row
+}, userID, paymentType string, since time.Time) (money.Money, error) {
+	query := `SELECT COALESCE(SUM(amount), 0) FROM payments
+			  WHERE user_id = $1 AND type = $2 AND status <> 'failed' AND created_at >= $3`
+	var total money.Money
+	err := q.QueryRow(query, userID, paymentType, since).Scan(&total)
+	return total, err
+}
+
+func (r *PostgresPaymentRepository) SaveExclusion(userID string, e limits.Exclusion) error {
+	query := `INSERT INTO payment_exclusions (user_id, since, until, updated_at) VALUES ($1, $2, $3, now())
+			  ON CONFLICT (user_id) DO UPDATE SET since = EXCLUDED.since, until = EXCLUDED.until, updated_at = now()
+			  WHERE payment_exclusions.until IS NOT NULL AND (EXCLUDED.until IS NULL OR EXCLUDED.until > payment_exclusions.until)`
+	_, err := r.db.Exec(query, userID, e.Since, e.Until)
+	return err
+}
+
+func (r *PostgresPaymentRepository) GetExclusion(userID string) (*limits.Exclusion, error) {
+	var e limits.Exclusion
+	var until sql.NullTime
+	err := r.db.QueryRow(`SELECT since, until FROM payment_exclusions WHERE user_id = $1`, userID).Scan(&e.Since, &until)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if until.Valid {
+		e.Until = &until.Time
+	}
+	return &e, nil
+}
+
 func (r *PostgresPaymentRepository) DeleteByID(id string) error {
 	query := `DELETE FROM payments WHERE id = $1`
 	_, err := r.db.Exec(query, id)
@@ -67,74 +166,120 @@ func (r *PostgresPaymentRepository) UpdateStatus(id string, status string) error
 	return err
 }
 
-func (r *PostgresPaymentRepository) UpdateUserBalance(userID string, amount float64, operation string) error {
+func (r *PostgresPaymentRepository) CreateReservation(res *domain.Reservation) error {
 	tx, err := r.db.Begin()
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		}
-	}()
+	defer tx.Rollback()
 
-	log.Printf("Updating balance for user %s: %s %.2f", userID, operation, amount)
+	existing, err := scanReservation(tx.QueryRow(
+		`SELECT id, user_id, amount, reference, status, created_at, updated_at FROM reservations WHERE reference = $1`,
+		res.Reference))
+	if err == nil {
+		*res = *existing
+		return nil
+	}
+	if err != domain.ErrReservationNotFound {
+		return err
+	}
 
-	var userIDInt int64
-	var currentBalance float64
+	userID, err := ownerID(res.UserID)
+	if err != nil {
+		return err
+	}
+	entry := domain.Transfer(domain.EntryKindStakeReserve, "reservation:"+res.ID,
+		userID, domain.AccountTypeWallet, userID, domain.AccountTypePending, res.Amount)
+	if err := post(tx, entry); err != nil {
+		return err
+	}
 
-	err = tx.QueryRow("SELECT id, balance FROM users WHERE id = $1", userID).Scan(&userIDInt, &currentBalance)
+	_, err = tx.Exec(`INSERT INTO reservations (id, user_id, amount, reference, status, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		res.ID, userID, res.Amount, res.Reference, res.Status, res.CreatedAt, res.UpdatedAt)
 	if err != nil {
-		log.Printf("Error querying by ID: %v", err)
-		if err == sql.ErrNoRows {
-			log.Printf("User ID %s not found, trying as username", userID)
-			err = tx.QueryRow("SELECT id, balance FROM users WHERE username = $1", userID).Scan(&userIDInt, &currentBalance)
-			if err != nil {
-				log.Printf("Error querying by username: %v", err)
-				if err == sql.ErrNoRows {
-					return errors.New("user not found")
-				}
-				return err
-			}
-		} else {
-			return err
-		}
+		return err
 	}
 
-	log.Printf("Found user %d with current balance: %.2f", userIDInt, currentBalance)
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	log.Printf("Reserved %s for user %s (reference %s)", res.Amount, userID, res.Reference)
+	return nil
+}
 
-	var newBalance float64
-	switch operation {
-	case "deposit":
-		newBalance = currentBalance + amount
-		log.Printf("Deposit operation: %.2f + %.2f = %.2f", currentBalance, amount, newBalance)
-	case "withdraw":
-		if currentBalance < amount {
-			log.Printf("Insufficient balance: %.2f < %.2f", currentBalance, amount)
-			return errors.New("insufficient balance")
-		}
-		newBalance = currentBalance - amount
-		log.Printf("Withdraw operation: %.2f - %.2f = %.2f", currentBalance, amount, newBalance)
-	default:
-		return fmt.Errorf("unsupported operation: %s", operation)
+func (r *PostgresPaymentRepository) GetReservation(id string) (*domain.Reservation, error) {
+	return scanReservation(r.db.QueryRow(
+		`SELECT id, user_id, amount, reference, status, created_at, updated_at FROM reservations WHERE id = $1`, id))
+}
+
+func (r *PostgresPaymentRepository) CaptureReservation(id string) (*domain.Reservation, error) {
+	return r.closeReservation(id, domain.ReservationStatusCaptured)
+}
+
+func (r *PostgresPaymentRepository) ReleaseReservation(id string) (*domain.Reservation, error) {
+	return r.closeReservation(id, domain.ReservationStatusReleased)
+}
+
+// closeReservation moves a reserved hold to its final status. A capture moves
+// the amount from pending to the house, a release moves it back to the wallet.
+// Closing it again with the same status is a no-op.
+func (r *PostgresPaymentRepository) closeReservation(id, status string) (*domain.Reservation, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
 	}
+	defer tx.Rollback()
 
-	result, err := tx.Exec("UPDATE users SET balance = $1 WHERE id = $2", newBalance, userIDInt)
+	res, err := scanReservation(tx.QueryRow(
+		`SELECT id, user_id, amount, reference, status, created_at, updated_at FROM reservations WHERE id = $1 FOR UPDATE`, id))
 	if err != nil {
-		log.Printf("Error updating balance: %v", err)
-		return err
+		return nil, err
+	}
+	if res.Status == status {
+		return res, nil
+	}
+	if res.Status != domain.ReservationStatusReserved {
+		return nil, domain.ErrReservationClosed
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-	log.Printf("Updated balance for user %d: %.2f, rows affected: %d", userIDInt, newBalance, rowsAffected)
+	reference := "reservation:" + res.ID
+	entry := domain.Transfer(domain.EntryKindStakeCapture, reference,
+		res.UserID, domain.AccountTypePending, "", domain.AccountTypeHouse, res.Amount)
+	if status == domain.ReservationStatusReleased {
+		entry = domain.Transfer(domain.EntryKindStakeRelease, reference,
+			res.UserID, domain.AccountTypePending, res.UserID, domain.AccountTypeWallet, res.Amount)
+	}
+	if err := post(tx, entry); err != nil {
+		return nil, err
+	}
 
-	err = tx.Commit()
-	if err != nil {
-		log.Printf("Error committing transaction: %v", err)
-		return err
+	res.Status = status
+	res.UpdatedAt = time.Now()
+	if _, err := tx.Exec(`UPDATE reservations SET status = $1, updated_at = $2 WHERE id = $3`, res.Status, res.UpdatedAt, res.ID); err != nil {
+		return nil, err
 	}
 
-	log.Printf("Successfully updated balance for user %d: %s %.2f, new balance: %.2f",
-		userIDInt, operation, amount, newBalance)
-	return nil
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	log.Printf("Reservation %s %s", res.ID, res.Status)
+	return res, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+func scanReservation(row *sql.Row) (*domain.Reservation, error) {
+	res := &domain.Reservation{}
+	err := row.Scan(&res.ID, &res.UserID, &res.Amount, &res.Reference, &res.Status, &res.CreatedAt, &res.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrReservationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
 }
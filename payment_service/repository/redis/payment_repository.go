@@ -6,6 +6,8 @@ import (
 	"log"
 	"muchway/payment_service/domain"
 	"muchway/payment_service/repository"
+	"muchway/pkg/limits"
+	"muchway/pkg/money"
 	"time"
 )
 
@@ -25,11 +27,11 @@ func NewRedisPaymentRepository(repo repository.PaymentRepository) *RedisPaymentR
 	}
 }
 
-func (r *RedisPaymentRepository) Create(payment *domain.Payment) error {
+func (r *RedisPaymentRepository) Create(payment *domain.Payment, check repository.LimitCheck) error {
 	log.Printf("Redis repository: Creating payment with ID %s", payment.ID)
 
 	// First, create the payment in the underlying repository
-	err := r.repo.Create(payment)
+	err := r.repo.Create(payment, check)
 	if err != nil {
 		log.Printf("Redis repository: Error creating payment in underlying repo: %v", err)
 		return err
@@ -151,13 +153,66 @@ func (r *RedisPaymentRepository) UpdateStatus(id string, status string) error {
 	return nil
 }
 
-func (r *RedisPaymentRepository) UpdateUserBalance(userID string, amount float64, operation string) error {
-	err := r.repo.UpdateUserBalance(userID, amount, operation)
-	if err != nil {
-		return err
-	}
+func (r *RedisPaymentRepository) FindByIdempotencyKey(userID, key string) (*domain.Payment, error) {
+	return r.repo.FindByIdempotencyKey(userID, key)
+}
 
-	return nil
+func (r *RedisPaymentRepository) SumPayments(userID, paymentType string, since time.Time) (money.Money, error) {
+	return r.repo.SumPayments(userID, paymentType, since)
+}
+
+func (r *RedisPaymentRepository) SaveExclusion(userID string, e limits.Exclusion) error {
+	return r.repo.SaveExclusion(userID, e)
+}
+
+func (r *RedisPaymentRepository) GetExclusion(userID string) (*limits.Exclusion, error) {
+	return r.repo.GetExclusion(userID)
+}
+
+func (r *RedisPaymentRepository) PurgeIdempotencyKeys(before time.Time) (int64, error) {
+	return r.repo.PurgeIdempotencyKeys(before)
+}
+
+func (r *RedisPaymentRepository) PostPayment(payment *domain.Payment) (bool, error) {
+	posted, err := r.repo.PostPayment(payment)
+
+	// The status changes whether or not the posting succeeds
+	RedisClient.Del(Ctx, fmt.Sprintf("%s%s", paymentKeyPrefix, payment.ID))
+	r.invalidateAllPaymentsCache()
+
+	return posted, err
+}
+
+func (r *RedisPaymentRepository) GetBalance(userID string) (*domain.Balance, error) {
+	return r.repo.GetBalance(userID)
+}
+
+func (r *RedisPaymentRepository) ListLedgerEntries(userID string, limit, offset int) ([]*domain.JournalEntry, error) {
+	return r.repo.ListLedgerEntries(userID, limit, offset)
+}
+
+func (r *RedisPaymentRepository) ImportOpeningBalances(balances map[string]money.Money) (int, error) {
+	return r.repo.ImportOpeningBalances(balances)
+}
+
+func (r *RedisPaymentRepository) OpeningBalancesImported() (bool, error) {
+	return r.repo.OpeningBalancesImported()
+}
+
+func (r *RedisPaymentRepository) CreateReservation(res *domain.Reservation) error {
+	return r.repo.CreateReservation(res)
+}
+
+func (r *RedisPaymentRepository) GetReservation(id string) (*domain.Reservation, error) {
+	return r.repo.GetReservation(id)
+}
+
+func (r *RedisPaymentRepository) CaptureReservation(id string) (*domain.Reservation, error) {
+	return r.repo.CaptureReservation(id)
+}
+
+func (r *RedisPaymentRepository) ReleaseReservation(id string) (*domain.Reservation, error) {
+	return r.repo.ReleaseReservation(id)
 }
 
 func (r *RedisPaymentRepository) invalidateAllPaymentsCache() {
@@ -2,65 +2,129 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
 	"log"
 	"muchway/payment_service/client"
 	"muchway/payment_service/domain"
 	"muchway/payment_service/email"
 	"muchway/payment_service/repository"
+	"muchway/pkg/lifecycle"
+	"muchway/pkg/limits"
+	"muchway/pkg/money"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type Config struct {
+	// KYCWithdrawalThreshold is the withdrawal amount above which the user's
+	// identity must be verified.
+	KYCWithdrawalThreshold money.Money
+	// WithdrawalMFAThreshold is the withdrawal amount above which the
+	// caller's StepUp is asked for a recent second factor.
+	WithdrawalMFAThreshold money.Money
+	// Background tracks the emails and balance reports sent after a
+	// payment, so that shutdown waits for them.
+	Background *lifecycle.Group
+}
+
+// StepUp refuses a withdrawal unless the caller proved a second factor
+// recently enough. It is asked only for withdrawals that take the user's
+// withdrawals over the last domain.WithdrawalWindow above
+// Config.WithdrawalMFAThreshold; a nil StepUp asks for nothing.
+type StepUp func() error
+
 type PaymentUsecase struct {
 	repo         repository.PaymentRepository
 	userClient   *client.UserClient
 	emailService email.EmailService
+	cfg          Config
+	// imported is set once the opening balances are known to be imported
+	imported atomic.Bool
 }
 
-func NewPaymentUsecase(r repository.PaymentRepository, userClient *client.UserClient, emailService email.EmailService) *PaymentUsecase {
+func NewPaymentUsecase(r repository.PaymentRepository, userClient *client.UserClient, emailService email.EmailService, cfg Config) *PaymentUsecase {
 	return &PaymentUsecase{
 		repo:         r,
 		userClient:   userClient,
 		emailService: emailService,
+		cfg:          cfg,
 	}
 }
 
-func (uc *PaymentUsecase) CreatePayment(p *domain.Payment) error {
-	if p.Type != "deposit" && p.Type != "withdraw" {
-		return errors.New("invalid payment type: must be 'deposit' or 'withdraw'")
+func (uc *PaymentUsecase) CreatePayment(p *domain.Payment, stepUp StepUp) error {
+	if p.Type != "deposit" && p.Type != "withdraw" && p.Type != "payout" {
+		return errors.New("invalid payment type: must be 'deposit', 'withdraw' or 'payout'")
 	}
 
-	if p.Amount <= 0 {
+	if !p.Amount.IsPositive() {
 		return errors.New("amount must be greater than zero")
 	}
+	if err := checkCurrency(p.Amount); err != nil {
+		return err
+	}
+
+	if p.IdempotencyKey != "" {
+		existing, err := uc.repo.FindByIdempotencyKey(p.UserID, p.IdempotencyKey)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return uc.replayPayment(p, existing)
+		}
+	}
+
+	// Withdrawals and payouts go ahead whatever the user's limits
+	var check repository.LimitCheck
+	switch p.Type {
+	case "deposit":
+		var err error
+		if check, err = uc.checkDeposit(p); err != nil {
+			return err
+		}
+	case "withdraw":
+		var err error
+		if check, err = uc.checkWithdrawal(p, stepUp); err != nil {
+			return err
+		}
+	}
 
 	p.ID = uuid.New().String()
 	p.CreatedAt = time.Now()
 	p.UpdatedAt = time.Now()
 	p.Status = "pending"
 
-	err := uc.repo.Create(p)
-	if err != nil {
-		return err
+	err := uc.repo.Create(p, check)
+	if errors.Is(err, domain.ErrDuplicateIdempotencyKey) {
+		// A concurrent request with the same key got there first
+		existing, findErr := uc.repo.FindByIdempotencyKey(p.UserID, p.IdempotencyKey)
+		if findErr != nil || existing == nil {
+			return err
+		}
+		return uc.replayPayment(p, existing)
 	}
-
-	err = uc.repo.UpdateUserBalance(p.UserID, p.Amount, p.Type)
 	if err != nil {
-		p.Status = "failed"
-		uc.repo.UpdateStatus(p.ID, "failed")
 		return err
 	}
 
-	p.Status = "completed"
-	err = uc.repo.UpdateStatus(p.ID, "completed")
-	if err != nil {
+	return uc.completePayment(p)
+}
+
+// completePayment posts a pending payment, then reports the new balance and
+// emails the user. A failed posting leaves the payment failed and frees its
+// idempotency key.
+func (uc *PaymentUsecase) completePayment(p *domain.Payment) error {
+	posted, err := uc.repo.PostPayment(p)
+	if err != nil || !posted {
 		return err
 	}
 
+	uc.syncBalance(p.UserID)
+
 	// Send email notification
 	if uc.userClient != nil && uc.emailService != nil {
-		go func() {
+		uc.cfg.Background.Go(func() {
 			// Get user email
 			userEmail, err := uc.userClient.GetUserEmail(p.UserID)
 			if err != nil {
@@ -75,12 +139,166 @@ func (uc *PaymentUsecase) CreatePayment(p *domain.Payment) error {
 			} else {
 				log.Printf("Payment confirmation email sent to: %s", userEmail)
 			}
-		}()
+		})
+	}
+
+	return nil
+}
+
+// checkDeposit refuses a deposit from a self-excluded user. It returns the
+// check of the user's deposit limits, which runs as the deposit is stored.
+func (uc *PaymentUsecase) checkDeposit(p *domain.Payment) (repository.LimitCheck, error) {
+	exclusion, err := uc.repo.GetExclusion(p.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if exclusion != nil && exclusion.Active(time.Now()) {
+		return nil, exclusion.Err()
+	}
+
+	if uc.userClient == nil {
+		return nil, nil
+	}
+	ls, err := uc.userClient.GetLimits(p.UserID)
+	if err != nil {
+		return nil, err
 	}
+	return func(sum repository.SumFunc) error {
+		return checkDepositLimits(p, limits.Of(ls, limits.Deposit), sum)
+	}, nil
+}
 
+// checkDepositLimits refuses a deposit that would take the user over one of
+// their deposit limits.
+func checkDepositLimits(p *domain.Payment, ls []limits.Limit, sum repository.SumFunc) error {
+	now := time.Now()
+	for _, l := range ls {
+		deposited, err := sum(p.UserID, "deposit", l.Period.Since(now))
+		if err != nil {
+			return err
+		}
+		if err := l.Check(deposited, p.Amount); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// checkWithdrawal returns the checks a withdrawal must pass as it is stored,
+// when the user's withdrawals are counted with their other payments locked,
+// so that concurrent withdrawals cannot pass a threshold together. These
+// refuse a withdrawal that takes the user's withdrawals over the last
+// domain.WithdrawalWindow above the KYC threshold, unless the user's identity
+// is verified, or above the step-up threshold, unless stepUp allows it.
+// Without user_service identity verification cannot be checked, so
+// withdrawals above the KYC threshold are refused.
+func (uc *PaymentUsecase) checkWithdrawal(p *domain.Payment, stepUp StepUp) (repository.LimitCheck, error) {
+	verified := false
+	if err := uc.checkKYCThreshold(p, uc.repo.SumPayments); errors.Is(err, domain.ErrKYCRequired) {
+		if verified, err = uc.kycVerified(p.UserID); err != nil {
+			return nil, err
+		}
+		if !verified {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	return func(sum repository.SumFunc) error {
+		if !verified {
+			if err := uc.checkKYCThreshold(p, sum); err != nil {
+				return err
+			}
+		}
+		return uc.checkStepUp(p, sum, stepUp)
+	}, nil
+}
+
+func (uc *PaymentUsecase) kycVerified(userID string) (bool, error) {
+	if uc.userClient == nil {
+		return false, errors.New("cannot check identity verification: user service unavailable")
+	}
+	return uc.userClient.KYCVerified(userID)
+}
+
+// checkStepUp asks stepUp for a withdrawal that takes the user's recent
+// withdrawals above the step-up threshold.
+func (uc *PaymentUsecase) checkStepUp(p *domain.Payment, sum repository.SumFunc, stepUp StepUp) error {
+	if stepUp == nil {
+		return nil
+	}
+	above, err := aboveThreshold(p, sum, uc.cfg.WithdrawalMFAThreshold)
+	if err != nil || !above {
+		return err
+	}
+	return stepUp()
+}
+
+// checkKYCThreshold returns ErrKYCRequired when the withdrawal takes the
+// user's recent withdrawals above the KYC threshold.
+func (uc *PaymentUsecase) checkKYCThreshold(p *domain.Payment, sum repository.SumFunc) error {
+	above, err := aboveThreshold(p, sum, uc.cfg.KYCWithdrawalThreshold)
+	if err != nil || !above {
+		return err
+	}
+	return fmt.Errorf("%w: withdrawals above %s in %s need a verified identity",
+		domain.ErrKYCRequired, uc.cfg.KYCWithdrawalThreshold.Format(), domain.WithdrawalWindow)
+}
+
+// aboveThreshold reports whether the withdrawal takes what the user withdrew
+// over the last domain.WithdrawalWindow above threshold.
+func aboveThreshold(p *domain.Payment, sum repository.SumFunc, threshold money.Money) (bool, error) {
+	withdrawn, err := sum(p.UserID, "withdraw", time.Now().Add(-domain.WithdrawalWindow))
+	if err != nil {
+		return false, err
+	}
+	// The thresholds are in one currency; other currencies are always above
+	total, err := withdrawn.Add(p.Amount)
+	if err != nil {
+		return true, nil
+	}
+	cmp, err := total.Cmp(threshold)
+	return err != nil || cmp > 0, nil
+}
+
+// RecordExclusion stores a self-exclusion announced by user_service.
+func (uc *PaymentUsecase) RecordExclusion(userID string, e limits.Exclusion) error {
+	return uc.repo.SaveExclusion(userID, e)
+}
+
+// checkCurrency refuses an amount the ledger cannot hold.
+func checkCurrency(amount money.Money) error {
+	if amount.Currency != domain.WalletCurrency {
+		return fmt.Errorf("%w %q: amounts must be in %s", domain.ErrUnsupportedCurrency, amount.Currency, domain.WalletCurrency)
+	}
+	return nil
+}
+
+// replayPayment answers a repeated request with the payment created by the
+// first one, provided both asked for the same thing. A payment still pending,
+// because the first request stopped before posting it, is posted now.
+func (uc *PaymentUsecase) replayPayment(p, existing *domain.Payment) error {
+	if existing.Type != p.Type || existing.Amount != p.Amount {
+		return domain.ErrIdempotencyKeyReused
+	}
+	if existing.Status == "pending" {
+		log.Printf("Resuming pending payment %s for idempotency key %s", existing.ID, p.IdempotencyKey)
+		if err := uc.completePayment(existing); err != nil {
+			return err
+		}
+	} else {
+		log.Printf("Replaying payment %s for idempotency key %s", existing.ID, p.IdempotencyKey)
+	}
+	*p = *existing
+	return nil
+}
+
+// PurgeIdempotencyKeys forgets idempotency keys older than the retention
+// window; a key may be reused once it has been purged.
+func (uc *PaymentUsecase) PurgeIdempotencyKeys(retention time.Duration) (int64, error) {
+	return uc.repo.PurgeIdempotencyKeys(time.Now().Add(-retention))
+}
+
 func (uc *PaymentUsecase) GetPaymentByID(id string) (*domain.Payment, error) {
 	return uc.repo.GetByID(id)
 }
@@ -93,17 +311,149 @@ func (uc *PaymentUsecase) DeletePaymentByID(id string) error {
 	return uc.repo.DeleteByID(id)
 }
 
-func (uc *PaymentUsecase) ProcessPayment(userID string, amount float64, paymentType string) (*domain.Payment, error) {
+// ProcessPayment creates and posts a payment. stepUp is asked for large
+// withdrawals, as described for StepUp, and may be nil.
+func (uc *PaymentUsecase) ProcessPayment(userID string, amount money.Money, paymentType, idempotencyKey string, stepUp StepUp) (*domain.Payment, error) {
 	payment := &domain.Payment{
-		UserID: userID,
-		Type:   paymentType,
-		Amount: amount,
+		UserID:         userID,
+		Type:           paymentType,
+		Amount:         amount,
+		IdempotencyKey: idempotencyKey,
 	}
 
-	err := uc.CreatePayment(payment)
+	err := uc.CreatePayment(payment, stepUp)
 	if err != nil {
 		return nil, err
 	}
 
 	return payment, nil
 }
+
+func (uc *PaymentUsecase) GetBalance(userID string) (*domain.Balance, error) {
+	return uc.repo.GetBalance(userID)
+}
+
+func (uc *PaymentUsecase) ListLedgerEntries(userID string, limit, offset int) ([]*domain.JournalEntry, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return uc.repo.ListLedgerEntries(userID, limit, offset)
+}
+
+func (uc *PaymentUsecase) ReserveFunds(userID string, amount money.Money, reference string) (*domain.Reservation, error) {
+	if !amount.IsPositive() {
+		return nil, errors.New("amount must be greater than zero")
+	}
+	if err := checkCurrency(amount); err != nil {
+		return nil, err
+	}
+	if reference == "" {
+		return nil, errors.New("reference is required")
+	}
+
+	now := time.Now()
+	res := &domain.Reservation{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Amount:    amount,
+		Reference: reference,
+		Status:    domain.ReservationStatusReserved,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.repo.CreateReservation(res); err != nil {
+		return nil, err
+	}
+	uc.syncBalance(res.UserID)
+	return res, nil
+}
+
+// CaptureFunds moves the stake from pending to the house; the wallet does
+// not change, so there is no balance to report.
+func (uc *PaymentUsecase) CaptureFunds(reservationID string) (*domain.Reservation, error) {
+	return uc.repo.CaptureReservation(reservationID)
+}
+
+func (uc *PaymentUsecase) ReleaseFunds(reservationID string) (*domain.Reservation, error) {
+	res, err := uc.repo.ReleaseReservation(reservationID)
+	if err != nil {
+		return nil, err
+	}
+	uc.syncBalance(res.UserID)
+	return res, nil
+}
+
+// syncBalance reports the user's wallet balance to the user service, which
+// shows it with the profile. The ledger stays authoritative: a failed report
+// is only logged, and the next posting to the wallet corrects the copy.
+// Nothing is reported before the opening balances have been imported, as the
+// ledger would not yet hold the balances the user service shows.
+func (uc *PaymentUsecase) syncBalance(userID string) {
+	if uc.userClient == nil {
+		return
+	}
+	uc.cfg.Background.Go(func() {
+		imported, err := uc.openingBalancesImported()
+		if err != nil {
+			log.Printf("Failed to check whether opening balances were imported: %v", err)
+			return
+		}
+		if !imported {
+			log.Printf("Not reporting the balance of user %s: opening balances have not been imported", userID)
+			return
+		}
+		uc.reportBalance(userID)
+	})
+}
+
+func (uc *PaymentUsecase) reportBalance(userID string) {
+	b, err := uc.repo.GetBalance(userID)
+	if err != nil {
+		log.Printf("Failed to read the balance of user %s: %v", userID, err)
+		return
+	}
+	if b.WalletVersion == 0 {
+		return
+	}
+	if err := uc.userClient.SyncBalance(b.UserID, b.Wallet, b.WalletVersion); err != nil {
+		log.Printf("Failed to report the balance of user %s: %v", userID, err)
+	}
+}
+
+func (uc *PaymentUsecase) openingBalancesImported() (bool, error) {
+	if uc.imported.Load() {
+		return true, nil
+	}
+	imported, err := uc.repo.OpeningBalancesImported()
+	if imported {
+		uc.imported.Store(true)
+	}
+	return imported, err
+}
+
+// ImportOpeningBalances carries the balances the user service holds over to
+// the ledger as opening balance entries. It runs once, when the payment
+// service takes over the balances, and returns the number of users with a
+// balance. Payments posted before the import add to the opening balances, so
+// every imported balance is reported back afterwards.
+func (uc *PaymentUsecase) ImportOpeningBalances() (int, error) {
+	if uc.userClient == nil {
+		return 0, errors.New("cannot import opening balances: user service unavailable")
+	}
+	balances, err := uc.userClient.GetBalances()
+	if err != nil {
+		return 0, err
+	}
+	n, err := uc.repo.ImportOpeningBalances(balances)
+	if err != nil {
+		return 0, err
+	}
+	uc.imported.Store(true)
+	for userID := range balances {
+		uc.reportBalance(userID)
+	}
+	return n, nil
+}
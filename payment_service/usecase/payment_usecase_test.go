@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"muchway/payment_service/domain"
+	"muchway/payment_service/repository"
+	"muchway/pkg/limits"
+	"muchway/pkg/money"
+)
+
+// fakeRepo keeps payments and wallet balances in memory. Methods the tests
+// do not need are left to the embedded nil interface.
+type fakeRepo struct {
+	repository.PaymentRepository
+
+	mu       sync.Mutex
+	payments map[string]*domain.Payment
+	keys     map[string]string
+	wallets  map[string]money.Money
+	postings int
+}
+
+func newFakeRepo() *fakeRepo {
+	return &fakeRepo{payments: map[string]*domain.Payment{}, keys: map[string]string{}, wallets: map[string]money.Money{}}
+}
+
+func (r *fakeRepo) Create(p *domain.Payment, check repository.LimitCheck) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if check != nil {
+		if err := check(r.sumPayments); err != nil {
+			return err
+		}
+	}
+	if p.IdempotencyKey != "" {
+		if _, ok := r.keys[p.UserID+"/"+p.IdempotencyKey]; ok {
+			return domain.ErrDuplicateIdempotencyKey
+		}
+		r.keys[p.UserID+"/"+p.IdempotencyKey] = p.ID
+	}
+	c := *p
+	r.payments[p.ID] = &c
+	return nil
+}
+
+func (r *fakeRepo) FindByIdempotencyKey(userID, key string) (*domain.Payment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id, ok := r.keys[userID+"/"+key]
+	if !ok {
+		return nil, nil
+	}
+	c := *r.payments[id]
+	return &c, nil
+}
+
+func (r *fakeRepo) GetExclusion(string) (*limits.Exclusion, error) { return nil, nil }
+
+func (r *fakeRepo) SumPayments(userID, paymentType string, since time.Time) (money.Money, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sumPayments(userID, paymentType, since)
+}
+
+func (r *fakeRepo) sumPayments(userID, paymentType string, since time.Time) (money.Money, error) {
+	total := money.FromMinor(0, "")
+	for _, p := range r.payments {
+		if p.UserID == userID && p.Type == paymentType && p.Status != "failed" && !p.CreatedAt.Before(since) {
+			total.Minor += p.Amount.Minor
+		}
+	}
+	return total, nil
+}
+
+func (r *fakeRepo) PostPayment(p *domain.Payment) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := r.payments[p.ID]
+	if p.Status = stored.Status; p.Status != "pending" {
+		return false, nil
+	}
+	wallet := money.FromMinor(r.wallets[p.UserID].Minor, "")
+	switch p.Type {
+	case "deposit", "payout":
+		wallet.Minor += p.Amount.Minor
+	case "withdraw":
+		wallet.Minor -= p.Amount.Minor
+	}
+	if wallet.Minor < 0 {
+		stored.Status, p.Status = "failed", "failed"
+		delete(r.keys, p.UserID+"/"+p.IdempotencyKey)
+		return false, domain.ErrInsufficientBalance
+	}
+	r.wallets[p.UserID] = wallet
+	r.postings++
+	stored.Status, p.Status = "completed", "completed"
+	return true, nil
+}
+
+func TestRetryResumesPaymentLeftPending(t *testing.T) {
+	repo := newFakeRepo()
+	uc := NewPaymentUsecase(repo, nil, nil, Config{})
+
+	// The first request stored the payment and its key, then stopped
+	// before posting it
+	first := &domain.Payment{ID: "p1", UserID: "42", Type: "deposit", Amount: money.MustParse("25.00"),
+		Status: "pending", CreatedAt: time.Now(), IdempotencyKey: "k1"}
+	if err := repo.Create(first, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		retry := &domain.Payment{UserID: "42", Type: "deposit", Amount: money.MustParse("25.00"), IdempotencyKey: "k1"}
+		if err := uc.CreatePayment(retry, nil); err != nil {
+			t.Fatalf("retry %d: CreatePayment = %v", i+1, err)
+		}
+		if retry.ID != "p1" || retry.Status != "completed" {
+			t.Errorf("retry %d: got payment %s %s, want p1 completed", i+1, retry.ID, retry.Status)
+		}
+	}
+	if repo.postings != 1 {
+		t.Errorf("posted %d time(s), want once", repo.postings)
+	}
+	if got := repo.wallets["42"]; got != money.MustParse("25.00") {
+		t.Errorf("wallet = %s, want 25.00", got)
+	}
+}
+
+func TestFailedPostingFreesTheKey(t *testing.T) {
+	repo := newFakeRepo()
+	uc := NewPaymentUsecase(repo, nil, nil, Config{KYCWithdrawalThreshold: money.MustParse("1000.00")})
+
+	withdrawal := &domain.Payment{UserID: "42", Type: "withdraw", Amount: money.MustParse("10.00"), IdempotencyKey: "k1"}
+	if err := uc.CreatePayment(withdrawal, nil); !errors.Is(err, domain.ErrInsufficientBalance) {
+		t.Fatalf("withdrawal from an empty wallet = %v, want ErrInsufficientBalance", err)
+	}
+	if withdrawal.Status != "failed" {
+		t.Errorf("status = %s, want failed", withdrawal.Status)
+	}
+
+	repo.wallets["42"] = money.MustParse("10.00")
+	retry := &domain.Payment{UserID: "42", Type: "withdraw", Amount: money.MustParse("10.00"), IdempotencyKey: "k1"}
+	if err := uc.CreatePayment(retry, nil); err != nil {
+		t.Fatalf("retry = %v", err)
+	}
+	if retry.ID == withdrawal.ID || retry.Status != "completed" {
+		t.Errorf("retry gave payment %s %s, want a new completed payment", retry.ID, retry.Status)
+	}
+}
+
+func TestDepositLimitsCountDepositsStoredFirst(t *testing.T) {
+	repo := newFakeRepo()
+	daily := []limits.Limit{{Kind: limits.Deposit, Period: limits.Day, Amount: money.MustParse("50.00")}}
+
+	// Both deposits fit under the limit on their own, as a check made
+	// before either was stored would find
+	for i, want := range []error{nil, limits.ErrLimitExceeded} {
+		p := &domain.Payment{ID: fmt.Sprint("p", i), UserID: "42", Type: "deposit", Amount: money.MustParse("30.00"),
+			Status: "pending", CreatedAt: time.Now()}
+		err := repo.Create(p, func(sum repository.SumFunc) error { return checkDepositLimits(p, daily, sum) })
+		if !errors.Is(err, want) {
+			t.Errorf("deposit %d: Create = %v, want %v", i+1, err, want)
+		}
+	}
+}
+
+func TestKYCThresholdCountsRecentWithdrawals(t *testing.T) {
+	cases := []struct {
+		name     string
+		earlier  []string
+		amount   string
+		required bool
+	}{
+		{"first withdrawal under the threshold", nil, "600.00", false},
+		{"first withdrawal over the threshold", nil, "1000.01", true},
+		{"together under the threshold", []string{"300.00", "300.00"}, "400.00", false},
+		{"together over the threshold", []string{"300.00", "300.00"}, "400.01", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			repo := newFakeRepo()
+			uc := NewPaymentUsecase(repo, nil, nil, Config{KYCWithdrawalThreshold: money.MustParse("1000.00")})
+			for i, amount := range c.earlier {
+				repo.Create(&domain.Payment{ID: fmt.Sprint("p", i), UserID: "42", Type: "withdraw", Amount: money.MustParse(amount),
+					Status: "completed", CreatedAt: time.Now().Add(-time.Hour)}, nil)
+			}
+			// A withdrawal from before the window does not count
+			repo.Create(&domain.Payment{ID: "old", UserID: "42", Type: "withdraw", Amount: money.MustParse("900.00"),
+				Status: "completed", CreatedAt: time.Now().Add(-domain.WithdrawalWindow - time.Minute)}, nil)
+
+			p := &domain.Payment{UserID: "42", Type: "withdraw", Amount: money.MustParse(c.amount)}
+			err := uc.checkKYCThreshold(p, repo.SumPayments)
+			if got := errors.Is(err, domain.ErrKYCRequired); got != c.required {
+				t.Errorf("checkKYCThreshold = %v, want KYC required %v", err, c.required)
+			}
+		})
+	}
+}
+
+func TestKYCThresholdCountsWithdrawalsStoredFirst(t *testing.T) {
+	repo := newFakeRepo()
+	uc := NewPaymentUsecase(repo, nil, nil, Config{KYCWithdrawalThreshold: money.MustParse("1000.00")})
+
+	// Both withdrawals are checked before either is stored
+	var payments []*domain.Payment
+	var checks []repository.LimitCheck
+	for i := 0; i < 2; i++ {
+		p := &domain.Payment{ID: fmt.Sprint("p", i), UserID: "42", Type: "withdraw", Amount: money.MustParse("600.00"),
+			Status: "pending", CreatedAt: time.Now()}
+		check, err := uc.checkWithdrawal(p, nil)
+		if err != nil || check == nil {
+			t.Fatalf("withdrawal %d: checkWithdrawal = %v, want a check to run as it is stored", i+1, err)
+		}
+		payments, checks = append(payments, p), append(checks, check)
+	}
+	for i, want := range []error{nil, domain.ErrKYCRequired} {
+		if err := repo.Create(payments[i], checks[i]); !errors.Is(err, want) {
+			t.Errorf("withdrawal %d: Create = %v, want %v", i+1, err, want)
+		}
+	}
+}
+
+func TestStepUpCountsWithdrawalsStoredFirst(t *testing.T) {
+	repo := newFakeRepo()
+	uc := NewPaymentUsecase(repo, nil, nil, Config{
+		KYCWithdrawalThreshold: money.MustParse("1000.00"),
+		WithdrawalMFAThreshold: money.MustParse("100.00"),
+	})
+	errStepUp := errors.New("second factor required")
+	stepUp := func() error { return errStepUp }
+
+	// Both withdrawals are checked before either is stored
+	var payments []*domain.Payment
+	var checks []repository.LimitCheck
+	for i := 0; i < 2; i++ {
+		p := &domain.Payment{ID: fmt.Sprint("p", i), UserID: "42", Type: "withdraw", Amount: money.MustParse("60.00"),
+			Status: "pending", CreatedAt: time.Now()}
+		check, err := uc.checkWithdrawal(p, stepUp)
+		if err != nil {
+			t.Fatalf("withdrawal %d: checkWithdrawal = %v", i+1, err)
+		}
+		payments, checks = append(payments, p), append(checks, check)
+	}
+	for i, want := range []error{nil, errStepUp} {
+		if err := repo.Create(payments[i], checks[i]); !errors.Is(err, want) {
+			t.Errorf("withdrawal %d: Create = %v, want %v", i+1, err, want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/peer"
+)
+
+// Denial is an entry of the audit trail: a call refused for lack of
+// permission.
+type Denial struct {
+	Time    time.Time
+	Method  string
+	Subject string
+	Role    string
+	Reason  string
+	Peer    string
+}
+
+// NewDenial describes a denied call to method by the caller in ctx.
+func NewDenial(ctx context.Context, method, reason string) Denial {
+	d := Denial{Time: time.Now(), Method: method, Reason: reason}
+	if c, ok := FromContext(ctx); ok {
+		d.Subject, d.Role = c.Subject, c.Role
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		d.Peer = p.Addr.String()
+	}
+	return d
+}
+
+// Auditor records denied calls.
+type Auditor interface {
+	Denied(ctx context.Context, d Denial)
+}
+
+// LogAuditor writes denials to the standard logger.
+type LogAuditor struct{}
+
+func (LogAuditor) Denied(_ context.Context, d Denial) {
+	log.Printf("Permission denied: %s by %q (role %q) from %s: %s", d.Method, d.Subject, d.Role, d.Peer, d.Reason)
+}
+
+// SQLAuditor logs denials and stores them in a table with this layout:
+//
+//	id BIGSERIAL PRIMARY KEY, occurred_at TIMESTAMPTZ, method TEXT,
+//	subject TEXT, role TEXT, reason TEXT, peer TEXT
+type SQLAuditor struct {
+	db    *sql.DB
+	table string
+}
+
+func NewSQLAuditor(db *sql.DB, table string) *SQLAuditor {
+	return &SQLAuditor{db: db, table: table}
+}
+
+func (a *SQLAuditor) Denied(ctx context.Context, d Denial) {
+	LogAuditor{}.Denied(ctx, d)
+
+	// The call is being refused anyway, so do not let a cancelled request
+	// lose its audit entry
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, err := a.db.ExecContext(ctx, fmt.Sprintf(
+		`INSERT INTO %s (occurred_at, method, subject, role, reason, peer) VALUES ($1, $2, $3, $4, $5, $6)`, a.table),
+		d.Time, d.Method, d.Subject, d.Role, d.Reason, d.Peer)
+	if err != nil {
+		log.Printf("Failed to record permission denial: %v", err)
+	}
+}
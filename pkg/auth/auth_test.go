@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func testKeys(t *testing.T) (*SigningKey, *Verifier) {
+	t.Helper()
+	key, err := GenerateKey(time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, NewVerifier(StaticKeySet{Keys: &JWKS{Keys: []JWK{key.JWK()}}}, "test")
+}
+
+func accessClaims(ttl time.Duration) *Claims {
+	now := time.Now()
+	return &Claims{ID: "t1", Issuer: "test", Subject: "42", Role: RoleBettor, Type: TokenTypeAccess,
+		IssuedAt: now.Unix(), ExpiresAt: now.Add(ttl).Unix()}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	key, v := testKeys(t)
+	token, err := Sign(key, accessClaims(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims, err := v.Verify(context.Background(), token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claims.Subject != "42" || claims.Role != RoleBettor {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+
+	parts := strings.Split(token, ".")
+	tampered := parts[0] + "." + b64.EncodeToString([]byte(`{"sub":"1","typ":"access","exp":9999999999}`)) + "." + parts[2]
+	if _, err := v.Verify(context.Background(), tampered); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected a tampered token to be rejected, got %v", err)
+	}
+}
+
+func TestVerifyRejects(t *testing.T) {
+	key, v := testKeys(t)
+	other, _ := GenerateKey(time.Now().Add(time.Hour))
+
+	expired, _ := Sign(key, accessClaims(-time.Minute))
+	if _, err := v.Verify(context.Background(), expired); !errors.Is(err, ErrExpiredToken) {
+		t.Errorf("expected ErrExpiredToken, got %v", err)
+	}
+
+	unknown, _ := Sign(other, accessClaims(time.Minute))
+	if _, err := v.Verify(context.Background(), unknown); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("expected ErrUnknownKey, got %v", err)
+	}
+
+	c := accessClaims(time.Minute)
+	c.Issuer = "someone else"
+	foreign, _ := Sign(key, c)
+	if _, err := v.Verify(context.Background(), foreign); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("expected a token from another issuer to be rejected, got %v", err)
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	key, v := testKeys(t)
+	token, _ := Sign(key, accessClaims(time.Minute))
+	intercept := UnaryServerInterceptor(v, "/svc/Public")
+
+	var seen *Claims
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		seen, _ = FromContext(ctx)
+		return nil, nil
+	}
+	call := func(method, authorization string) error {
+		ctx := context.Background()
+		if authorization != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", authorization))
+		}
+		seen = nil
+		_, err := intercept(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	if err := call("/svc/Private", ""); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated without a token, got %v", err)
+	}
+	if err := call("/svc/Private", "Bearer garbage"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated for a bad token, got %v", err)
+	}
+	if err := call("/svc/Private", "Bearer "+token); err != nil || seen == nil || seen.Subject != "42" {
+		t.Errorf("expected the caller's claims in the context, got %+v (%v)", seen, err)
+	}
+	if err := call("/svc/Public", ""); err != nil || seen != nil {
+		t.Errorf("expected an anonymous call to a public method to pass, got %v", err)
+	}
+}
+
+func TestRequireHTTP(t *testing.T) {
+	key, v := testKeys(t)
+	bettor, _ := Sign(key, accessClaims(time.Minute))
+	c := accessClaims(time.Minute)
+	c.Role = RoleTrader
+	trader, _ := Sign(key, c)
+	expired, _ := Sign(key, accessClaims(-time.Minute))
+
+	var seen *Claims
+	h := v.RequireHTTP(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = FromContext(r.Context())
+	}), RoleTrader)
+
+	cases := []struct {
+		authorization string
+		want          int
+	}{
+		{"", http.StatusUnauthorized},
+		{"Bearer garbage", http.StatusUnauthorized},
+		{"Bearer " + expired, http.StatusUnauthorized},
+		{"Bearer " + bettor, http.StatusForbidden},
+		{"Bearer " + trader, http.StatusOK},
+	}
+	for _, tc := range cases {
+		seen = nil
+		req := httptest.NewRequest(http.MethodPost, "/events", nil)
+		if tc.authorization != "" {
+			req.Header.Set("Authorization", tc.authorization)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != tc.want {
+			t.Errorf("Authorization %.20q: status %d, want %d", tc.authorization, rec.Code, tc.want)
+		}
+		if (seen != nil) != (tc.want == http.StatusOK) {
+			t.Errorf("Authorization %.20q: handler ran = %v", tc.authorization, seen != nil)
+		}
+	}
+	if seen == nil || seen.Role != RoleTrader {
+		t.Errorf("expected the trader's claims in the context, got %+v", seen)
+	}
+}
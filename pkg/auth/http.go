@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// RequireHTTP is the HTTP counterpart of UnaryServerInterceptor followed by
+// a Policy entry: next runs only for callers with a valid bearer token and
+// one of roles, and sees their claims in the request context. Admins may
+// call every handler.
+func (v *Verifier) RequireHTTP(next http.Handler, roles ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "missing bearer token", http.StatusUnauthorized)
+			return
+		}
+		claims, err := v.Verify(r.Context(), token)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			if errors.Is(err, ErrExpiredToken) {
+				http.Error(w, "token has expired", http.StatusUnauthorized)
+				return
+			}
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+		if !(Policy{"": roles}).Allows("", claims) {
+			http.Error(w, "permission denied", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), claims)))
+	})
+}
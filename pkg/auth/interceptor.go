@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Verifier checks access tokens.
+type Verifier struct {
+	keys   KeySource
+	issuer string
+}
+
+func NewVerifier(keys KeySource, issuer string) *Verifier {
+	return &Verifier{keys: keys, issuer: issuer}
+}
+
+// Verify returns the claims of a valid access token.
+func (v *Verifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	claims, err := Parse(token, func(kid string) (ed25519.PublicKey, error) {
+		return v.keys.PublicKey(ctx, kid)
+	}, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if claims.Type != TokenTypeAccess || (v.issuer != "" && claims.Issuer != v.issuer) {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+type claimsKey struct{}
+
+// NewContext returns ctx carrying the caller's claims.
+func NewContext(ctx context.Context, c *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, c)
+}
+
+// FromContext returns the claims of the authenticated caller.
+func FromContext(ctx context.Context) (*Claims, bool) {
+	c, ok := ctx.Value(claimsKey{}).(*Claims)
+	return c, ok
+}
+
+// UnaryServerInterceptor rejects calls without a valid bearer token, except
+// to the listed public methods, and stores the caller's claims in the
+// context. Methods are full gRPC names such as "/event.EventService/ListEvents".
+func UnaryServerInterceptor(v *Verifier, public ...string) grpc.UnaryServerInterceptor {
+	open := methodSet(public)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := v.authenticate(ctx, open[info.FullMethod])
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor.
+func StreamServerInterceptor(v *Verifier, public ...string) grpc.StreamServerInterceptor {
+	open := methodSet(public)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := v.authenticate(ss.Context(), open[info.FullMethod])
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate verifies the bearer token of the call. On a public method a
+// missing token is allowed but a bad one is still rejected.
+func (v *Verifier) authenticate(ctx context.Context, public bool) (context.Context, error) {
+	token, ok := bearerToken(ctx)
+	if !ok {
+		if public {
+			return ctx, nil
+		}
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	claims, err := v.Verify(ctx, token)
+	if err != nil {
+		if errors.Is(err, ErrExpiredToken) {
+			return nil, status.Error(codes.Unauthenticated, "token has expired")
+		}
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	return NewContext(ctx, claims), nil
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+	for _, v := range md.Get("authorization") {
+		if token, ok := strings.CutPrefix(v, "Bearer "); ok && token != "" {
+			return token, true
+		}
+	}
+	return "", false
+}
+
+func methodSet(methods []string) map[string]bool {
+	set := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		set[m] = true
+	}
+	return set
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context { return s.ctx }
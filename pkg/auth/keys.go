@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// SigningKey is an Ed25519 key pair used to sign tokens.
+type SigningKey struct {
+	ID        string
+	Private   ed25519.PrivateKey
+	CreatedAt time.Time
+	// ExpiresAt is when the key stops being published; tokens signed with
+	// it must have expired by then.
+	ExpiresAt time.Time
+}
+
+// GenerateKey creates a signing key valid until expiresAt.
+func GenerateKey(expiresAt time.Time) (*SigningKey, error) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	return &SigningKey{ID: hex.EncodeToString(id), Private: priv, CreatedAt: time.Now(), ExpiresAt: expiresAt}, nil
+}
+
+// JWK returns the public half of the key.
+func (k *SigningKey) JWK() JWK {
+	return JWK{
+		Kty: "OKP",
+		Crv: "Ed25519",
+		Alg: "EdDSA",
+		Use: "sig",
+		Kid: k.ID,
+		X:   b64.EncodeToString(k.Private.Public().(ed25519.PublicKey)),
+	}
+}
+
+// JWK is a public key in JSON Web Key form.
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Kid string `json:"kid"`
+	X   string `json:"x"`
+}
+
+// JWKS is the set of keys a token may be signed with.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// Key returns the public key with the given ID.
+func (s *JWKS) Key(kid string) (ed25519.PublicKey, error) {
+	for _, k := range s.Keys {
+		if k.Kid != kid {
+			continue
+		}
+		if k.Kty != "OKP" || k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("%w: key %s is not an Ed25519 key", ErrInvalidToken, kid)
+		}
+		x, err := b64.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%w: malformed key %s", ErrInvalidToken, kid)
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, ErrUnknownKey
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// KeySource looks up the public key a token was signed with.
+type KeySource interface {
+	PublicKey(ctx context.Context, kid string) (ed25519.PublicKey, error)
+}
+
+// RemoteKeySet is a KeySource backed by a JWKS served over HTTP. The set is
+// cached and fetched again when it is older than RefreshInterval or a token
+// names a key it does not contain, so keys added by rotation are picked up
+// at once.
+type RemoteKeySet struct {
+	url    string
+	client *http.Client
+
+	RefreshInterval time.Duration
+	// MinRefetch limits how often an unknown key ID can trigger a fetch.
+	MinRefetch time.Duration
+
+	mu        sync.Mutex
+	keys      *JWKS
+	fetchedAt time.Time
+}
+
+func NewRemoteKeySet(url string) *RemoteKeySet {
+	return &RemoteKeySet{
+		url:             url,
+		client:          &http.Client{Timeout: 5 * time.Second},
+		RefreshInterval: 10 * time.Minute,
+		MinRefetch:      10 * time.Second,
+	}
+}
+
+func (s *RemoteKeySet) PublicKey(ctx context.Context, kid string) (ed25519.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.keys == nil || time.Since(s.fetchedAt) > s.RefreshInterval {
+		if err := s.fetch(ctx); err != nil && s.keys == nil {
+			return nil, err
+		}
+	}
+	key, err := s.keys.Key(kid)
+	if errors.Is(err, ErrUnknownKey) && time.Since(s.fetchedAt) > s.MinRefetch {
+		if fetchErr := s.fetch(ctx); fetchErr != nil {
+			return nil, fetchErr
+		}
+		key, err = s.keys.Key(kid)
+	}
+	return key, err
+}
+
+func (s *RemoteKeySet) fetch(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		log.Printf("Failed to fetch JWKS from %s: %v", s.url, err)
+		return fmt.Errorf("fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetch JWKS: %s", resp.Status)
+	}
+
+	var keys JWKS
+	if err := json.NewDecoder(resp.Body).Decode(&keys); err != nil {
+		return fmt.Errorf("decode JWKS: %w", err)
+	}
+	s.keys = &keys
+	s.fetchedAt = time.Now()
+	return nil
+}
+
+// StaticKeySet is a KeySource over a fixed set of keys.
+type StaticKeySet struct {
+	Keys *JWKS
+}
+
+func (s StaticKeySet) PublicKey(_ context.Context, kid string) (ed25519.PublicKey, error) {
+	return s.Keys.Key(kid)
+}
+
+// JWKSHandler serves the key set returned by keys as JSON.
+func JWKSHandler(keys func() *JWKS) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "max-age=60")
+		if err := json.NewEncoder(w).Encode(keys()); err != nil {
+			log.Printf("Failed to write JWKS: %v", err)
+		}
+	})
+}
@@ -0,0 +1,192 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Special entries of a Policy.
+const (
+	// Anyone allows calls without a token.
+	Anyone = "*"
+	// Authenticated allows any caller with a valid token.
+	Authenticated = "authenticated"
+)
+
+// Policy lists, for each full gRPC method name, the roles allowed to call it.
+// Admins may call every method. Methods missing from the policy are denied,
+// so a new RPC stays closed until it is given an entry.
+type Policy map[string][]string
+
+// Public returns the methods open to callers without a token.
+func (p Policy) Public() []string {
+	var methods []string
+	for m, roles := range p {
+		for _, r := range roles {
+			if r == Anyone {
+				methods = append(methods, m)
+				break
+			}
+		}
+	}
+	return methods
+}
+
+// Allows reports whether a caller with claims c, nil when the call carries
+// no token, may call method.
+func (p Policy) Allows(method string, c *Claims) bool {
+	if c != nil && c.Role == RoleAdmin {
+		return true
+	}
+	for _, r := range p[method] {
+		switch {
+		case r == Anyone:
+			return true
+		case c == nil:
+		case r == Authenticated, r == c.Role:
+			return true
+		}
+	}
+	return false
+}
+
+// Authorizer enforces a Policy and records the calls it denies.
+type Authorizer struct {
+	policy Policy
+	audit  Auditor
+}
+
+func NewAuthorizer(policy Policy, audit Auditor) *Authorizer {
+	return &Authorizer{policy: policy, audit: audit}
+}
+
+// Public returns the methods open to callers without a token, to pass to
+// UnaryServerInterceptor.
+func (a *Authorizer) Public() []string {
+	return a.policy.Public()
+}
+
+// UnaryServerInterceptor denies calls the policy does not allow. It must run
+// after the interceptor that authenticates the caller.
+func (a *Authorizer) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := a.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// UnaryServerInterceptor.
+func (a *Authorizer) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (a *Authorizer) authorize(ctx context.Context, method string) error {
+	claims, _ := FromContext(ctx)
+	if a.policy.Allows(method, claims) {
+		return nil
+	}
+	return a.deny(ctx, method, "role not allowed")
+}
+
+// RequireOwner allows the call when the caller is the user with userID or
+// has one of the staff roles, such as a trader reading another user's bets.
+// Admins and services pass as well.
+func (a *Authorizer) RequireOwner(ctx context.Context, userID string, staff ...string) error {
+	claims, ok := FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	if claims.Role == RoleAdmin || claims.Role == RoleService || claims.Subject == userID {
+		return nil
+	}
+	for _, r := range staff {
+		if claims.Role == r {
+			return nil
+		}
+	}
+	method, _ := grpc.Method(ctx)
+	return a.deny(ctx, method, fmt.Sprintf("not the owner of user %s", userID))
+}
+
+// RequireSelf allows the call only when the caller is the user with userID.
+// Unlike RequireOwner it lets neither admins nor services through.
+func (a *Authorizer) RequireSelf(ctx context.Context, userID string) error {
+	claims, ok := FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	if claims.Subject == userID {
+		return nil
+	}
+	method, _ := grpc.Method(ctx)
+	return a.deny(ctx, method, fmt.Sprintf("not user %s", userID))
+}
+
+// RequireRole allows the call only when the caller has one of roles. Unlike
+// the policy it does not let admins through, so it can keep an operation,
+// such as crediting winnings, to the service that owns it.
+func (a *Authorizer) RequireRole(ctx context.Context, roles ...string) error {
+	claims, ok := FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	for _, r := range roles {
+		if claims.Role == r {
+			return nil
+		}
+	}
+	method, _ := grpc.Method(ctx)
+	return a.deny(ctx, method, fmt.Sprintf("role %q may not do this", claims.Role))
+}
+
+// IsStaff reports whether the caller acts for the operator rather than as a
+// bettor: an admin, a service or one of the given roles.
+func IsStaff(ctx context.Context, roles ...string) bool {
+	claims, ok := FromContext(ctx)
+	if !ok {
+		return false
+	}
+	if claims.Role == RoleAdmin || claims.Role == RoleService {
+		return true
+	}
+	for _, r := range roles {
+		if claims.Role == r {
+			return true
+		}
+	}
+	return false
+}
+
+// MFARequiredMessage is the status message of calls refused by RequireFreshMFA,
+// telling clients to complete a two-factor step-up and retry.
+const MFARequiredMessage = "fresh two-factor authentication required"
+
+// RequireFreshMFA refuses the call unless the caller proved a second factor
+// within maxAge.
+func RequireFreshMFA(ctx context.Context, maxAge time.Duration) error {
+	claims, ok := FromContext(ctx)
+	if !ok || claims.MFAAt == 0 || time.Since(time.Unix(claims.MFAAt, 0)) > maxAge {
+		return status.Error(codes.PermissionDenied, MFARequiredMessage)
+	}
+	return nil
+}
+
+func (a *Authorizer) deny(ctx context.Context, method, reason string) error {
+	if a.audit != nil {
+		a.audit.Denied(ctx, NewDenial(ctx, method, reason))
+	}
+	return status.Error(codes.PermissionDenied, "permission denied")
+}
@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type recordingAuditor struct{ denials []Denial }
+
+func (r *recordingAuditor) Denied(_ context.Context, d Denial) { r.denials = append(r.denials, d) }
+
+func TestPolicyAllows(t *testing.T) {
+	p := Policy{
+		"/svc/List":   {Anyone},
+		"/svc/Get":    {Authenticated},
+		"/svc/Settle": {RoleTrader, RoleService},
+	}
+	bettor := &Claims{Subject: "42", Role: RoleBettor}
+	trader := &Claims{Subject: "7", Role: RoleTrader}
+	admin := &Claims{Subject: "1", Role: RoleAdmin}
+
+	cases := []struct {
+		method string
+		claims *Claims
+		want   bool
+	}{
+		{"/svc/List", nil, true},
+		{"/svc/Get", nil, false},
+		{"/svc/Get", bettor, true},
+		{"/svc/Settle", bettor, false},
+		{"/svc/Settle", trader, true},
+		{"/svc/Settle", admin, true},
+		{"/svc/Unlisted", trader, false},
+		{"/svc/Unlisted", admin, true},
+	}
+	for _, c := range cases {
+		if got := p.Allows(c.method, c.claims); got != c.want {
+			t.Errorf("Allows(%s, %+v) = %v, want %v", c.method, c.claims, got, c.want)
+		}
+	}
+
+	if public := p.Public(); len(public) != 1 || public[0] != "/svc/List" {
+		t.Errorf("unexpected public methods %v", public)
+	}
+}
+
+func TestAuthorizerDeniesAndAudits(t *testing.T) {
+	audit := &recordingAuditor{}
+	a := NewAuthorizer(Policy{"/svc/Settle": {RoleTrader}}, audit)
+	intercept := a.UnaryServerInterceptor()
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	ctx := NewContext(context.Background(), &Claims{Subject: "42", Role: RoleBettor})
+	_, err := intercept(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/svc/Settle"}, handler)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("expected PermissionDenied, got %v", err)
+	}
+	if len(audit.denials) != 1 || audit.denials[0].Method != "/svc/Settle" || audit.denials[0].Subject != "42" {
+		t.Errorf("unexpected audit trail %+v", audit.denials)
+	}
+
+	if err := a.RequireOwner(ctx, "42"); err != nil {
+		t.Errorf("expected the owner to pass, got %v", err)
+	}
+	if err := a.RequireOwner(ctx, "43", RoleTrader); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied for another user's data, got %v", err)
+	}
+	trader := NewContext(context.Background(), &Claims{Subject: "7", Role: RoleTrader})
+	if err := a.RequireOwner(trader, "43", RoleTrader); err != nil {
+		t.Errorf("expected staff to pass, got %v", err)
+	}
+	if len(audit.denials) != 2 {
+		t.Errorf("expected the ownership denial to be audited, got %d entries", len(audit.denials))
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	a := NewAuthorizer(Policy{}, nil)
+	cases := []struct {
+		role string
+		want codes.Code
+	}{
+		{RoleService, codes.OK},
+		{RoleBettor, codes.PermissionDenied},
+		{RoleFinance, codes.PermissionDenied},
+		{RoleAdmin, codes.PermissionDenied},
+	}
+	for _, c := range cases {
+		ctx := NewContext(context.Background(), &Claims{Subject: "1", Role: c.role})
+		if got := status.Code(a.RequireRole(ctx, RoleService)); got != c.want {
+			t.Errorf("RequireRole(%s) = %v, want %v", c.role, got, c.want)
+		}
+	}
+	if got := status.Code(a.RequireRole(context.Background(), RoleService)); got != codes.Unauthenticated {
+		t.Errorf("RequireRole without a token = %v, want Unauthenticated", got)
+	}
+}
+
+func TestRequireSelf(t *testing.T) {
+	audit := &recordingAuditor{}
+	a := NewAuthorizer(Policy{}, audit)
+	cases := []struct {
+		claims *Claims
+		want   codes.Code
+	}{
+		{&Claims{Subject: "42", Role: RoleBettor}, codes.OK},
+		{&Claims{Subject: "43", Role: RoleBettor}, codes.PermissionDenied},
+		{&Claims{Subject: "1", Role: RoleAdmin}, codes.PermissionDenied},
+		{&Claims{Subject: "service:bet_service", Role: RoleService}, codes.PermissionDenied},
+	}
+	for _, c := range cases {
+		ctx := NewContext(context.Background(), c.claims)
+		if got := status.Code(a.RequireSelf(ctx, "42")); got != c.want {
+			t.Errorf("RequireSelf as %s %s = %v, want %v", c.claims.Role, c.claims.Subject, got, c.want)
+		}
+	}
+	if len(audit.denials) != 3 {
+		t.Errorf("expected every denial to be audited, got %d entries", len(audit.denials))
+	}
+}
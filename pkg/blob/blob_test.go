@@ -0,0 +1,74 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := s.Put(ctx, "kyc/42/passport", strings.NewReader("scan"))
+	if err != nil || n != 4 {
+		t.Fatalf("Put = %d, %v", n, err)
+	}
+	r, err := s.Get(ctx, "kyc/42/passport")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := io.ReadAll(r)
+	r.Close()
+	if string(b) != "scan" {
+		t.Errorf("Get = %q, want %q", b, "scan")
+	}
+
+	if err := s.Delete(ctx, "kyc/42/passport"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get(ctx, "kyc/42/passport"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete = %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(ctx, "kyc/42/passport"); err != nil {
+		t.Errorf("deleting a missing blob: %v", err)
+	}
+}
+
+func TestFileStoreFailedPutLeavesNothing(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	broken := io.MultiReader(strings.NewReader("half"), errReader{})
+	if _, err := s.Put(context.Background(), "doc", broken); err == nil {
+		t.Fatal("Put succeeded with a failing reader")
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("left %d file(s) behind", len(entries))
+	}
+}
+
+func TestFileStoreRejectsEscapingKeys(t *testing.T) {
+	s, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"", "/etc/passwd", "../secret", "kyc/../../secret", "kyc//doc", `kyc\doc`} {
+		if _, err := s.Put(context.Background(), key, strings.NewReader("x")); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Put(%q) = %v, want ErrInvalidKey", key, err)
+		}
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errors.New("connection reset") }
@@ -0,0 +1,86 @@
+// Package clientip works out the address a request came from when it may
+// have passed through proxies. X-Forwarded-For is only believed as far back
+// as the chain of trusted proxies goes, so a client cannot choose the
+// address it is counted under by sending the header itself.
+package clientip
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// Header carries the chain of addresses, as an HTTP header or as gRPC
+// metadata.
+const Header = "x-forwarded-for"
+
+// Proxies are the networks of the proxies trusted to report the address
+// they received a request from.
+type Proxies []netip.Prefix
+
+// ParseProxies parses addresses and CIDR networks, such as "10.0.0.0/8" or
+// "::1".
+func ParseProxies(list []string) (Proxies, error) {
+	var p Proxies
+	for _, s := range list {
+		if prefix, err := netip.ParsePrefix(s); err == nil {
+			p = append(p, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy %q: want an address or a CIDR network", s)
+		}
+		p = append(p, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return p, nil
+}
+
+// Resolve returns the address of the client of a request received from
+// peer, a host or host:port, carrying the given X-Forwarded-For values.
+// The chain is read from the nearest hop back, and the first address not
+// of a trusted proxy is the client.
+func (p Proxies) Resolve(peer string, forwarded []string) string {
+	client := host(peer)
+	if !p.trusts(client) {
+		return client
+	}
+	var chain []string
+	for _, v := range forwarded {
+		chain = append(chain, strings.Split(v, ",")...)
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(chain[i])
+		if _, err := netip.ParseAddr(hop); err != nil {
+			// Whatever came before a malformed entry cannot be trusted
+			return client
+		}
+		client = hop
+		if !p.trusts(hop) {
+			return client
+		}
+	}
+	return client
+}
+
+func (p Proxies) trusts(s string) bool {
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range p {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func host(addr string) string {
+	if h, _, err := net.SplitHostPort(addr); err == nil {
+		return h
+	}
+	return addr
+}
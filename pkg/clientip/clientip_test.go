@@ -0,0 +1,46 @@
+package clientip
+
+import "testing"
+
+func TestResolve(t *testing.T) {
+	proxies, err := ParseProxies([]string{"10.0.0.0/8", "::1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name      string
+		peer      string
+		forwarded []string
+		want      string
+	}{
+		{"direct", "203.0.113.7:4000", nil, "203.0.113.7"},
+		{"direct with a forged header", "203.0.113.7:4000", []string{"198.51.100.1"}, "203.0.113.7"},
+		{"through a proxy", "10.0.0.2:4000", []string{"203.0.113.7"}, "203.0.113.7"},
+		{"forged entry before the proxy's", "10.0.0.2:4000", []string{"198.51.100.1, 203.0.113.7"}, "203.0.113.7"},
+		{"two proxies", "10.0.0.2:4000", []string{"203.0.113.7, 10.0.0.9"}, "203.0.113.7"},
+		{"headers in order", "10.0.0.2:4000", []string{"198.51.100.1", "203.0.113.7"}, "203.0.113.7"},
+		{"proxy without a header", "10.0.0.2:4000", nil, "10.0.0.2"},
+		{"malformed entry", "10.0.0.2:4000", []string{"203.0.113.7, junk"}, "10.0.0.2"},
+		{"IPv6 loopback proxy", "[::1]:4000", []string{"2001:db8::1"}, "2001:db8::1"},
+		{"peer without a port", "10.0.0.2", []string{"203.0.113.7"}, "203.0.113.7"},
+	}
+	for _, c := range cases {
+		if got := proxies.Resolve(c.peer, c.forwarded); got != c.want {
+			t.Errorf("%s: Resolve(%q, %q) = %q, want %q", c.name, c.peer, c.forwarded, got, c.want)
+		}
+	}
+
+	var none Proxies
+	if got := none.Resolve("10.0.0.2:4000", []string{"203.0.113.7"}); got != "10.0.0.2" {
+		t.Errorf("without trusted proxies Resolve = %q, want the peer", got)
+	}
+}
+
+func TestParseProxies(t *testing.T) {
+	if _, err := ParseProxies([]string{"127.0.0.1", "10.1.2.3/8", "::1"}); err != nil {
+		t.Errorf("ParseProxies: %v", err)
+	}
+	if _, err := ParseProxies([]string{"gateway"}); err == nil {
+		t.Error("expected a host name to be refused")
+	}
+}
@@ -0,0 +1,424 @@
+// Package config loads a service's settings into a struct. Each setting is
+// taken from, in increasing order of precedence, its default, a YAML file,
+// the environment and the command line:
+//
+//	type Config struct {
+//		GRPCAddr string `yaml:"grpc_addr" env:"GRPC_ADDR" default:":50051" usage:"gRPC listen address"`
+//		SMTP     struct {
+//			Password string `yaml:"password" env:"SMTP_PASSWORD" secret:"true"`
+//		} `yaml:"smtp"`
+//	}
+//
+// The file is named by the -config flag or CONFIG_FILE. Nested structs are
+// nested mappings in the file, and on the command line a setting is named by
+// its dotted path, as in -smtp.password. A setting tagged secret can instead
+// be read from the file named by <ENV>_FILE or by the <key>_file key, which
+// suits mounted secrets, and is masked by Redacted.
+//
+// Settings may be strings, booleans, numbers, durations such as "15m",
+// types implementing encoding.TextUnmarshaler, and slices and string-keyed
+// maps of those, written "a,b" and "k1:v1,k2:v2" outside the file.
+package config
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	durationType        = reflect.TypeOf(time.Duration(0))
+)
+
+// Validator is implemented by configurations with rules beyond required
+// settings. Load calls Validate once every source has been applied.
+type Validator interface {
+	Validate() error
+}
+
+type setting struct {
+	path     []string
+	env      string
+	def      string
+	hasDef   bool
+	required bool
+	secret   bool
+	usage    string
+	value    reflect.Value
+}
+
+func (s *setting) name() string {
+	return strings.Join(s.path, ".")
+}
+
+func (s *setting) help() string {
+	var notes []string
+	if s.hasDef {
+		notes = append(notes, fmt.Sprintf("default %q", s.def))
+	}
+	if s.env != "" {
+		notes = append(notes, "env "+s.env)
+	}
+	if s.required {
+		notes = append(notes, "required")
+	}
+	if len(notes) == 0 {
+		return s.usage
+	}
+	return strings.TrimSpace(s.usage + " (" + strings.Join(notes, ", ") + ")")
+}
+
+func (s *setting) set(text string) error {
+	if err := setValue(s.value, text); err != nil {
+		return fmt.Errorf("%s: %w", s.name(), err)
+	}
+	return nil
+}
+
+// MustLoad loads cfg from the process's environment and arguments, logs
+// the effective configuration and returns the arguments left after the
+// flags. It exits if the configuration is invalid, or after printing the
+// flags for -help.
+func MustLoad(cfg any) []string {
+	args, err := Load(cfg, os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	log.Printf("Configuration:\n%s", Redacted(cfg))
+	return args
+}
+
+// Load fills cfg, a pointer to a struct, from its defaults, the YAML file,
+// the environment and the flags in args, then checks that every required
+// setting has a value. It returns the arguments after the flags, such as a
+// subcommand.
+func Load(cfg any, args []string) ([]string, error) {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config: Load needs a pointer to a struct, not %T", cfg)
+	}
+	all := settings(v.Elem(), nil)
+
+	// The command line is parsed first to find the file, but applied last
+	fs := flag.NewFlagSet(filepath.Base(os.Args[0]), flag.ContinueOnError)
+	file := fs.String("config", os.Getenv("CONFIG_FILE"), "YAML file to read settings from (env CONFIG_FILE)")
+	var fromFlags []func()
+	for _, s := range all {
+		s := s
+		parse := func(text string) error {
+			parsed := reflect.New(s.value.Type()).Elem()
+			if err := setValue(parsed, text); err != nil {
+				return err
+			}
+			fromFlags = append(fromFlags, func() { s.value.Set(parsed) })
+			return nil
+		}
+		if s.value.Kind() == reflect.Bool {
+			fs.BoolFunc(s.name(), s.help(), parse)
+		} else {
+			fs.Func(s.name(), s.help(), parse)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	for _, s := range all {
+		if s.hasDef {
+			if err := s.set(s.def); err != nil {
+				return nil, fmt.Errorf("config: default of %w", err)
+			}
+		}
+	}
+	if *file != "" {
+		if err := loadFile(*file, all); err != nil {
+			return nil, err
+		}
+	}
+	if err := loadEnv(all); err != nil {
+		return nil, err
+	}
+	for _, apply := range fromFlags {
+		apply()
+	}
+
+	var missing []string
+	for _, s := range all {
+		if s.required && s.value.IsZero() {
+			name := s.name()
+			if s.env != "" {
+				name += " (" + s.env + ")"
+			}
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required settings: %s", strings.Join(missing, ", "))
+	}
+	if validator, ok := cfg.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return fs.Args(), nil
+}
+
+// settings lists the fields of v, a struct, descending into nested structs.
+func settings(v reflect.Value, path []string) []*setting {
+	var out []*setting
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		key, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if key == "-" {
+			continue
+		}
+		if key == "" {
+			key = strings.ToLower(f.Name)
+		}
+		p := append(path[:len(path):len(path)], key)
+		if f.Type.Kind() == reflect.Struct && !reflect.PointerTo(f.Type).Implements(textUnmarshalerType) {
+			out = append(out, settings(v.Field(i), p)...)
+			continue
+		}
+		def, hasDef := f.Tag.Lookup("default")
+		out = append(out, &setting{
+			path:     p,
+			env:      f.Tag.Get("env"),
+			def:      def,
+			hasDef:   hasDef,
+			required: f.Tag.Get("required") == "true",
+			secret:   f.Tag.Get("secret") == "true",
+			usage:    f.Tag.Get("usage"),
+			value:    v.Field(i),
+		})
+	}
+	return out
+}
+
+func loadFile(file string, all []*setting) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("config: %s: %w", file, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil
+	}
+
+	byName := map[string]*setting{}
+	groups := map[string]bool{}
+	for _, s := range all {
+		byName[s.name()] = s
+		for i := 1; i < len(s.path); i++ {
+			groups[strings.Join(s.path[:i], ".")] = true
+		}
+	}
+
+	var walk func(n *yaml.Node, prefix string) error
+	walk = func(n *yaml.Node, prefix string) error {
+		if n.Kind != yaml.MappingNode {
+			return fmt.Errorf("config: %s line %d: expected a mapping", file, n.Line)
+		}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, val := n.Content[i], n.Content[i+1]
+			name := prefix + key.Value
+			if s, ok := byName[name]; ok {
+				if err := setNode(s, val); err != nil {
+					return fmt.Errorf("config: %s line %d: %w", file, val.Line, err)
+				}
+				continue
+			}
+			if base, ok := strings.CutSuffix(name, "_file"); ok && byName[base] != nil && byName[base].secret {
+				if err := setSecretFile(byName[base], val.Value); err != nil {
+					return fmt.Errorf("config: %s line %d: %w", file, val.Line, err)
+				}
+				continue
+			}
+			if groups[name] {
+				if err := walk(val, name+"."); err != nil {
+					return err
+				}
+				continue
+			}
+			return fmt.Errorf("config: %s line %d: unknown setting %s", file, key.Line, name)
+		}
+		return nil
+	}
+	return walk(doc.Content[0], "")
+}
+
+// setNode applies a YAML value. Scalars are parsed the same way as the
+// environment and flags; lists and mappings are decoded by the YAML package.
+func setNode(s *setting, n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		if n.Tag == "!!null" {
+			return nil
+		}
+		return s.set(n.Value)
+	}
+	parsed := reflect.New(s.value.Type())
+	if err := n.Decode(parsed.Interface()); err != nil {
+		return fmt.Errorf("%s: %w", s.name(), err)
+	}
+	s.value.Set(parsed.Elem())
+	return nil
+}
+
+func loadEnv(all []*setting) error {
+	for _, s := range all {
+		if s.env == "" {
+			continue
+		}
+		if v := os.Getenv(s.env); v != "" {
+			if err := s.set(v); err != nil {
+				return fmt.Errorf("%s: %w", s.env, err)
+			}
+			continue
+		}
+		if s.secret {
+			if file := os.Getenv(s.env + "_FILE"); file != "" {
+				if err := setSecretFile(s, file); err != nil {
+					return fmt.Errorf("%s_FILE: %w", s.env, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// setSecretFile reads a secret from a file, dropping the trailing newline
+// editors and secret managers tend to leave.
+func setSecretFile(s *setting, file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("%s: %w", s.name(), err)
+	}
+	return s.set(strings.TrimRight(string(data), "\r\n"))
+}
+
+func setValue(v reflect.Value, text string) error {
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text))
+	}
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(text)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		s := reflect.MakeSlice(v.Type(), 0, 0)
+		for _, item := range splitList(text) {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := setValue(elem, item); err != nil {
+				return err
+			}
+			s = reflect.Append(s, elem)
+		}
+		v.Set(s)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("unsupported type %s", v.Type())
+		}
+		m := reflect.MakeMap(v.Type())
+		for _, item := range splitList(text) {
+			key, val, ok := strings.Cut(item, ":")
+			if !ok || key == "" {
+				return fmt.Errorf("invalid entry %q, want key:value", item)
+			}
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := setValue(elem, val); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(key).Convert(v.Type().Key()), elem)
+		}
+		v.Set(m)
+	default:
+		return fmt.Errorf("unsupported type %s", v.Type())
+	}
+	return nil
+}
+
+func splitList(text string) []string {
+	var items []string
+	for _, item := range strings.Split(text, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Redacted lists the settings of cfg, a struct or a pointer to one, as
+// "path = value" lines. Secrets and the passwords in URLs are masked, so the
+// result can be logged.
+func Redacted(cfg any) string {
+	v := reflect.Indirect(reflect.ValueOf(cfg))
+	var b strings.Builder
+	for _, s := range settings(v, nil) {
+		fmt.Fprintf(&b, "  %s = %s\n", s.name(), display(s))
+	}
+	return b.String()
+}
+
+func display(s *setting) string {
+	switch {
+	case s.value.IsZero():
+		if s.value.Kind() == reflect.String {
+			return `""`
+		}
+	case s.secret:
+		return "[redacted]"
+	case s.value.Kind() == reflect.String:
+		if u, err := url.Parse(s.value.String()); err == nil && u.User != nil {
+			return u.Redacted()
+		}
+		return s.value.String()
+	}
+	return fmt.Sprint(s.value.Interface())
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"errors"
+	"net"
+)
+
+// The connection settings the services share, so that every service reads
+// them from the same variables.
+
+type Postgres struct {
+	URL            string `yaml:"url" env:"DATABASE_URL" required:"true" usage:"PostgreSQL connection URL"`
+	MigrateOnStart bool   `yaml:"migrate_on_start" env:"MIGRATE_ON_START" default:"true" usage:"apply pending migrations at startup"`
+}
+
+type RabbitMQ struct {
+	URL string `yaml:"url" env:"AMQP_URL" required:"true" usage:"RabbitMQ URL"`
+}
+
+type Redis struct {
+	Addr     string `yaml:"addr" env:"REDIS_ADDR" default:"localhost:6379" usage:"Redis address"`
+	Password string `yaml:"password" env:"REDIS_PASSWORD" secret:"true" usage:"Redis password"`
+	DB       int    `yaml:"db" env:"REDIS_DB" default:"0" usage:"Redis database number"`
+}
+
+// SMTP is the mail server emails are sent through, signing in as Username.
+type SMTP struct {
+	Host     string `yaml:"host" env:"SMTP_HOST" default:"smtp.gmail.com" usage:"SMTP server host"`
+	Port     string `yaml:"port" env:"SMTP_PORT" default:"587" usage:"SMTP server port"`
+	Username string `yaml:"username" env:"SMTP_USERNAME" usage:"SMTP account, also the sender address"`
+	Password string `yaml:"password" env:"SMTP_PASSWORD" secret:"true" usage:"SMTP account password"`
+}
+
+// Validate requires a password once an account is configured. Without an
+// account, emails fail to send and the failures are logged.
+func (s SMTP) Validate() error {
+	if s.Username != "" && s.Password == "" {
+		return errors.New("smtp.password (SMTP_PASSWORD) is required with smtp.username")
+	}
+	if s.Port != "" {
+		if _, err := net.LookupPort("tcp", s.Port); err != nil {
+			return errors.New("smtp.port: " + err.Error())
+		}
+	}
+	return nil
+}
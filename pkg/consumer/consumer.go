@@ -0,0 +1,332 @@
+// Package consumer consumes RabbitMQ queues with manual acknowledgement.
+//
+// A message is acked only after its handler returns nil. A failed message is
+// moved to a retry queue, "<queue>.retry.<delay>", where it waits out the
+// delay before being dead-lettered back onto the queue. The delay grows with
+// every attempt, and each delay has a queue of its own with a fixed
+// x-message-ttl: RabbitMQ only expires messages at the head of a queue, so a
+// short delay queued behind a long one would otherwise wait as long. After
+// MaxAttempts failures, or straight away for a Permanent error, it is moved to
+// the queue's dead-letter queue, "<queue>.dlq", to be inspected and replayed
+// with cmd/dlq. A moved message is acked only once the broker has confirmed
+// the copy, so a failed move leaves it on the queue.
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+// Headers set on retried and dead-lettered messages.
+const (
+	HeaderAttempts  = "x-attempts"
+	HeaderLastError = "x-last-error"
+	HeaderQueue     = "x-original-queue"
+	HeaderFailedAt  = "x-failed-at"
+)
+
+// Handler processes one message body. Returning an error schedules a retry.
+type Handler func(body []byte) error
+
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks an error that retrying cannot fix, such as a malformed
+// message. The message is dead-lettered without further attempts.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked with Permanent.
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+// RetryQueue names the queue where messages that failed on queue wait out
+// delay; DeadLetterQueue names the queue of those that are not retried.
+func RetryQueue(queue string, delay time.Duration) string {
+	return fmt.Sprintf("%s.retry.%s", queue, delay)
+}
+func DeadLetterQueue(queue string) string { return queue + ".dlq" }
+
+type Consumer struct {
+	conn *amqp.Connection
+
+	// MaxAttempts is how many times a message is handled before it is
+	// dead-lettered.
+	MaxAttempts int
+	// RetryDelay is the delay before the first retry; it doubles with every
+	// attempt up to MaxRetryDelay.
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+	// Prefetch limits the unacknowledged messages held by each queue's
+	// consumer.
+	Prefetch int
+
+	mu            sync.Mutex
+	subscriptions []subscription
+	lost          []string
+	stopping      bool
+	running       sync.WaitGroup
+}
+
+type subscription struct {
+	ch  *amqp.Channel
+	tag string
+}
+
+func New(conn *amqp.Connection) *Consumer {
+	return &Consumer{
+		conn:          conn,
+		MaxAttempts:   5,
+		RetryDelay:    time.Second,
+		MaxRetryDelay: time.Minute,
+		Prefetch:      10,
+	}
+}
+
+// Declare declares queue together with its dead-letter queue and a retry
+// queue for each of delays.
+func Declare(ch *amqp.Channel, queue string, delays ...time.Duration) error {
+	if _, err := ch.QueueDeclare(queue, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("queue declare %q: %w", queue, err)
+	}
+	for _, delay := range delays {
+		// Expired retries go back to the queue through the default exchange
+		retryArgs := amqp.Table{
+			"x-message-ttl":             delay.Milliseconds(),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": queue,
+		}
+		if _, err := ch.QueueDeclare(RetryQueue(queue, delay), true, false, false, false, retryArgs); err != nil {
+			return fmt.Errorf("queue declare %q: %w", RetryQueue(queue, delay), err)
+		}
+	}
+	if _, err := ch.QueueDeclare(DeadLetterQueue(queue), true, false, false, false, nil); err != nil {
+		return fmt.Errorf("queue declare %q: %w", DeadLetterQueue(queue), err)
+	}
+	return nil
+}
+
+// Consume declares the queue and hands its messages to h on a goroutine of
+// its own. Messages of one queue are handled one at a time, in order.
+func (c *Consumer) Consume(queue string, h Handler) error {
+	ch, err := c.conn.Channel()
+	if err != nil {
+		return err
+	}
+	if err := Declare(ch, queue, c.retryDelays()...); err != nil {
+		ch.Close()
+		return err
+	}
+	if err := ch.Qos(c.Prefetch, 0, false); err != nil {
+		ch.Close()
+		return err
+	}
+	// Retries and dead letters are published on the same channel, one at a
+	// time, each waiting for its confirmation
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		return err
+	}
+	confirms := ch.NotifyPublish(make(chan amqp.Confirmation, 1))
+	c.mu.Lock()
+	tag := fmt.Sprintf("%s.%d", queue, len(c.subscriptions))
+	c.mu.Unlock()
+	msgs, err := ch.Consume(queue, tag, false, false, false, false, nil)
+	if err != nil {
+		ch.Close()
+		return fmt.Errorf("consume %q: %w", queue, err)
+	}
+	c.mu.Lock()
+	c.subscriptions = append(c.subscriptions, subscription{ch: ch, tag: tag})
+	c.mu.Unlock()
+
+	log.Printf("Subscribed to queue %s", queue)
+	c.running.Add(1)
+	go func() {
+		defer c.running.Done()
+		for d := range msgs {
+			c.deliver(ch, confirms, queue, d, h)
+		}
+		log.Printf("Consumer of %s stopped", queue)
+		c.mu.Lock()
+		if !c.stopping {
+			c.lost = append(c.lost, queue)
+		}
+		c.mu.Unlock()
+	}()
+	return nil
+}
+
+// Shutdown cancels every subscription and waits for the messages already
+// delivered to be handled, or until ctx is done. Messages left unacked are
+// redelivered once the channels close.
+func (c *Consumer) Shutdown(ctx context.Context) error {
+	c.mu.Lock()
+	subs := c.subscriptions
+	c.subscriptions = nil
+	c.stopping = true
+	c.mu.Unlock()
+
+	for _, s := range subs {
+		if err := s.ch.Cancel(s.tag, false); err != nil {
+			log.Printf("Failed to cancel consumer %s: %v", s.tag, err)
+		}
+	}
+	done := make(chan struct{})
+	go func() {
+		c.running.Wait()
+		close(done)
+	}()
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	for _, s := range subs {
+		s.ch.Close()
+	}
+	return err
+}
+
+// Check reports queues whose subscription ended other than by Shutdown,
+// such as when the broker closed the channel. Their messages are no longer
+// being handled.
+func (c *Consumer) Check(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.lost) > 0 {
+		return fmt.Errorf("stopped consuming %v", c.lost)
+	}
+	return nil
+}
+
+func (c *Consumer) deliver(ch *amqp.Channel, confirms <-chan amqp.Confirmation, queue string, d amqp.Delivery, h Handler) {
+	err := call(h, d.Body)
+	if err == nil {
+		if ackErr := d.Ack(false); ackErr != nil {
+			log.Printf("Failed to ack message on %s: %v", queue, ackErr)
+		}
+		return
+	}
+
+	attempts := Attempts(d.Headers) + 1
+	headers := amqp.Table{}
+	for k, v := range d.Headers {
+		headers[k] = v
+	}
+	headers[HeaderAttempts] = int32(attempts)
+	headers[HeaderLastError] = err.Error()
+	headers[HeaderQueue] = queue
+
+	msg := amqp.Publishing{
+		ContentType:  d.ContentType,
+		DeliveryMode: amqp.Persistent,
+		Headers:      headers,
+		Body:         d.Body,
+	}
+	var target string
+	if IsPermanent(err) || attempts >= c.MaxAttempts {
+		target = DeadLetterQueue(queue)
+		headers[HeaderFailedAt] = time.Now().UTC().Format(time.RFC3339)
+		log.Printf("Dead-lettering message on %s after %d attempt(s): %v", queue, attempts, err)
+	} else {
+		delay := c.retryDelay(attempts)
+		target = RetryQueue(queue, delay)
+		log.Printf("Retrying message on %s in %s (attempt %d of %d): %v", queue, delay, attempts, c.MaxAttempts, err)
+	}
+
+	if pubErr := publishConfirmed(ch, confirms, target, msg); pubErr != nil {
+		// Leave the message on the queue rather than lose it
+		log.Printf("Failed to move message to %s: %v", target, pubErr)
+		if nackErr := d.Nack(false, true); nackErr != nil {
+			log.Printf("Failed to nack message on %s: %v", queue, nackErr)
+		}
+		return
+	}
+	if ackErr := d.Ack(false); ackErr != nil {
+		log.Printf("Failed to ack message on %s: %v", queue, ackErr)
+	}
+}
+
+// publishConfirmed publishes msg to queue and waits for the broker to
+// confirm it. The channel's confirmations are read only here, so each one
+// belongs to the message just published.
+func publishConfirmed(ch *amqp.Channel, confirms <-chan amqp.Confirmation, queue string, msg amqp.Publishing) error {
+	if err := ch.Publish("", queue, false, false, msg); err != nil {
+		return err
+	}
+	c, ok := <-confirms
+	if !ok {
+		return errors.New("channel closed before the broker confirmed the message")
+	}
+	if !c.Ack {
+		return fmt.Errorf("broker rejected message for %s", queue)
+	}
+	return nil
+}
+
+// call runs the handler, turning a panic into an error.
+func call(h Handler, body []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
+	return h(body)
+}
+
+func (c *Consumer) retryDelay(attempt int) time.Duration {
+	d := c.RetryDelay
+	for i := 1; i < attempt && d < c.MaxRetryDelay; i++ {
+		d *= 2
+	}
+	if d > c.MaxRetryDelay {
+		d = c.MaxRetryDelay
+	}
+	return d
+}
+
+// retryDelays returns the distinct delays messages can be retried after, one
+// for each retry queue.
+func (c *Consumer) retryDelays() []time.Duration {
+	var delays []time.Duration
+	for attempt := 1; attempt < c.MaxAttempts; attempt++ {
+		d := c.retryDelay(attempt)
+		if len(delays) == 0 || delays[len(delays)-1] != d {
+			delays = append(delays, d)
+		}
+	}
+	return delays
+}
+
+// Attempts returns how many times a message has failed so far.
+func Attempts(headers amqp.Table) int {
+	switch v := headers[HeaderAttempts].(type) {
+	case int8:
+		return int(v)
+	case int16:
+		return int(v)
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case int:
+		return v
+	}
+	return 0
+}
@@ -0,0 +1,82 @@
+package consumer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+func TestCallRecoversPanic(t *testing.T) {
+	err := call(func([]byte) error { panic("boom") }, nil)
+	if err == nil {
+		t.Fatal("expected an error from a panicking handler")
+	}
+	if IsPermanent(err) {
+		t.Error("expected a panic to be retried")
+	}
+}
+
+func TestPermanent(t *testing.T) {
+	base := errors.New("bad json")
+	err := Permanent(base)
+	if !IsPermanent(err) || !errors.Is(err, base) {
+		t.Errorf("expected a permanent error wrapping %v, got %v", base, err)
+	}
+	if IsPermanent(base) {
+		t.Error("expected a plain error not to be permanent")
+	}
+	if Permanent(nil) != nil {
+		t.Error("expected Permanent(nil) to be nil")
+	}
+}
+
+func TestAttempts(t *testing.T) {
+	cases := []struct {
+		headers amqp.Table
+		want    int
+	}{
+		{nil, 0},
+		{amqp.Table{HeaderAttempts: int32(3)}, 3},
+		{amqp.Table{HeaderAttempts: int64(4)}, 4},
+		{amqp.Table{HeaderAttempts: "x"}, 0},
+	}
+	for _, tc := range cases {
+		if got := Attempts(tc.headers); got != tc.want {
+			t.Errorf("Attempts(%v) = %d, want %d", tc.headers, got, tc.want)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	c := &Consumer{RetryDelay: time.Second, MaxRetryDelay: 5 * time.Second}
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if got := c.retryDelay(i + 1); got != w {
+			t.Errorf("retryDelay(%d) = %s, want %s", i+1, got, w)
+		}
+	}
+}
+
+func TestRetryDelays(t *testing.T) {
+	cases := []struct {
+		maxAttempts int
+		want        []time.Duration
+	}{
+		{1, nil},
+		{3, []time.Duration{time.Second, 2 * time.Second}},
+		// Delays capped at MaxRetryDelay share a queue
+		{7, []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}},
+	}
+	for _, tc := range cases {
+		c := &Consumer{MaxAttempts: tc.maxAttempts, RetryDelay: time.Second, MaxRetryDelay: 5 * time.Second}
+		if got := c.retryDelays(); fmt.Sprint(got) != fmt.Sprint(tc.want) {
+			t.Errorf("retryDelays with %d attempts = %v, want %v", tc.maxAttempts, got, tc.want)
+		}
+	}
+	if got, want := RetryQueue("payments", 2*time.Second), "payments.retry.2s"; got != want {
+		t.Errorf("RetryQueue = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,112 @@
+package consumer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+// DeadLetter is a message sitting in a dead-letter queue.
+type DeadLetter struct {
+	Queue     string
+	Attempts  int
+	LastError string
+	FailedAt  time.Time
+	Body      []byte
+}
+
+// Inspect returns up to limit messages from the dead-letter queue of queue
+// without removing them.
+func Inspect(conn *amqp.Connection, queue string, limit int) ([]DeadLetter, error) {
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, err
+	}
+	// Closing the channel returns the unacknowledged messages to the queue
+	defer ch.Close()
+
+	if err := Declare(ch, queue); err != nil {
+		return nil, err
+	}
+
+	var out []DeadLetter
+	for len(out) < limit {
+		d, ok, err := ch.Get(DeadLetterQueue(queue), false)
+		if err != nil {
+			return out, err
+		}
+		if !ok {
+			break
+		}
+		out = append(out, deadLetter(queue, d))
+	}
+	return out, nil
+}
+
+// Replay moves up to limit messages from the dead-letter queue of queue back
+// onto queue with their attempt count reset, and reports how many it moved.
+func Replay(conn *amqp.Connection, queue string, limit int) (int, error) {
+	ch, err := conn.Channel()
+	if err != nil {
+		return 0, err
+	}
+	defer ch.Close()
+
+	if err := Declare(ch, queue); err != nil {
+		return 0, err
+	}
+	if err := ch.Confirm(false); err != nil {
+		return 0, err
+	}
+	confirms := ch.NotifyPublish(make(chan amqp.Confirmation, 1))
+
+	replayed := 0
+	for replayed < limit {
+		d, ok, err := ch.Get(DeadLetterQueue(queue), false)
+		if err != nil {
+			return replayed, err
+		}
+		if !ok {
+			break
+		}
+
+		headers := amqp.Table{}
+		for k, v := range d.Headers {
+			headers[k] = v
+		}
+		delete(headers, HeaderAttempts)
+		delete(headers, HeaderLastError)
+		delete(headers, HeaderFailedAt)
+
+		err = ch.Publish("", queue, false, false, amqp.Publishing{
+			ContentType:  d.ContentType,
+			DeliveryMode: amqp.Persistent,
+			Headers:      headers,
+			Body:         d.Body,
+		})
+		if err != nil {
+			return replayed, err
+		}
+		// Only drop the dead letter once the broker holds the replayed copy
+		if c := <-confirms; !c.Ack {
+			return replayed, fmt.Errorf("broker rejected replayed message for %s", queue)
+		}
+		if err := d.Ack(false); err != nil {
+			return replayed, err
+		}
+		replayed++
+	}
+	return replayed, nil
+}
+
+func deadLetter(queue string, d amqp.Delivery) DeadLetter {
+	dl := DeadLetter{Queue: queue, Attempts: Attempts(d.Headers), Body: d.Body}
+	if s, ok := d.Headers[HeaderLastError].(string); ok {
+		dl.LastError = s
+	}
+	if s, ok := d.Headers[HeaderFailedAt].(string); ok {
+		dl.FailedAt, _ = time.Parse(time.RFC3339, s)
+	}
+	return dl
+}
@@ -0,0 +1,101 @@
+// Package events defines the envelope every message on the bus travels in and
+// the versioned payloads carried inside it. Payloads are checked against the
+// JSON Schemas under schemas/ when a message is built and again when it is
+// consumed.
+//
+// A payload version changes only in a way old consumers cannot read, such as
+// removing or retyping a field; adding an optional field does not need one.
+// During a rollout a producer keeps publishing the old version until every
+// consumer handles the new one.
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Envelope wraps the payload of a bus message.
+type Envelope struct {
+	ID            string          `json:"id"`
+	Type          string          `json:"type"`
+	Version       int             `json:"version"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	Producer      string          `json:"producer"`
+	CorrelationID string          `json:"correlation_id,omitempty"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// Source builds the envelopes of one producing service.
+type Source struct {
+	Producer string
+}
+
+// New wraps payload as version of the message type. The correlation ID ties
+// together the messages caused by one operation and may be empty.
+func (s Source) New(typ string, version int, correlationID string, payload interface{}) (*Envelope, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("events: marshal %s: %w", typ, err)
+	}
+	if err := Validate(typ, version, raw); err != nil {
+		return nil, fmt.Errorf("events: %w", err)
+	}
+	return &Envelope{
+		ID:            uuid.New().String(),
+		Type:          typ,
+		Version:       version,
+		OccurredAt:    time.Now().UTC(),
+		Producer:      s.Producer,
+		CorrelationID: correlationID,
+		Payload:       raw,
+	}, nil
+}
+
+// Deferred returns an outbox payload that is wrapped in an envelope only when
+// it is marshalled, so build sees fields assigned by the write that records
+// the message, such as an ID returned on insert. A schema failure then fails
+// the write.
+func (s Source) Deferred(typ string, version int, build func() (correlationID string, payload interface{})) json.Marshaler {
+	return deferred{source: s, typ: typ, version: version, build: build}
+}
+
+type deferred struct {
+	source  Source
+	typ     string
+	version int
+	build   func() (string, interface{})
+}
+
+func (d deferred) MarshalJSON() ([]byte, error) {
+	correlationID, payload := d.build()
+	env, err := d.source.New(d.typ, d.version, correlationID, payload)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(env)
+}
+
+// Decode reads a message body. A body that is not an envelope was published
+// before envelopes were introduced; it is returned as version 0 with the
+// whole body as payload and an empty type.
+func Decode(body []byte) (*Envelope, error) {
+	var env Envelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		return nil, fmt.Errorf("events: %w", err)
+	}
+	if env.Type == "" || env.Version < 1 || len(env.Payload) == 0 {
+		return &Envelope{Payload: body}, nil
+	}
+	if err := Validate(env.Type, env.Version, env.Payload); err != nil {
+		return nil, fmt.Errorf("events: message %s: %w", env.ID, err)
+	}
+	return &env, nil
+}
+
+// Unmarshal decodes the payload into v.
+func (e *Envelope) Unmarshal(v interface{}) error {
+	return json.Unmarshal(e.Payload, v)
+}
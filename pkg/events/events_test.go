@@ -0,0 +1,151 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"muchway/pkg/consumer"
+	"muchway/pkg/money"
+	"muchway/pkg/topology"
+)
+
+var source = Source{Producer: "test"}
+
+func TestRegisteredSchemasLoad(t *testing.T) {
+	for key, file := range registry {
+		if schemas[file] == nil {
+			t.Errorf("schema %s for %s v%d not loaded", file, key.typ, key.version)
+		}
+	}
+}
+
+func TestNewValidatesPayload(t *testing.T) {
+	bet := BetV1{ID: "b1", UserID: "u1", EventID: "e1", SelectionID: "s1", Amount: money.MustParse("10"),
+		Odds: 2.5, Status: "pending", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	env, err := source.New(topology.BetCreated, BetVersion, "b1", bet)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if env.ID == "" || env.Producer != "test" || env.Type != topology.BetCreated || env.Version != 1 {
+		t.Errorf("unexpected envelope %+v", env)
+	}
+
+	bet.Odds = 0.5
+	if _, err := source.New(topology.BetCreated, BetVersion, "", bet); err == nil {
+		t.Error("expected odds below 1 to fail the schema")
+	}
+	if _, err := source.New(topology.BetCreated, 2, "", bet); !errors.Is(err, ErrUnknownSchema) {
+		t.Errorf("expected ErrUnknownSchema, got %v", err)
+	}
+	if _, err := source.New(topology.UserDeleted, UserDeletedVersion, "", map[string]string{}); err == nil {
+		t.Error("expected a missing username to fail the schema")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		typ     string
+		payload string
+		ok      bool
+	}{
+		{"valid", topology.EventSettled, `{"event_id":"e1","winning_selection_ids":["s1"],"settled_at":"2024-05-01T10:00:00Z"}`, true},
+		{"bad date", topology.EventSettled, `{"event_id":"e1","settled_at":"yesterday"}`, false},
+		{"bad item", topology.EventSettled, `{"event_id":"e1","winning_selection_ids":[1],"settled_at":"2024-05-01T10:00:00Z"}`, false},
+		{"enum", topology.PaymentRequested, `{"user_id":"1","amount":{"amount":"5.00","currency":"USD"},"payment_type":"refund"}`, false},
+		{"nested ref", topology.PaymentRequested, `{"user_id":"1","amount":{"amount":"5.00"},"payment_type":"deposit"}`, false},
+		{"integer", topology.UserLoggedIn, `{"id":1.5,"username":"a","logged_in_at":"2024-05-01T10:00:00Z"}`, false},
+		{"not an object", topology.UserDeleted, `"alice"`, false},
+		{"permanent exclusion", topology.UserExcluded, `{"id":1,"since":"2024-05-01T10:00:00Z","until":null}`, true},
+		{"missing until", topology.UserExcluded, `{"id":1,"since":"2024-05-01T10:00:00Z"}`, false},
+		{"credentials", topology.UserCreated, `{"id":1,"username":"a","email":"a@example.com","role":"user","password":"$2a$10$x"}`, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Validate(tc.typ, 1, []byte(tc.payload))
+			if tc.ok && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !tc.ok && err == nil {
+				t.Error("expected a validation error")
+			}
+		})
+	}
+}
+
+func TestHandleVersions(t *testing.T) {
+	var got []int
+	h := Handle(topology.EventSettled, Versions{
+		0: func(env *Envelope) error { got = append(got, 0); return nil },
+		1: func(env *Envelope) error {
+			var p EventSettledV1
+			if err := env.Unmarshal(&p); err != nil {
+				return err
+			}
+			if p.EventID != "e1" {
+				t.Errorf("expected event e1, got %q", p.EventID)
+			}
+			got = append(got, 1)
+			return nil
+		},
+	})
+
+	env, err := source.New(topology.EventSettled, EventSettledVersion, "e1", EventSettledV1{EventID: "e1", SettledAt: time.Now()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := json.Marshal(env)
+	if err := h(body); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := h([]byte(`{"event_id":"e1","settled_at":"2024-05-01T10:00:00Z"}`)); err != nil {
+		t.Fatalf("unexpected error for a legacy body: %v", err)
+	}
+	if len(got) != 2 || got[0] != 1 || got[1] != 0 {
+		t.Errorf("expected v1 then legacy handler, got %v", got)
+	}
+
+	env.Version = 2
+	body, _ = json.Marshal(env)
+	if err := h(body); !consumer.IsPermanent(err) {
+		t.Errorf("expected an unknown version to be dead-lettered, got %v", err)
+	}
+}
+
+func TestHandleRejectsOtherType(t *testing.T) {
+	h := Handle(topology.UserDeleted, Versions{1: func(*Envelope) error { return nil }})
+	env, err := source.New(topology.UserCreated, UserVersion, "", UserV1{ID: 1, Username: "alice", Email: "a@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := json.Marshal(env)
+	if err := h(body); !consumer.IsPermanent(err) {
+		t.Errorf("expected a message of another type to be dead-lettered, got %v", err)
+	}
+}
+
+func TestDeferredReadsPayloadWhenMarshalled(t *testing.T) {
+	user := &UserV1{Username: "alice", Email: "a@example.com"}
+	msg := source.Deferred(topology.UserCreated, UserVersion, func() (string, interface{}) {
+		return user.Username, user
+	})
+	if _, err := json.Marshal(msg); err == nil {
+		t.Error("expected a user without an ID to fail the schema")
+	}
+
+	user.ID = 7
+	body, err := json.Marshal(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	env, err := Decode(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got UserV1
+	if err := env.Unmarshal(&got); err != nil || got.ID != 7 {
+		t.Errorf("expected the ID assigned before marshalling, got %+v (%v)", got, err)
+	}
+}
@@ -0,0 +1,38 @@
+package events
+
+import (
+	"fmt"
+	"log"
+
+	"muchway/pkg/consumer"
+)
+
+// Versions maps the payload versions a consumer understands to the function
+// handling each. Version 0 stands for bodies published without an envelope.
+type Versions map[int]func(env *Envelope) error
+
+// Handle returns a consumer handler for a queue carrying messages of type
+// typ. Bodies without an envelope are handled as version 0. A message that
+// fails its schema, is of another type or has no handler for its version is
+// dead-lettered.
+func Handle(typ string, versions Versions) consumer.Handler {
+	return func(body []byte) error {
+		env, err := Decode(body)
+		if err != nil {
+			return consumer.Permanent(err)
+		}
+		if env.Version == 0 {
+			env.Type = typ
+		}
+		if env.Type != typ {
+			return consumer.Permanent(fmt.Errorf("events: expected %s, got %s", typ, env.Type))
+		}
+
+		h, ok := versions[env.Version]
+		if !ok {
+			log.Printf("No handler for %s v%d (message %s)", env.Type, env.Version, env.ID)
+			return consumer.Permanent(fmt.Errorf("events: unsupported %s version %d", env.Type, env.Version))
+		}
+		return h(env)
+	}
+}
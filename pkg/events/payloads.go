@@ -0,0 +1,96 @@
+package events
+
+import (
+	"time"
+
+	"muchway/pkg/money"
+)
+
+// Payload versions currently published.
+const (
+	BetVersion              = 1
+	BetRejectedVersion      = 1
+	UserVersion             = 1
+	UserDeletedVersion      = 1
+	UserLoggedInVersion     = 1
+	UserExcludedVersion     = 1
+	EventVersion            = 1
+	EventSettledVersion     = 1
+	PaymentRequestedVersion = 1
+)
+
+// BetV1 is the payload of bet.created, bet.updated and bet.deleted.
+type BetV1 struct {
+	ID          string      `json:"id"`
+	UserID      string      `json:"user_id"`
+	EventID     string      `json:"event_id"`
+	SelectionID string      `json:"selection_id"`
+	Amount      money.Money `json:"amount"`
+	Odds        float64     `json:"odds"`
+	Status      string      `json:"status"`
+	Payout      money.Money `json:"payout"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// BetRejectedV1 is the payload of bet.rejected.
+type BetRejectedV1 struct {
+	Bet    BetV1  `json:"bet"`
+	Reason string `json:"reason"`
+}
+
+// UserV1 is the payload of user.created and user.updated. It never carries
+// the password hash.
+type UserV1 struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+}
+
+// UserDeletedV1 is the payload of user.deleted.
+type UserDeletedV1 struct {
+	Username string `json:"username"`
+}
+
+// UserLoggedInV1 is the payload of user.logged_in.
+type UserLoggedInV1 struct {
+	ID         int64     `json:"id"`
+	Username   string    `json:"username"`
+	LoggedInAt time.Time `json:"logged_in_at"`
+}
+
+// UserExcludedV1 is the payload of user.excluded, published when a user
+// excludes themselves or extends an exclusion. Until is null for a
+// permanent exclusion.
+type UserExcludedV1 struct {
+	ID    int64      `json:"id"`
+	Since time.Time  `json:"since"`
+	Until *time.Time `json:"until"`
+}
+
+// EventV1 is the payload of event.created.
+type EventV1 struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	StartTime time.Time `json:"start_time"`
+	Status    string    `json:"status"`
+	WinnerID  string    `json:"winner_id,omitempty"`
+}
+
+// EventSettledV1 is the payload of event.settled.
+type EventSettledV1 struct {
+	EventID             string    `json:"event_id"`
+	WinnerID            string    `json:"winner_id,omitempty"`
+	WinningSelectionIDs []string  `json:"winning_selection_ids,omitempty"`
+	SettledAt           time.Time `json:"settled_at"`
+}
+
+// PaymentRequestedV1 is the payload of payment.requested.
+type PaymentRequestedV1 struct {
+	OrderID        string      `json:"order_id,omitempty"`
+	UserID         string      `json:"user_id"`
+	Amount         money.Money `json:"amount"`
+	PaymentType    string      `json:"payment_type"`
+	IdempotencyKey string      `json:"idempotency_key,omitempty"`
+}
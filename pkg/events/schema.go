@@ -0,0 +1,238 @@
+package events
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"muchway/pkg/topology"
+)
+
+// ErrUnknownSchema is returned for a message type and version that has no
+// schema in the registry.
+var ErrUnknownSchema = errors.New("no schema for message type and version")
+
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+type schemaKey struct {
+	typ     string
+	version int
+}
+
+// registry maps every message type and version to its JSON Schema under
+// schemas/. Types that share a payload share a schema file.
+var registry = map[schemaKey]string{
+	{topology.BetCreated, 1}:       "bet.v1.json",
+	{topology.BetUpdated, 1}:       "bet.v1.json",
+	{topology.BetDeleted, 1}:       "bet.v1.json",
+	{topology.BetRejected, 1}:      "bet_rejected.v1.json",
+	{topology.UserCreated, 1}:      "user.v1.json",
+	{topology.UserUpdated, 1}:      "user.v1.json",
+	{topology.UserDeleted, 1}:      "user_deleted.v1.json",
+	{topology.UserLoggedIn, 1}:     "user_logged_in.v1.json",
+	{topology.UserExcluded, 1}:     "user_excluded.v1.json",
+	{topology.EventCreated, 1}:     "event.v1.json",
+	{topology.EventSettled, 1}:     "event_settled.v1.json",
+	{topology.PaymentRequested, 1}: "payment_requested.v1.json",
+}
+
+var schemas = map[string]*schema{}
+
+func init() {
+	for _, file := range registry {
+		if _, ok := schemas[file]; ok {
+			continue
+		}
+		data, err := schemaFiles.ReadFile("schemas/" + file)
+		if err != nil {
+			panic(fmt.Sprintf("events: %v", err))
+		}
+		var s schema
+		if err := json.Unmarshal(data, &s); err != nil {
+			panic(fmt.Sprintf("events: parse schema %s: %v", file, err))
+		}
+		schemas[file] = &s
+	}
+}
+
+// Validate checks a payload against the schema registered for the message
+// type and version.
+func Validate(typ string, version int, payload []byte) error {
+	file, ok := registry[schemaKey{typ, version}]
+	if !ok {
+		return fmt.Errorf("%w: %s v%d", ErrUnknownSchema, typ, version)
+	}
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Errorf("%s v%d: %w", typ, version, err)
+	}
+	root := schemas[file]
+	if err := root.validate(root, "payload", v); err != nil {
+		return fmt.Errorf("%s v%d: %w", typ, version, err)
+	}
+	return nil
+}
+
+// schema is the subset of JSON Schema the message schemas use: type,
+// properties, required, additionalProperties (as a boolean), items, enum,
+// minimum, minLength, format "date-time" and local $ref into definitions.
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 schemaTypes        `json:"type"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	AdditionalProperties *bool              `json:"additionalProperties"`
+	Items                *schema            `json:"items"`
+	Enum                 []interface{}      `json:"enum"`
+	Minimum              *float64           `json:"minimum"`
+	MinLength            *int               `json:"minLength"`
+	Format               string             `json:"format"`
+	Definitions          map[string]*schema `json:"definitions"`
+}
+
+// schemaTypes accepts "type" as a single name or a list of names.
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*t = schemaTypes{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*t = many
+	return nil
+}
+
+func (s *schema) validate(root *schema, path string, v interface{}) error {
+	if s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, "#/definitions/")
+		def, ok := root.Definitions[name]
+		if !ok || name == s.Ref {
+			return fmt.Errorf("%s: unresolvable $ref %q", path, s.Ref)
+		}
+		return def.validate(root, path, v)
+	}
+
+	if len(s.Type) > 0 {
+		matched := false
+		for _, t := range s.Type {
+			if hasType(v, t) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("%s: expected %s", path, strings.Join(s.Type, " or "))
+		}
+	}
+
+	if len(s.Enum) > 0 {
+		found := false
+		for _, e := range s.Enum {
+			if fmt.Sprint(e) == fmt.Sprint(v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not one of %v", path, v, s.Enum)
+		}
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				return fmt.Errorf("%s: missing required property %q", path, name)
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			prop, ok := s.Properties[name]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					return fmt.Errorf("%s: unexpected property %q", path, name)
+				}
+				continue
+			}
+			if err := prop.validate(root, path+"."+name, v[name]); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				if err := s.Items.validate(root, fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+					return err
+				}
+			}
+		}
+	case string:
+		if s.MinLength != nil && len([]rune(v)) < *s.MinLength {
+			return fmt.Errorf("%s: shorter than %d characters", path, *s.MinLength)
+		}
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, v); err != nil {
+				return fmt.Errorf("%s: not a date-time: %q", path, v)
+			}
+		}
+	case json.Number:
+		if s.Minimum != nil {
+			f, err := v.Float64()
+			if err != nil || f < *s.Minimum {
+				return fmt.Errorf("%s: less than %v", path, *s.Minimum)
+			}
+		}
+	}
+	return nil
+}
+
+func hasType(v interface{}, t string) bool {
+	switch t {
+	case "object":
+		_, ok := v.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := v.([]interface{})
+		return ok
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "null":
+		return v == nil
+	case "number":
+		_, ok := v.(json.Number)
+		return ok
+	case "integer":
+		n, ok := v.(json.Number)
+		if !ok {
+			return false
+		}
+		if _, err := n.Int64(); err == nil {
+			return true
+		}
+		f, err := n.Float64()
+		return err == nil && f == math.Trunc(f)
+	}
+	return false
+}
@@ -0,0 +1,243 @@
+// Package health reports whether a service and the dependencies it needs
+// are working, over the standard gRPC health service (grpc.health.v1) and
+// over HTTP:
+//
+//	/healthz  200 while the process is up
+//	/readyz   200 while every dependency check passes, 503 otherwise
+//
+// A Monitor runs its checks periodically and sets the serving status of the
+// service's gRPC services, and of the server as a whole (""), from the
+// results.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Checker reports whether a dependency is usable. sql.DB.PingContext is
+// one.
+type Checker func(ctx context.Context) error
+
+// CheckTimeout bounds each check.
+const CheckTimeout = 2 * time.Second
+
+type check struct {
+	name string
+	fn   Checker
+}
+
+// Monitor runs dependency checks and publishes the result.
+type Monitor struct {
+	services []string
+	server   *grpchealth.Server
+
+	mu       sync.Mutex
+	checks   []check
+	results  map[string]error
+	checked  bool
+	stopping bool
+}
+
+// NewMonitor returns a Monitor for the named gRPC services, such as
+// "bet.BetService". They report NOT_SERVING until the first checks pass.
+func NewMonitor(services ...string) *Monitor {
+	m := &Monitor{services: services, server: grpchealth.NewServer(), results: map[string]error{}}
+	m.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return m
+}
+
+// Add adds a dependency check.
+func (m *Monitor) Add(name string, fn Checker) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.checks = append(m.checks, check{name, fn})
+}
+
+// Register serves the gRPC health service on s.
+func (m *Monitor) Register(s *grpc.Server) {
+	healthpb.RegisterHealthServer(s, m.server)
+}
+
+// Run checks the dependencies straight away and then every interval until
+// ctx is done.
+func (m *Monitor) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		m.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check runs every check once, concurrently, and updates the status.
+func (m *Monitor) Check(ctx context.Context) {
+	m.mu.Lock()
+	checks := m.checks
+	m.mu.Unlock()
+
+	results := make(map[string]error, len(checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, c := range checks {
+		wg.Add(1)
+		go func(c check) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, CheckTimeout)
+			defer cancel()
+			err := c.fn(ctx)
+			mu.Lock()
+			results[c.name] = err
+			mu.Unlock()
+		}(c)
+	}
+	wg.Wait()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for name, err := range results {
+		if prev, seen := m.results[name]; err != nil && (!seen || prev == nil) {
+			log.Printf("Health check %s failing: %v", name, err)
+		} else if err == nil && seen && prev != nil {
+			log.Printf("Health check %s recovered", name)
+		}
+	}
+	m.results = results
+	m.checked = true
+	if m.stopping {
+		return
+	}
+	if m.failing() == nil {
+		m.setStatus(healthpb.HealthCheckResponse_SERVING)
+	} else {
+		m.setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
+// Shutdown reports NOT_SERVING from now on, so that load balancers stop
+// sending traffic while the service drains.
+func (m *Monitor) Shutdown() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stopping = true
+	m.server.Shutdown()
+}
+
+// Ready returns an error naming the failing checks, if any.
+func (m *Monitor) Ready() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	switch {
+	case m.stopping:
+		return errors.New("shutting down")
+	case !m.checked:
+		return errors.New("not checked yet")
+	}
+	return m.failing()
+}
+
+// failing must be called with m.mu held.
+func (m *Monitor) failing() error {
+	var names []string
+	for name, err := range m.results {
+		if err != nil {
+			names = append(names, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+	return fmt.Errorf("failing checks: %v", names)
+}
+
+// setStatus must be called with m.mu held, or before m is shared.
+func (m *Monitor) setStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	m.server.SetServingStatus("", status)
+	for _, s := range m.services {
+		m.server.SetServingStatus(s, status)
+	}
+}
+
+// Handler returns a handler serving only /healthz and /readyz, for a
+// service without an HTTP server of its own.
+func (m *Monitor) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", m.ServeLive)
+	mux.HandleFunc("/readyz", m.ServeReady)
+	return mux
+}
+
+// ServeLive serves /healthz.
+func (m *Monitor) ServeLive(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, "ok")
+}
+
+// readiness is the body of /readyz.
+type readiness struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// ServeReady serves /readyz.
+func (m *Monitor) ServeReady(w http.ResponseWriter, r *http.Request) {
+	body := readiness{Status: "ok", Checks: map[string]string{}}
+	code := http.StatusOK
+	if err := m.Ready(); err != nil {
+		body.Status = err.Error()
+		code = http.StatusServiceUnavailable
+	}
+	m.mu.Lock()
+	for name, err := range m.results {
+		body.Checks[name] = "ok"
+		if err != nil {
+			body.Checks[name] = err.Error()
+		}
+	}
+	m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
+
+// GRPC checks a downstream service through its gRPC health service.
+func GRPC(conn grpc.ClientConnInterface, service string) Checker {
+	client := healthpb.NewHealthClient(conn)
+	return func(ctx context.Context) error {
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			return err
+		}
+		if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("%s is %s", service, resp.GetStatus())
+		}
+		return nil
+	}
+}
+
+// AMQP checks that a RabbitMQ connection is open.
+func AMQP(conn interface{ IsClosed() bool }) Checker {
+	return func(context.Context) error {
+		if conn.IsClosed() {
+			return errors.New("connection closed")
+		}
+		return nil
+	}
+}
@@ -0,0 +1,115 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func status(t *testing.T, m *Monitor, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := m.server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("Check(%q): %v", service, err)
+	}
+	return resp.GetStatus()
+}
+
+func ready(t *testing.T, m *Monitor) (int, readiness) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	var body readiness
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decoding /readyz: %v", err)
+	}
+	return rec.Code, body
+}
+
+func TestReadiness(t *testing.T) {
+	var dbErr error
+	m := NewMonitor("bet.BetService")
+	m.Add("postgres", func(context.Context) error { return dbErr })
+	m.Add("redis", func(context.Context) error { return nil })
+
+	if code, _ := ready(t, m); code != http.StatusServiceUnavailable {
+		t.Errorf("/readyz before the first check = %d, want 503", code)
+	}
+	if got := status(t, m, "bet.BetService"); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("status before the first check = %s, want NOT_SERVING", got)
+	}
+
+	m.Check(context.Background())
+	if code, body := ready(t, m); code != http.StatusOK || body.Checks["postgres"] != "ok" {
+		t.Errorf("/readyz = %d %+v, want 200 with postgres ok", code, body)
+	}
+	for _, service := range []string{"", "bet.BetService"} {
+		if got := status(t, m, service); got != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("status of %q = %s, want SERVING", service, got)
+		}
+	}
+
+	dbErr = errors.New("connection refused")
+	m.Check(context.Background())
+	if code, body := ready(t, m); code != http.StatusServiceUnavailable || body.Checks["postgres"] != "connection refused" || body.Checks["redis"] != "ok" {
+		t.Errorf("/readyz = %d %+v, want 503 naming postgres", code, body)
+	}
+	if got := status(t, m, "bet.BetService"); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("status with a failing check = %s, want NOT_SERVING", got)
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	m := NewMonitor("bet.BetService")
+	m.Check(context.Background())
+	m.Shutdown()
+	m.Check(context.Background())
+
+	if code, _ := ready(t, m); code != http.StatusServiceUnavailable {
+		t.Errorf("/readyz after Shutdown = %d, want 503", code)
+	}
+	if got := status(t, m, "bet.BetService"); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("status after Shutdown = %s, want NOT_SERVING", got)
+	}
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("/healthz after Shutdown = %d, want 200", rec.Code)
+	}
+}
+
+func TestGRPCChecker(t *testing.T) {
+	downstream := NewMonitor("user.UserService")
+	srv := grpc.NewServer()
+	downstream.Register(srv)
+	lis := bufconn.Listen(1 << 20)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	check := GRPC(conn, "user.UserService")
+	if err := check(context.Background()); err == nil {
+		t.Error("expected a downstream service that is not serving to fail the check")
+	}
+	downstream.Check(context.Background())
+	if err := check(context.Background()); err != nil {
+		t.Errorf("check of a serving downstream service: %v", err)
+	}
+}
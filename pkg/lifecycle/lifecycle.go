@@ -0,0 +1,259 @@
+// Package lifecycle runs a service until it receives SIGINT or SIGTERM, or
+// one of its servers fails, and then shuts it down in order so that work in
+// flight is finished rather than cut off:
+//
+//  1. the service reports itself unhealthy, then servers stop accepting
+//     calls and finish the ones they are serving;
+//  2. consumers cancel their subscriptions and finish the messages they hold;
+//  3. workers, such as the outbox relay, see their context cancelled;
+//  4. background goroutines, such as notification emails, are waited for;
+//  5. connections are closed, the last opened first.
+//
+// The steps share one deadline. A gRPC server still busy at the deadline is
+// stopped hard, and work still running is abandoned; connections are closed
+// regardless.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+type step struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+// Runner starts a service's servers and workers and shuts them down.
+type Runner struct {
+	timeout time.Duration
+
+	signalled   context.Context
+	stopSignals context.CancelFunc
+	failed      chan error
+
+	ctx         context.Context
+	stopWorkers context.CancelFunc
+	workers     sync.WaitGroup
+	background  Group
+
+	draining  []func()
+	servers   []step
+	consumers []step
+	closers   []step
+}
+
+// New returns a Runner listening for SIGINT and SIGTERM. timeout bounds the
+// whole shutdown.
+func New(timeout time.Duration) *Runner {
+	r := &Runner{timeout: timeout, failed: make(chan error, 1)}
+	r.signalled, r.stopSignals = signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	r.ctx, r.stopWorkers = context.WithCancel(context.Background())
+	return r
+}
+
+// Context is cancelled once servers and consumers have stopped. Workers and
+// the service's own loops run under it.
+func (r *Runner) Context() context.Context {
+	return r.ctx
+}
+
+// Background returns the group fire-and-forget goroutines are started in,
+// so that shutdown waits for them.
+func (r *Runner) Background() *Group {
+	return &r.background
+}
+
+// OnShutdown registers fn to be called as soon as shutdown begins, before
+// the servers stop, such as to fail health checks.
+func (r *Runner) OnShutdown(fn func()) {
+	r.draining = append(r.draining, fn)
+}
+
+// Serve runs serve on a goroutine of its own. A serve that returns an error
+// before shutdown has begun shuts the service down; stop is called to end
+// it and should return once in-flight requests are done.
+func (r *Runner) Serve(name string, serve func() error, stop func(ctx context.Context) error) {
+	r.servers = append(r.servers, step{name, stop})
+	go func() {
+		if err := serve(); err != nil {
+			r.fail(fmt.Errorf("%s: %w", name, err))
+		}
+	}()
+}
+
+// GRPC serves srv on lis. Shutdown waits for running calls with
+// GracefulStop, and cancels those still running at the deadline.
+func (r *Runner) GRPC(name string, srv *grpc.Server, lis net.Listener) {
+	log.Printf("%s listening on %s", name, lis.Addr())
+	r.Serve(name, func() error { return srv.Serve(lis) }, func(ctx context.Context) error {
+		done := make(chan struct{})
+		go func() {
+			srv.GracefulStop()
+			close(done)
+		}()
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			srv.Stop()
+			return ctx.Err()
+		}
+	})
+}
+
+// HTTP serves srv on its address. Shutdown waits for running requests, and
+// closes the connections still open at the deadline.
+func (r *Runner) HTTP(name string, srv *http.Server) {
+	log.Printf("%s listening on %s", name, srv.Addr)
+	r.Serve(name, func() error {
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}, func(ctx context.Context) error {
+		if err := srv.Shutdown(ctx); err != nil {
+			srv.Close()
+			return err
+		}
+		return nil
+	})
+}
+
+// Consumer registers the shutdown of a message consumer, called once the
+// servers have stopped. It should return when the messages being handled
+// are done.
+func (r *Runner) Consumer(name string, shutdown func(ctx context.Context) error) {
+	r.consumers = append(r.consumers, step{name, shutdown})
+}
+
+// Go runs a worker, such as a relay or a ticker, until Context is
+// cancelled. Shutdown waits for it to return.
+func (r *Runner) Go(name string, work func(ctx context.Context)) {
+	r.workers.Add(1)
+	go func() {
+		defer r.workers.Done()
+		work(r.ctx)
+		log.Printf("Stopped %s", name)
+	}()
+}
+
+// Close registers a connection to close at the end of shutdown. Closers run
+// in the reverse order of registration.
+func (r *Runner) Close(name string, fn func() error) {
+	r.closers = append(r.closers, step{name, func(context.Context) error { return fn() }})
+}
+
+// Run blocks until a signal arrives or a server fails, then shuts down. It
+// returns the server's error, or nil after a signal. A second signal during
+// shutdown kills the process.
+func (r *Runner) Run() error {
+	var cause error
+	select {
+	case <-r.signalled.Done():
+		log.Println("Shutting down")
+	case cause = <-r.failed:
+		log.Printf("Shutting down: %v", cause)
+	}
+	r.stopSignals()
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	for _, fn := range r.draining {
+		fn()
+	}
+	stopAll(ctx, r.servers)
+	stopAll(ctx, r.consumers)
+	r.stopWorkers()
+	if err := wait(ctx, &r.workers); err != nil {
+		log.Printf("Workers still running at the deadline: %v", err)
+	}
+	if err := r.background.Wait(ctx); err != nil {
+		log.Printf("Background work still running at the deadline: %v", err)
+	}
+	for i := len(r.closers) - 1; i >= 0; i-- {
+		c := r.closers[i]
+		if err := c.stop(ctx); err != nil {
+			log.Printf("Failed to close %s: %v", c.name, err)
+		}
+	}
+	log.Println("Shutdown complete")
+	return cause
+}
+
+func (r *Runner) fail(err error) {
+	select {
+	case r.failed <- err:
+	default:
+	}
+}
+
+// stopAll runs the steps concurrently and waits for them.
+func stopAll(ctx context.Context, steps []step) {
+	var wg sync.WaitGroup
+	for _, s := range steps {
+		wg.Add(1)
+		go func(s step) {
+			defer wg.Done()
+			if err := s.stop(ctx); err != nil {
+				log.Printf("Failed to stop %s cleanly: %v", s.name, err)
+				return
+			}
+			log.Printf("Stopped %s", s.name)
+		}(s)
+	}
+	wg.Wait()
+}
+
+// Group tracks fire-and-forget goroutines so that shutdown can wait for
+// them. A nil *Group just starts them.
+type Group struct {
+	wg sync.WaitGroup
+}
+
+// Go runs fn on a goroutine of its own.
+func (g *Group) Go(fn func()) {
+	if g == nil {
+		go fn()
+		return
+	}
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		fn()
+	}()
+}
+
+// Wait waits for the goroutines started so far, or until ctx is done.
+func (g *Group) Wait(ctx context.Context) error {
+	if g == nil {
+		return nil
+	}
+	return wait(ctx, &g.wg)
+}
+
+func wait(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
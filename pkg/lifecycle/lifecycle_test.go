@@ -0,0 +1,112 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+type recorder struct {
+	mu    sync.Mutex
+	steps []string
+}
+
+func (r *recorder) add(step string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.steps = append(r.steps, step)
+}
+
+func (r *recorder) String() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return strings.Join(r.steps, " ")
+}
+
+func TestShutdownOrder(t *testing.T) {
+	var rec recorder
+	r := New(time.Second)
+
+	r.Close("db", func() error { rec.add("close db"); return nil })
+	r.Close("amqp", func() error { rec.add("close amqp"); return nil })
+	r.Go("relay", func(ctx context.Context) {
+		<-ctx.Done()
+		rec.add("relay")
+	})
+	r.Consumer("queue", func(context.Context) error { rec.add("consumer"); return nil })
+	r.OnShutdown(func() { rec.add("unhealthy") })
+	r.Background().Go(func() {
+		<-r.Context().Done()
+		time.Sleep(10 * time.Millisecond)
+		rec.add("email")
+	})
+
+	stop := make(chan struct{})
+	r.Serve("api", func() error {
+		<-stop
+		return nil
+	}, func(context.Context) error {
+		rec.add("server")
+		close(stop)
+		return nil
+	})
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGTERM); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Run(); err != nil {
+		t.Fatalf("Run() = %v after a signal, want nil", err)
+	}
+	want := "unhealthy server consumer relay email close amqp close db"
+	if got := rec.String(); got != want {
+		t.Errorf("shutdown order = %q, want %q", got, want)
+	}
+}
+
+func TestServerFailureShutsDown(t *testing.T) {
+	r := New(time.Second)
+	closed := false
+	r.Close("db", func() error { closed = true; return nil })
+	r.Serve("api", func() error { return errors.New("address in use") }, func(context.Context) error { return nil })
+
+	err := r.Run()
+	if err == nil || !strings.Contains(err.Error(), "api: address in use") {
+		t.Fatalf("Run() = %v, want the server's error", err)
+	}
+	if !closed {
+		t.Error("db was not closed")
+	}
+}
+
+func TestShutdownDeadline(t *testing.T) {
+	r := New(50 * time.Millisecond)
+	closed := make(chan struct{})
+	r.Close("db", func() error { close(closed); return nil })
+	r.Background().Go(func() { time.Sleep(time.Hour) })
+	r.Serve("api", func() error { return errors.New("boom") }, func(context.Context) error { return nil })
+
+	start := time.Now()
+	r.Run()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("shutdown took %s, want it bounded by the timeout", elapsed)
+	}
+	select {
+	case <-closed:
+	default:
+		t.Error("db was not closed after the deadline")
+	}
+}
+
+func TestNilGroup(t *testing.T) {
+	var g *Group
+	done := make(chan struct{})
+	g.Go(func() { close(done) })
+	<-done
+	if err := g.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,91 @@
+package limits
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ExclusionPeriod is how long a user excludes themselves from gambling.
+type ExclusionPeriod string
+
+const (
+	ExcludeDay         ExclusionPeriod = "24h"
+	ExcludeWeek        ExclusionPeriod = "7d"
+	ExcludeSixMonths   ExclusionPeriod = "6m"
+	ExcludePermanently ExclusionPeriod = "permanent"
+)
+
+var (
+	ErrInvalidExclusion = errors.New("invalid exclusion period")
+	ErrSelfExcluded     = errors.New("account is self-excluded")
+)
+
+// Exclusion is a self-exclusion. While it is active the user cannot log in,
+// bet or deposit; withdrawals and the settlement of bets already placed go
+// on as usual.
+type Exclusion struct {
+	Since time.Time `json:"since"`
+	// Until is nil for a permanent exclusion.
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// Exclude starts an exclusion for the period at now.
+func Exclude(period ExclusionPeriod, now time.Time) (Exclusion, error) {
+	var until time.Time
+	switch period {
+	case ExcludeDay:
+		until = now.Add(24 * time.Hour)
+	case ExcludeWeek:
+		until = now.AddDate(0, 0, 7)
+	case ExcludeSixMonths:
+		until = now.AddDate(0, 6, 0)
+	case ExcludePermanently:
+		return Exclusion{Since: now}, nil
+	default:
+		return Exclusion{}, fmt.Errorf("%w: %q", ErrInvalidExclusion, period)
+	}
+	return Exclusion{Since: now, Until: &until}, nil
+}
+
+func (e Exclusion) Permanent() bool {
+	return e.Until == nil
+}
+
+// Active reports whether the exclusion is in force at now.
+func (e Exclusion) Active(now time.Time) bool {
+	return !now.Before(e.Since) && (e.Until == nil || now.Before(*e.Until))
+}
+
+// Outlasts reports whether e ends later than o. An exclusion can only be
+// extended, never cut short.
+func (e Exclusion) Outlasts(o Exclusion) bool {
+	switch {
+	case o.Until == nil:
+		return false
+	case e.Until == nil:
+		return true
+	}
+	return e.Until.After(*o.Until)
+}
+
+// Err returns an *ExcludedError for the exclusion.
+func (e Exclusion) Err() error {
+	return &ExcludedError{Until: e.Until}
+}
+
+// ExcludedError is returned for what a self-excluded user may not do.
+type ExcludedError struct {
+	Until *time.Time
+}
+
+func (e *ExcludedError) Error() string {
+	if e.Until == nil {
+		return ErrSelfExcluded.Error() + " permanently"
+	}
+	return fmt.Sprintf("%s until %s", ErrSelfExcluded, e.Until.UTC().Format(time.RFC3339))
+}
+
+func (e *ExcludedError) Is(target error) bool {
+	return target == ErrSelfExcluded
+}
@@ -0,0 +1,217 @@
+// Package limits describes the responsible gambling limits bettors set on
+// themselves: how much they may deposit, stake or lose in a day, week or
+// month, how long a session may last, and self-exclusion for a fixed period
+// or for good. user_service stores the limits and exclusions; the services
+// that take deposits and bets enforce them.
+//
+// Making a limit stricter takes effect at once. Raising or removing one only
+// takes effect after a cooling-off period, so a decision made in the heat of
+// the moment cannot be acted on straight away.
+package limits
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"muchway/pkg/money"
+)
+
+type Kind string
+
+const (
+	Deposit Kind = "deposit"
+	Loss    Kind = "loss"
+	Stake   Kind = "stake"
+	// Session limits how long a login lasts rather than an amount.
+	Session Kind = "session"
+)
+
+// Period is the rolling window a money limit applies to. Session limits
+// have no period.
+type Period string
+
+const (
+	Day   Period = "day"
+	Week  Period = "week"
+	Month Period = "month"
+)
+
+var (
+	ErrInvalidLimit  = errors.New("invalid limit")
+	ErrLimitExceeded = errors.New("limit exceeded")
+)
+
+// Window is the length of the period. A month is 30 days.
+func (p Period) Window() time.Duration {
+	switch p {
+	case Day:
+		return 24 * time.Hour
+	case Week:
+		return 7 * 24 * time.Hour
+	case Month:
+		return 30 * 24 * time.Hour
+	}
+	return 0
+}
+
+// Since is the start of the window that ends at now.
+func (p Period) Since(now time.Time) time.Time {
+	return now.Add(-p.Window())
+}
+
+func (p Period) adjective() string {
+	switch p {
+	case Day:
+		return "daily"
+	case Week:
+		return "weekly"
+	case Month:
+		return "monthly"
+	}
+	return string(p)
+}
+
+type Limit struct {
+	Kind   Kind
+	Period Period
+	// Amount applies to deposit, loss and stake limits.
+	Amount money.Money
+	// Duration applies to session limits.
+	Duration time.Duration
+	// Pending is a raise or removal waiting out its cooling-off period. The
+	// limit keeps applying as it is until then.
+	Pending *Change
+}
+
+// Change is a requested raise or removal of a limit.
+type Change struct {
+	Amount   money.Money
+	Duration time.Duration
+	// Remove drops the limit altogether.
+	Remove      bool
+	EffectiveAt time.Time
+}
+
+// Validate checks that the limit is complete: money limits need a period
+// and a positive amount, session limits a positive duration.
+func (l Limit) Validate() error {
+	switch l.Kind {
+	case Deposit, Loss, Stake:
+		if l.Period.Window() == 0 {
+			return fmt.Errorf("%w: %s limit needs a day, week or month period", ErrInvalidLimit, l.Kind)
+		}
+		if !l.Amount.IsPositive() {
+			return fmt.Errorf("%w: amount must be greater than zero", ErrInvalidLimit)
+		}
+	case Session:
+		if l.Period != "" {
+			return fmt.Errorf("%w: session limits have no period", ErrInvalidLimit)
+		}
+		if l.Duration <= 0 {
+			return fmt.Errorf("%w: duration must be greater than zero", ErrInvalidLimit)
+		}
+	default:
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidLimit, l.Kind)
+	}
+	return nil
+}
+
+// Current returns the limit as it applies at now, with a pending change
+// whose cooling-off period is over taken into account. It reports false
+// when such a change removed the limit.
+func (l Limit) Current(now time.Time) (Limit, bool) {
+	if l.Pending == nil || now.Before(l.Pending.EffectiveAt) {
+		return l, true
+	}
+	if l.Pending.Remove {
+		return Limit{}, false
+	}
+	return Limit{Kind: l.Kind, Period: l.Period, Amount: l.Pending.Amount, Duration: l.Pending.Duration}, true
+}
+
+// Request works out what becomes of current, the limit in force (nil if
+// there is none), when next is asked for at now. A new or stricter limit
+// applies at once; a more generous one waits coolingOff. A request replaces
+// any change that is still pending.
+func Request(current *Limit, next Limit, now time.Time, coolingOff time.Duration) (Limit, error) {
+	if err := next.Validate(); err != nil {
+		return Limit{}, err
+	}
+	if current == nil {
+		return next, nil
+	}
+	stricter, err := next.stricterThan(*current)
+	if err != nil {
+		return Limit{}, err
+	}
+	if stricter {
+		return next, nil
+	}
+	l := *current
+	l.Pending = &Change{Amount: next.Amount, Duration: next.Duration, EffectiveAt: now.Add(coolingOff)}
+	return l, nil
+}
+
+// Remove returns current with its removal pending until coolingOff has
+// passed.
+func Remove(current Limit, now time.Time, coolingOff time.Duration) Limit {
+	current.Pending = &Change{Remove: true, EffectiveAt: now.Add(coolingOff)}
+	return current
+}
+
+// stricterThan reports whether l allows no more than o.
+func (l Limit) stricterThan(o Limit) (bool, error) {
+	if l.Kind == Session {
+		return l.Duration <= o.Duration, nil
+	}
+	c, err := l.Amount.Cmp(o.Amount)
+	if err != nil {
+		return false, err
+	}
+	return c <= 0, nil
+}
+
+// Check returns an *ExceededError when adding amount to what was already
+// used in the period would go over the limit.
+func (l Limit) Check(used, amount money.Money) error {
+	total, err := used.Add(amount)
+	if err != nil {
+		return err
+	}
+	c, err := total.Cmp(l.Amount)
+	if err != nil {
+		return err
+	}
+	if c > 0 {
+		return &ExceededError{Limit: l, Used: used, Requested: amount}
+	}
+	return nil
+}
+
+// Of returns the limits of the given kind.
+func Of(ls []Limit, kind Kind) []Limit {
+	var out []Limit
+	for _, l := range ls {
+		if l.Kind == kind {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// ExceededError says which limit a deposit or bet would break.
+type ExceededError struct {
+	Limit     Limit
+	Used      money.Money
+	Requested money.Money
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("%s %s limit of %s reached: %s used, %s requested",
+		e.Limit.Period.adjective(), e.Limit.Kind, e.Limit.Amount.Format(), e.Used.String(), e.Requested.String())
+}
+
+func (e *ExceededError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
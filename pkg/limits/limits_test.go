@@ -0,0 +1,149 @@
+package limits
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"muchway/pkg/money"
+)
+
+var now = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func deposit(amount string) Limit {
+	return Limit{Kind: Deposit, Period: Day, Amount: money.MustParse(amount)}
+}
+
+func TestLoweringTakesEffectAtOnce(t *testing.T) {
+	current := deposit("100")
+	got, err := Request(&current, deposit("50"), now, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Amount != money.MustParse("50") || got.Pending != nil {
+		t.Errorf("got %+v, want 50.00 with nothing pending", got)
+	}
+
+	got, err = Request(nil, deposit("500"), now, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Amount != money.MustParse("500") || got.Pending != nil {
+		t.Errorf("a first limit should apply at once, got %+v", got)
+	}
+}
+
+func TestRaisingWaitsForCoolingOff(t *testing.T) {
+	current := deposit("100")
+	got, err := Request(&current, deposit("200"), now, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Amount != money.MustParse("100") {
+		t.Errorf("amount = %s, want the old 100.00 until the raise is due", got.Amount)
+	}
+	if got.Pending == nil || got.Pending.Amount != money.MustParse("200") {
+		t.Fatalf("pending = %+v, want a raise to 200.00", got.Pending)
+	}
+
+	if l, _ := got.Current(now.Add(23 * time.Hour)); l.Amount != money.MustParse("100") {
+		t.Errorf("before cooling-off: %s, want 100.00", l.Amount)
+	}
+	if l, _ := got.Current(now.Add(24 * time.Hour)); l.Amount != money.MustParse("200") || l.Pending != nil {
+		t.Errorf("after cooling-off: %+v, want 200.00", l)
+	}
+
+	// Lowering again cancels the raise
+	again, err := Request(&got, deposit("80"), now.Add(time.Hour), 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Amount != money.MustParse("80") || again.Pending != nil {
+		t.Errorf("got %+v, want 80.00 with nothing pending", again)
+	}
+}
+
+func TestRemoveWaitsForCoolingOff(t *testing.T) {
+	l := Remove(Limit{Kind: Session, Duration: time.Hour}, now, 24*time.Hour)
+	if _, ok := l.Current(now.Add(time.Hour)); !ok {
+		t.Error("limit removed before cooling-off")
+	}
+	if _, ok := l.Current(now.Add(24 * time.Hour)); ok {
+		t.Error("limit still in force after cooling-off")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	l := deposit("100")
+	if err := l.Check(money.MustParse("60"), money.MustParse("40")); err != nil {
+		t.Errorf("reaching the limit exactly: %v", err)
+	}
+	err := l.Check(money.MustParse("60"), money.MustParse("40.01"))
+	var exceeded *ExceededError
+	if !errors.As(err, &exceeded) || !errors.Is(err, ErrLimitExceeded) {
+		t.Fatalf("got %v, want an ExceededError", err)
+	}
+	if want := "daily deposit limit of 100.00 USD reached: 60.00 used, 40.01 requested"; err.Error() != want {
+		t.Errorf("message = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	bad := []Limit{
+		{Kind: Deposit, Amount: money.MustParse("10")},
+		{Kind: Stake, Period: Week},
+		{Kind: Session, Period: Day, Duration: time.Hour},
+		{Kind: Session},
+		{Kind: "bonus", Period: Day, Amount: money.MustParse("10")},
+	}
+	for _, l := range bad {
+		if err := l.Validate(); !errors.Is(err, ErrInvalidLimit) {
+			t.Errorf("Validate(%+v) = %v, want ErrInvalidLimit", l, err)
+		}
+	}
+}
+
+func TestProtoRoundTrip(t *testing.T) {
+	current := Limit{Kind: Loss, Period: Week, Amount: money.MustParse("250")}
+	l, err := Request(&current, Limit{Kind: Loss, Period: Week, Amount: money.MustParse("300")}, now, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := FromProto(ToProto(l))
+	if got.Kind != l.Kind || got.Period != l.Period || got.Amount != l.Amount {
+		t.Errorf("got %+v, want %+v", got, l)
+	}
+	if got.Pending == nil || got.Pending.Amount != l.Pending.Amount || !got.Pending.EffectiveAt.Equal(l.Pending.EffectiveAt) {
+		t.Errorf("pending = %+v, want %+v", got.Pending, l.Pending)
+	}
+}
+
+func TestExclusion(t *testing.T) {
+	week, err := Exclude(ExcludeWeek, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !week.Active(now) || !week.Active(now.Add(6*24*time.Hour)) || week.Active(now.Add(7*24*time.Hour)) {
+		t.Errorf("a week's exclusion from %s should end at %s", now, week.Until)
+	}
+	forever, err := Exclude(ExcludePermanently, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !forever.Active(now.AddDate(50, 0, 0)) {
+		t.Error("permanent exclusion ended")
+	}
+	if !forever.Outlasts(week) || week.Outlasts(forever) {
+		t.Error("a permanent exclusion should outlast any other")
+	}
+	day, _ := Exclude(ExcludeDay, now)
+	if !week.Outlasts(day) || day.Outlasts(week) {
+		t.Error("a week should outlast a day")
+	}
+	if _, err := Exclude("1y", now); !errors.Is(err, ErrInvalidExclusion) {
+		t.Errorf("got %v, want ErrInvalidExclusion", err)
+	}
+	if err := week.Err(); !errors.Is(err, ErrSelfExcluded) || err.Error() != "account is self-excluded until 2024-03-08T12:00:00Z" {
+		t.Errorf("got %v", err)
+	}
+}
@@ -0,0 +1,366 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.30.2
+// source: limits/limits.proto
+
+package limitspb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	moneypb "muchway/pkg/money/moneypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LimitKind int32
+
+const (
+	LimitKind_LIMIT_KIND_UNSPECIFIED LimitKind = 0
+	LimitKind_LIMIT_KIND_DEPOSIT     LimitKind = 1
+	LimitKind_LIMIT_KIND_LOSS        LimitKind = 2
+	LimitKind_LIMIT_KIND_STAKE       LimitKind = 3
+	LimitKind_LIMIT_KIND_SESSION     LimitKind = 4
+)
+
+// Enum value maps for LimitKind.
+var (
+	LimitKind_name = map[int32]string{
+		0: "LIMIT_KIND_UNSPECIFIED",
+		1: "LIMIT_KIND_DEPOSIT",
+		2: "LIMIT_KIND_LOSS",
+		3: "LIMIT_KIND_STAKE",
+		4: "LIMIT_KIND_SESSION",
+	}
+	LimitKind_value = map[string]int32{
+		"LIMIT_KIND_UNSPECIFIED": 0,
+		"LIMIT_KIND_DEPOSIT":     1,
+		"LIMIT_KIND_LOSS":        2,
+		"LIMIT_KIND_STAKE":       3,
+		"LIMIT_KIND_SESSION":     4,
+	}
+)
+
+func (x LimitKind) Enum() *LimitKind {
+	p := new(LimitKind)
+	*p = x
+	return p
+}
+
+func (x LimitKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LimitKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_limits_limits_proto_enumTypes[0].Descriptor()
+}
+
+func (LimitKind) Type() protoreflect.EnumType {
+	return &file_limits_limits_proto_enumTypes[0]
+}
+
+func (x LimitKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LimitKind.Descriptor instead.
+func (LimitKind) EnumDescriptor() ([]byte, []int) {
+	return file_limits_limits_proto_rawDescGZIP(), []int{0}
+}
+
+type LimitPeriod int32
+
+const (
+	LimitPeriod_LIMIT_PERIOD_UNSPECIFIED LimitPeriod = 0
+	LimitPeriod_LIMIT_PERIOD_DAY         LimitPeriod = 1
+	LimitPeriod_LIMIT_PERIOD_WEEK        LimitPeriod = 2
+	LimitPeriod_LIMIT_PERIOD_MONTH       LimitPeriod = 3
+)
+
+// Enum value maps for LimitPeriod.
+var (
+	LimitPeriod_name = map[int32]string{
+		0: "LIMIT_PERIOD_UNSPECIFIED",
+		1: "LIMIT_PERIOD_DAY",
+		2: "LIMIT_PERIOD_WEEK",
+		3: "LIMIT_PERIOD_MONTH",
+	}
+	LimitPeriod_value = map[string]int32{
+		"LIMIT_PERIOD_UNSPECIFIED": 0,
+		"LIMIT_PERIOD_DAY":         1,
+		"LIMIT_PERIOD_WEEK":        2,
+		"LIMIT_PERIOD_MONTH":       3,
+	}
+)
+
+func (x LimitPeriod) Enum() *LimitPeriod {
+	p := new(LimitPeriod)
+	*p = x
+	return p
+}
+
+func (x LimitPeriod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LimitPeriod) Descriptor() protoreflect.EnumDescriptor {
+	return file_limits_limits_proto_enumTypes[1].Descriptor()
+}
+
+func (LimitPeriod) Type() protoreflect.EnumType {
+	return &file_limits_limits_proto_enumTypes[1]
+}
+
+func (x LimitPeriod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LimitPeriod.Descriptor instead.
+func (LimitPeriod) EnumDescriptor() ([]byte, []int) {
+	return file_limits_limits_proto_rawDescGZIP(), []int{1}
+}
+
+// Limit is a responsible gambling limit. Deposit, loss and stake limits
+// carry an amount per period; session limits carry a duration and no period.
+type Limit struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Kind            LimitKind              `protobuf:"varint,1,opt,name=kind,proto3,enum=limits.LimitKind" json:"kind,omitempty"`
+	Period          LimitPeriod            `protobuf:"varint,2,opt,name=period,proto3,enum=limits.LimitPeriod" json:"period,omitempty"`
+	Amount          *moneypb.Money         `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	DurationSeconds int64                  `protobuf:"varint,4,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	// pending is a raise or removal that takes effect after a cooling-off
+	// period.
+	Pending       *LimitChange `protobuf:"bytes,5,opt,name=pending,proto3" json:"pending,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Limit) Reset() {
+	*x = Limit{}
+	mi := &file_limits_limits_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Limit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Limit) ProtoMessage() {}
+
+func (x *Limit) ProtoReflect() protoreflect.Message {
+	mi := &file_limits_limits_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Limit.ProtoReflect.Descriptor instead.
+func (*Limit) Descriptor() ([]byte, []int) {
+	return file_limits_limits_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Limit) GetKind() LimitKind {
+	if x != nil {
+		return x.Kind
+	}
+	return LimitKind_LIMIT_KIND_UNSPECIFIED
+}
+
+func (x *Limit) GetPeriod() LimitPeriod {
+	if x != nil {
+		return x.Period
+	}
+	return LimitPeriod_LIMIT_PERIOD_UNSPECIFIED
+}
+
+func (x *Limit) GetAmount() *moneypb.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Limit) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *Limit) GetPending() *LimitChange {
+	if x != nil {
+		return x.Pending
+	}
+	return nil
+}
+
+type LimitChange struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Amount          *moneypb.Money         `protobuf:"bytes,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	DurationSeconds int64                  `protobuf:"varint,2,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	Remove          bool                   `protobuf:"varint,3,opt,name=remove,proto3" json:"remove,omitempty"`
+	// effective_at is a Unix timestamp.
+	EffectiveAt   int64 `protobuf:"varint,4,opt,name=effective_at,json=effectiveAt,proto3" json:"effective_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LimitChange) Reset() {
+	*x = LimitChange{}
+	mi := &file_limits_limits_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LimitChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LimitChange) ProtoMessage() {}
+
+func (x *LimitChange) ProtoReflect() protoreflect.Message {
+	mi := &file_limits_limits_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LimitChange.ProtoReflect.Descriptor instead.
+func (*LimitChange) Descriptor() ([]byte, []int) {
+	return file_limits_limits_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LimitChange) GetAmount() *moneypb.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *LimitChange) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *LimitChange) GetRemove() bool {
+	if x != nil {
+		return x.Remove
+	}
+	return false
+}
+
+func (x *LimitChange) GetEffectiveAt() int64 {
+	if x != nil {
+		return x.EffectiveAt
+	}
+	return 0
+}
+
+var File_limits_limits_proto protoreflect.FileDescriptor
+
+const file_limits_limits_proto_rawDesc = "" +
+	"\n" +
+	"\x13limits/limits.proto\x12\x06limits\x1a\x11money/money.proto\"\xdb\x01\n" +
+	"\x05Limit\x12%\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x11.limits.LimitKindR\x04kind\x12+\n" +
+	"\x06period\x18\x02 \x01(\x0e2\x13.limits.LimitPeriodR\x06period\x12$\n" +
+	"\x06amount\x18\x03 \x01(\v2\f.money.MoneyR\x06amount\x12)\n" +
+	"\x10duration_seconds\x18\x04 \x01(\x03R\x0fdurationSeconds\x12-\n" +
+	"\apending\x18\x05 \x01(\v2\x13.limits.LimitChangeR\apending\"\x99\x01\n" +
+	"\vLimitChange\x12$\n" +
+	"\x06amount\x18\x01 \x01(\v2\f.money.MoneyR\x06amount\x12)\n" +
+	"\x10duration_seconds\x18\x02 \x01(\x03R\x0fdurationSeconds\x12\x16\n" +
+	"\x06remove\x18\x03 \x01(\bR\x06remove\x12!\n" +
+	"\feffective_at\x18\x04 \x01(\x03R\veffectiveAt*\x82\x01\n" +
+	"\tLimitKind\x12\x1a\n" +
+	"\x16LIMIT_KIND_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12LIMIT_KIND_DEPOSIT\x10\x01\x12\x13\n" +
+	"\x0fLIMIT_KIND_LOSS\x10\x02\x12\x14\n" +
+	"\x10LIMIT_KIND_STAKE\x10\x03\x12\x16\n" +
+	"\x12LIMIT_KIND_SESSION\x10\x04*p\n" +
+	"\vLimitPeriod\x12\x1c\n" +
+	"\x18LIMIT_PERIOD_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10LIMIT_PERIOD_DAY\x10\x01\x12\x15\n" +
+	"\x11LIMIT_PERIOD_WEEK\x10\x02\x12\x16\n" +
+	"\x12LIMIT_PERIOD_MONTH\x10\x03B&Z$muchway/pkg/limits/limitspb;limitspbb\x06proto3"
+
+var (
+	file_limits_limits_proto_rawDescOnce sync.Once
+	file_limits_limits_proto_rawDescData []byte
+)
+
+func file_limits_limits_proto_rawDescGZIP() []byte {
+	file_limits_limits_proto_rawDescOnce.Do(func() {
+		file_limits_limits_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_limits_limits_proto_rawDesc), len(file_limits_limits_proto_rawDesc)))
+	})
+	return file_limits_limits_proto_rawDescData
+}
+
+var file_limits_limits_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_limits_limits_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_limits_limits_proto_goTypes = []any{
+	(LimitKind)(0),        // 0: limits.LimitKind
+	(LimitPeriod)(0),      // 1: limits.LimitPeriod
+	(*Limit)(nil),         // 2: limits.Limit
+	(*LimitChange)(nil),   // 3: limits.LimitChange
+	(*moneypb.Money)(nil), // 4: money.Money
+}
+var file_limits_limits_proto_depIdxs = []int32{
+	0, // 0: limits.Limit.kind:type_name -> limits.LimitKind
+	1, // 1: limits.Limit.period:type_name -> limits.LimitPeriod
+	4, // 2: limits.Limit.amount:type_name -> money.Money
+	3, // 3: limits.Limit.pending:type_name -> limits.LimitChange
+	4, // 4: limits.LimitChange.amount:type_name -> money.Money
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_limits_limits_proto_init() }
+func file_limits_limits_proto_init() {
+	if File_limits_limits_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_limits_limits_proto_rawDesc), len(file_limits_limits_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_limits_limits_proto_goTypes,
+		DependencyIndexes: file_limits_limits_proto_depIdxs,
+		EnumInfos:         file_limits_limits_proto_enumTypes,
+		MessageInfos:      file_limits_limits_proto_msgTypes,
+	}.Build()
+	File_limits_limits_proto = out.File
+	file_limits_limits_proto_goTypes = nil
+	file_limits_limits_proto_depIdxs = nil
+}
@@ -0,0 +1,84 @@
+package limits
+
+import (
+	"time"
+
+	"muchway/pkg/limits/limitspb"
+	"muchway/pkg/money"
+)
+
+var kinds = map[Kind]limitspb.LimitKind{
+	Deposit: limitspb.LimitKind_LIMIT_KIND_DEPOSIT,
+	Loss:    limitspb.LimitKind_LIMIT_KIND_LOSS,
+	Stake:   limitspb.LimitKind_LIMIT_KIND_STAKE,
+	Session: limitspb.LimitKind_LIMIT_KIND_SESSION,
+}
+
+var periods = map[Period]limitspb.LimitPeriod{
+	Day:   limitspb.LimitPeriod_LIMIT_PERIOD_DAY,
+	Week:  limitspb.LimitPeriod_LIMIT_PERIOD_WEEK,
+	Month: limitspb.LimitPeriod_LIMIT_PERIOD_MONTH,
+}
+
+func KindToProto(k Kind) limitspb.LimitKind { return kinds[k] }
+
+func KindFromProto(p limitspb.LimitKind) Kind {
+	for k, v := range kinds {
+		if v == p {
+			return k
+		}
+	}
+	return ""
+}
+
+func PeriodToProto(p Period) limitspb.LimitPeriod { return periods[p] }
+
+func PeriodFromProto(p limitspb.LimitPeriod) Period {
+	for k, v := range periods {
+		if v == p {
+			return k
+		}
+	}
+	return ""
+}
+
+func ToProto(l Limit) *limitspb.Limit {
+	p := &limitspb.Limit{
+		Kind:            KindToProto(l.Kind),
+		Period:          PeriodToProto(l.Period),
+		DurationSeconds: int64(l.Duration / time.Second),
+	}
+	if l.Kind != Session {
+		p.Amount = money.ToProto(l.Amount)
+	}
+	if c := l.Pending; c != nil {
+		p.Pending = &limitspb.LimitChange{
+			DurationSeconds: int64(c.Duration / time.Second),
+			Remove:          c.Remove,
+			EffectiveAt:     c.EffectiveAt.Unix(),
+		}
+		if l.Kind != Session && !c.Remove {
+			p.Pending.Amount = money.ToProto(c.Amount)
+		}
+	}
+	return p
+}
+
+// FromProto converts a proto limit. The result is not validated.
+func FromProto(p *limitspb.Limit) Limit {
+	l := Limit{
+		Kind:     KindFromProto(p.GetKind()),
+		Period:   PeriodFromProto(p.GetPeriod()),
+		Amount:   money.FromProto(p.GetAmount()),
+		Duration: time.Duration(p.GetDurationSeconds()) * time.Second,
+	}
+	if c := p.GetPending(); c != nil {
+		l.Pending = &Change{
+			Amount:      money.FromProto(c.GetAmount()),
+			Duration:    time.Duration(c.GetDurationSeconds()) * time.Second,
+			Remove:      c.GetRemove(),
+			EffectiveAt: time.Unix(c.GetEffectiveAt(), 0),
+		}
+	}
+	return l
+}
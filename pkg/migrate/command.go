@@ -0,0 +1,77 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+)
+
+// ErrUsage is returned for a migrate command that is not understood.
+var ErrUsage = errors.New("usage: migrate up | down [N] | status")
+
+// Run handles a service's migrations at startup. args are the command-line
+// arguments left after the flags: given "migrate ..." it runs that command
+// and returns done, and the service should exit. Otherwise it applies the
+// pending migrations if up is set.
+func Run(ctx context.Context, db *sql.DB, fsys fs.FS, args []string, up bool) (done bool, err error) {
+	m, err := New(db, fsys)
+	if err != nil {
+		return false, err
+	}
+	if len(args) > 0 {
+		if args[0] != "migrate" {
+			return false, fmt.Errorf("unknown command %q", args[0])
+		}
+		return true, Command(ctx, m, args[1:], os.Stdout)
+	}
+	if up {
+		if _, err := m.Up(ctx); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// Command runs a migrate command, such as "up", "down 2" or "status", and
+// writes what it did to w. down reverts one migration unless told more.
+func Command(ctx context.Context, m *Migrator, args []string, w io.Writer) error {
+	if len(args) == 0 {
+		return ErrUsage
+	}
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		n, err := m.Up(ctx)
+		fmt.Fprintf(w, "Applied %d migration(s)\n", n)
+		return err
+	case args[0] == "down" && len(args) <= 2:
+		steps := 1
+		if len(args) == 2 {
+			var err error
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return ErrUsage
+			}
+		}
+		n, err := m.Down(ctx, steps)
+		fmt.Fprintf(w, "Reverted %d migration(s)\n", n)
+		return err
+	case args[0] == "status" && len(args) == 1:
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			applied := "pending"
+			if s.AppliedAt != nil {
+				applied = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%06d_%-40s %s\n", s.Version, s.Name, applied)
+		}
+		return nil
+	}
+	return ErrUsage
+}
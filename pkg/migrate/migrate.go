@@ -0,0 +1,244 @@
+// Package migrate applies a service's versioned schema migrations to its
+// PostgreSQL database.
+//
+// Migrations are pairs of files named like 000001_create_bets_table.up.sql
+// and 000001_create_bets_table.down.sql, usually embedded in the service
+// binary. Each runs in its own transaction together with the row recording
+// it in schema_migrations, so a failed migration leaves nothing behind. A
+// run holds the row lock of schema_migrations_lock throughout, so replicas
+// starting at the same time apply every migration exactly once.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is one schema change and the statements that undo it.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status is a migration and when it was applied, if it has been.
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Load reads the migrations in the root of fsys, ordered by version. Every
+// migration needs both an up and a down file.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int64]*Migration{}
+	for _, e := range entries {
+		if e.IsDir() || path.Ext(e.Name()) != ".sql" {
+			continue
+		}
+		m := fileName.FindStringSubmatch(e.Name())
+		if m == nil {
+			return nil, fmt.Errorf("migrate: %s: want a name like 000001_create_table.up.sql", e.Name())
+		}
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migrate: %s: invalid version", e.Name())
+		}
+		body, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		mig := byVersion[version]
+		if mig == nil {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		}
+		if mig.Name != m[2] {
+			return nil, fmt.Errorf("migrate: version %d is used by both %s and %s", version, mig.Name, m[2])
+		}
+		if m[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" || mig.Down == "" {
+			return nil, fmt.Errorf("migrate: %06d_%s needs both an up and a down file", mig.Version, mig.Name)
+		}
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Migrator applies and reverts migrations on a database.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New loads the migrations in fsys for db.
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Up applies every migration that has not been applied yet, oldest first,
+// and returns how many it applied.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	n := 0
+	err := m.locked(ctx, func(applied map[int64]time.Time) error {
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			err := m.exec(ctx, mig.Up, `INSERT INTO schema_migrations (version, name, applied_at) VALUES ($1, $2, $3)`,
+				mig.Version, mig.Name, time.Now())
+			if err != nil {
+				return fmt.Errorf("migrate: applying %06d_%s: %w", mig.Version, mig.Name, err)
+			}
+			log.Printf("Applied migration %06d_%s", mig.Version, mig.Name)
+			n++
+		}
+		return nil
+	})
+	return n, err
+}
+
+// Down reverts the latest n applied migrations, newest first, and returns
+// how many it reverted.
+func (m *Migrator) Down(ctx context.Context, n int) (int, error) {
+	reverted := 0
+	err := m.locked(ctx, func(applied map[int64]time.Time) error {
+		for i := len(m.migrations) - 1; i >= 0 && reverted < n; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			err := m.exec(ctx, mig.Down, `DELETE FROM schema_migrations WHERE version = $1`, mig.Version)
+			if err != nil {
+				return fmt.Errorf("migrate: reverting %06d_%s: %w", mig.Version, mig.Name, err)
+			}
+			log.Printf("Reverted migration %06d_%s", mig.Version, mig.Name)
+			reverted++
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status lists every migration and whether it has been applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.locked(ctx, func(applied map[int64]time.Time) error {
+		for _, mig := range m.migrations {
+			s := Status{Migration: mig}
+			if at, ok := applied[mig.Version]; ok {
+				s.AppliedAt = &at
+			}
+			statuses = append(statuses, s)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// locked runs fn while holding the migration lock, passing it the applied
+// versions. The lock is a row lock held by an open transaction, so it is
+// released even if the process dies.
+func (m *Migrator) locked(ctx context.Context, fn func(applied map[int64]time.Time) error) error {
+	_, err := m.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMPTZ NOT NULL
+		);
+		CREATE TABLE IF NOT EXISTS schema_migrations_lock (
+			id INT PRIMARY KEY CHECK (id = 1)
+		);
+		INSERT INTO schema_migrations_lock (id) VALUES (1) ON CONFLICT DO NOTHING;`)
+	if err != nil {
+		return fmt.Errorf("migrate: creating schema_migrations: %w", err)
+	}
+
+	lock, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer lock.Rollback()
+	if _, err := lock.ExecContext(ctx, `SELECT id FROM schema_migrations_lock WHERE id = 1 FOR UPDATE`); err != nil {
+		return fmt.Errorf("migrate: taking the lock: %w", err)
+	}
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+	for version := range applied {
+		if !m.known(version) {
+			return fmt.Errorf("migrate: the database has migration %d applied, which this build does not have", version)
+		}
+	}
+	return fn(applied)
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int64]time.Time, error) {
+	rows, err := m.db.QueryContext(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	applied := map[int64]time.Time{}
+	for rows.Next() {
+		var version int64
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+	return applied, rows.Err()
+}
+
+func (m *Migrator) known(version int64) bool {
+	i := sort.Search(len(m.migrations), func(i int) bool { return m.migrations[i].Version >= version })
+	return i < len(m.migrations) && m.migrations[i].Version == version
+}
+
+// exec runs a migration's statements and the bookkeeping query in one
+// transaction.
+func (m *Migrator) exec(ctx context.Context, statements, query string, args ...any) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, statements); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,69 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func file(sql string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(sql)} }
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000010_add_index.up.sql":   file("CREATE INDEX i ON t (c);"),
+		"000010_add_index.down.sql": file("DROP INDEX i;"),
+		"000002_create_t.up.sql":    file("CREATE TABLE t (c INT);"),
+		"000002_create_t.down.sql":  file("DROP TABLE t;"),
+		"embed.go":                  file("package migrations"),
+		"README.md":                 file("not a migration"),
+	}
+	migrations, err := Load(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) != 2 {
+		t.Fatalf("loaded %d migrations, want 2", len(migrations))
+	}
+	first, second := migrations[0], migrations[1]
+	if first.Version != 2 || first.Name != "create_t" || first.Down != "DROP TABLE t;" {
+		t.Errorf("first migration = %+v", first)
+	}
+	if second.Version != 10 || second.Up != "CREATE INDEX i ON t (c);" {
+		t.Errorf("second migration = %+v", second)
+	}
+}
+
+func TestLoadRejectsBadSets(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+		want string
+	}{
+		{"missing down", fstest.MapFS{"000001_a.up.sql": file("SELECT 1;")}, "needs both an up and a down file"},
+		{"bad name", fstest.MapFS{"create_a.up.sql": file("SELECT 1;")}, "want a name like"},
+		{"zero version", fstest.MapFS{"000000_a.up.sql": file("SELECT 1;"), "000000_a.down.sql": file("SELECT 1;")}, "invalid version"},
+		{"shared version", fstest.MapFS{
+			"000001_a.up.sql": file("SELECT 1;"), "000001_a.down.sql": file("SELECT 1;"),
+			"000001_b.up.sql": file("SELECT 1;"), "000001_b.down.sql": file("SELECT 1;"),
+		}, "version 1 is used by both"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.fsys)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Load() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandUsage(t *testing.T) {
+	for _, args := range [][]string{nil, {"sideways"}, {"up", "2"}, {"down", "0"}, {"down", "x"}, {"status", "all"}} {
+		if err := Command(context.Background(), &Migrator{}, args, io.Discard); !errors.Is(err, ErrUsage) {
+			t.Errorf("Command(%q) error = %v, want ErrUsage", args, err)
+		}
+	}
+}
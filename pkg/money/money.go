@@ -0,0 +1,294 @@
+// Package money represents amounts as integer minor units (cents) tagged with
+// an ISO 4217 currency code, so that balances, stakes and payouts never pick
+// up floating point error. Every supported currency has two decimal places,
+// matching the NUMERIC(…, 2) columns the services store amounts in.
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is used for amounts that do not carry a currency, such as
+// values read from the database.
+const DefaultCurrency = "USD"
+
+var (
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+)
+
+type Money struct {
+	Minor    int64
+	Currency string
+}
+
+// FromMinor returns an amount of minor units. An empty currency means
+// DefaultCurrency.
+func FromMinor(minor int64, currency string) Money {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	return Money{Minor: minor, Currency: currency}
+}
+
+// Parse reads a decimal string such as "12.5" or "-0.25". More than two
+// decimal places are rejected unless they are zeros.
+func Parse(s, currency string) (Money, error) {
+	minor, err := parseMinor(s)
+	if err != nil {
+		return Money{}, err
+	}
+	return FromMinor(minor, currency), nil
+}
+
+// MustParse is like Parse but panics on error. It is meant for constants and
+// tests.
+func MustParse(s string) Money {
+	m, err := Parse(s, "")
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func (m Money) currency() string {
+	if m.Currency == "" {
+		return DefaultCurrency
+	}
+	return m.Currency
+}
+
+func (m Money) IsZero() bool     { return m.Minor == 0 }
+func (m Money) IsPositive() bool { return m.Minor > 0 }
+func (m Money) IsNegative() bool { return m.Minor < 0 }
+
+func (m Money) Neg() Money {
+	return Money{Minor: -m.Minor, Currency: m.Currency}
+}
+
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return Money{}, err
+	}
+	return Money{Minor: m.Minor + o.Minor, Currency: m.currency()}, nil
+}
+
+func (m Money) Sub(o Money) (Money, error) {
+	return m.Add(o.Neg())
+}
+
+// Cmp returns -1, 0 or +1 depending on whether m is less than, equal to or
+// greater than o.
+func (m Money) Cmp(o Money) (int, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return 0, err
+	}
+	switch {
+	case m.Minor < o.Minor:
+		return -1, nil
+	case m.Minor > o.Minor:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// MulOdds multiplies the amount by decimal odds, rounding half away from zero
+// to the nearest minor unit. Odds are taken to four decimal places and the
+// product is computed on integers, so the result does not drift. A product
+// too large for an int64 is an ErrInvalidAmount.
+func (m Money) MulOdds(odds float64) (Money, error) {
+	const scale = 10000
+	x := math.Round(odds * scale)
+	if math.IsNaN(x) || math.Abs(x) >= math.MaxInt64 {
+		return Money{}, fmt.Errorf("%w: odds %g", ErrInvalidAmount, odds)
+	}
+	o := int64(x)
+	if o != 0 {
+		limit := int64(math.MaxInt64) / abs(o)
+		if m.Minor > limit || m.Minor < -limit {
+			return Money{}, fmt.Errorf("%w: %s at odds %g overflows", ErrInvalidAmount, m, odds)
+		}
+	}
+	p := m.Minor * o
+	q, r := p/scale, p%scale
+	if r >= scale/2 {
+		q++
+	} else if r <= -scale/2 {
+		q--
+	}
+	return Money{Minor: q, Currency: m.Currency}, nil
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func (m Money) sameCurrency(o Money) error {
+	if m.currency() != o.currency() {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency(), o.currency())
+	}
+	return nil
+}
+
+// String formats the amount as a decimal with two places, e.g. "-12.05".
+func (m Money) String() string {
+	minor := m.Minor
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, minor/100, minor%100)
+}
+
+// Format returns the amount followed by its currency code, e.g. "12.05 USD".
+func (m Money) Format() string {
+	return m.String() + " " + m.currency()
+}
+
+// Value stores the amount as an exact decimal string. The columns hold no
+// currency, so only DefaultCurrency amounts are stored; another currency is
+// refused rather than read back as DefaultCurrency.
+func (m Money) Value() (driver.Value, error) {
+	if err := m.sameCurrency(Money{}); err != nil {
+		return nil, err
+	}
+	return m.String(), nil
+}
+
+// Scan reads a NUMERIC column, which holds a DefaultCurrency amount. The
+// driver returns its text representation, which is parsed without going
+// through float64. Scanning into an amount of another currency is refused
+// rather than changing its currency.
+func (m *Money) Scan(src interface{}) error {
+	if err := m.sameCurrency(Money{}); err != nil {
+		return err
+	}
+	var minor int64
+	var err error
+	switch v := src.(type) {
+	case []byte:
+		minor, err = parseMinor(string(v))
+	case string:
+		minor, err = parseMinor(v)
+	case int64:
+		minor = v * 100
+	case float64:
+		minor = int64(math.Round(v * 100))
+	case nil:
+		minor = 0
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidAmount, src)
+	}
+	if err != nil {
+		return err
+	}
+	*m = FromMinor(minor, "")
+	return nil
+}
+
+type jsonMoney struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMoney{Amount: m.String(), Currency: m.currency()})
+}
+
+// UnmarshalJSON accepts {"amount": "12.50", "currency": "USD"} as well as a
+// bare JSON number or string, which older messages carry.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := strings.TrimSpace(string(data))
+	if s == "null" {
+		return nil
+	}
+	if strings.HasPrefix(s, "{") {
+		var v jsonMoney
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		parsed, err := Parse(v.Amount, v.Currency)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	}
+	parsed, err := Parse(strings.Trim(s, `"`), "")
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// UnmarshalText reads "12.50" or "12.50 EUR", the way amounts are written in
+// configuration.
+func (m *Money) UnmarshalText(text []byte) error {
+	amount, currency, _ := strings.Cut(strings.TrimSpace(string(text)), " ")
+	parsed, err := Parse(amount, strings.TrimSpace(currency))
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func parseMinor(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("%w: empty", ErrInvalidAmount)
+	}
+	neg := false
+	switch s[0] {
+	case '-':
+		neg = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	if len(frac) > 2 {
+		if strings.Trim(frac[2:], "0") != "" {
+			return 0, fmt.Errorf("%w: %q has more than two decimal places", ErrInvalidAmount, s)
+		}
+		frac = frac[:2]
+	}
+	for len(frac) < 2 {
+		frac += "0"
+	}
+	if whole == "" {
+		whole = "0"
+	}
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || units < 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	cents, err := strconv.ParseInt(frac, 10, 64)
+	if err != nil || cents < 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	if units > (math.MaxInt64-cents)/100 {
+		return 0, fmt.Errorf("%w: %q overflows", ErrInvalidAmount, s)
+	}
+	minor := units*100 + cents
+	if neg {
+		minor = -minor
+	}
+	return minor, nil
+}
@@ -0,0 +1,128 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseAndString(t *testing.T) {
+	cases := map[string]string{
+		"12":      "12.00",
+		"12.5":    "12.50",
+		"0.05":    "0.05",
+		"-3.10":   "-3.10",
+		".7":      "0.70",
+		"1.2300":  "1.23",
+		"9999.99": "9999.99",
+	}
+	for in, want := range cases {
+		m, err := Parse(in, "")
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", in, err)
+		}
+		if got := m.String(); got != want {
+			t.Errorf("Parse(%q) = %s, want %s", in, got, want)
+		}
+	}
+
+	for _, in := range []string{"", "abc", "1.234", "1.2.3", "."} {
+		if _, err := Parse(in, ""); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("Parse(%q): expected ErrInvalidAmount, got %v", in, err)
+		}
+	}
+}
+
+func TestMulOddsRoundsToTheCent(t *testing.T) {
+	cases := []struct {
+		stake string
+		odds  float64
+		want  string
+	}{
+		{"10.00", 2.5, "25.00"},
+		{"0.10", 3, "0.30"},
+		{"33.33", 1.85, "61.66"},
+		{"1.15", 1.5, "1.73"},
+		{"20.00", 1.8, "36.00"},
+	}
+	for _, c := range cases {
+		got, err := MustParse(c.stake).MulOdds(c.odds)
+		if err != nil || got.String() != c.want {
+			t.Errorf("%s x %.2f = %s (%v), want %s", c.stake, c.odds, got, err, c.want)
+		}
+	}
+}
+
+func TestMulOddsRejectsOverflow(t *testing.T) {
+	cases := []struct {
+		amount Money
+		odds   float64
+	}{
+		{FromMinor(math.MaxInt64/10000+1, ""), 1},
+		{FromMinor(-math.MaxInt64/20000-1, ""), 2},
+		{MustParse("10.00"), 1e300},
+		{MustParse("10.00"), math.NaN()},
+	}
+	for _, c := range cases {
+		if got, err := c.amount.MulOdds(c.odds); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("%s x %g = %s (%v), want ErrInvalidAmount", c.amount, c.odds, got, err)
+		}
+	}
+	if got, err := FromMinor(math.MaxInt64/10000, "").MulOdds(1); err != nil || got.Minor != math.MaxInt64/10000 {
+		t.Errorf("largest amount at odds 1 = %s (%v), want it unchanged", got, err)
+	}
+}
+
+func TestAddRejectsCurrencyMismatch(t *testing.T) {
+	if _, err := FromMinor(100, "USD").Add(FromMinor(100, "EUR")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatalf("expected ErrCurrencyMismatch, got %v", err)
+	}
+	sum, err := FromMinor(10, "").Add(FromMinor(20, DefaultCurrency))
+	if err != nil || sum.Minor != 30 {
+		t.Fatalf("expected 30 minor units, got %v (%v)", sum, err)
+	}
+}
+
+func TestScanNumeric(t *testing.T) {
+	var m Money
+	if err := m.Scan([]byte("1234.56")); err != nil || m.Minor != 123456 {
+		t.Fatalf("expected 123456 minor units, got %d (%v)", m.Minor, err)
+	}
+}
+
+func TestOnlyDefaultCurrencyIsStored(t *testing.T) {
+	if _, err := FromMinor(1050, "EUR").Value(); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Value of a EUR amount = %v, want ErrCurrencyMismatch", err)
+	}
+	if v, err := FromMinor(1050, "").Value(); err != nil || v != "10.50" {
+		t.Errorf("Value = %v (%v), want 10.50", v, err)
+	}
+
+	euros := FromMinor(0, "EUR")
+	if err := euros.Scan([]byte("10.50")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Scan into a EUR amount = %v, want ErrCurrencyMismatch", err)
+	}
+	if euros.Currency != "EUR" {
+		t.Errorf("Scan changed the currency to %s", euros.Currency)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	data, err := json.Marshal(FromMinor(1050, "EUR"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"amount":"10.50","currency":"EUR"}` {
+		t.Fatalf("unexpected JSON %s", data)
+	}
+	var back Money
+	if err := json.Unmarshal(data, &back); err != nil || back != FromMinor(1050, "EUR") {
+		t.Fatalf("round trip gave %v (%v)", back, err)
+	}
+
+	var legacy Money
+	if err := json.Unmarshal([]byte(`19.99`), &legacy); err != nil || legacy.Minor != 1999 {
+		t.Fatalf("expected legacy number to parse, got %v (%v)", legacy, err)
+	}
+}
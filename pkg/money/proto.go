@@ -0,0 +1,15 @@
+package money
+
+import "muchway/pkg/money/moneypb"
+
+func ToProto(m Money) *moneypb.Money {
+	return &moneypb.Money{MinorUnits: m.Minor, Currency: m.currency()}
+}
+
+// FromProto converts a proto amount; a nil message is zero.
+func FromProto(p *moneypb.Money) Money {
+	if p == nil {
+		return FromMinor(0, "")
+	}
+	return FromMinor(p.MinorUnits, p.Currency)
+}
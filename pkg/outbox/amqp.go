@@ -0,0 +1,107 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/streadway/amqp"
+)
+
+// AMQPPublisher publishes on a channel in confirm mode and waits for the
+// broker's ack. The channel is reopened after an error.
+type AMQPPublisher struct {
+	conn *amqp.Connection
+
+	mu       sync.Mutex
+	ch       *amqp.Channel
+	confirms chan amqp.Confirmation
+	tag      uint64
+	declared map[string]bool
+}
+
+func NewAMQPPublisher(conn *amqp.Connection) (*AMQPPublisher, error) {
+	p := &AMQPPublisher{conn: conn}
+	if err := p.open(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *AMQPPublisher) open() error {
+	ch, err := p.conn.Channel()
+	if err != nil {
+		return err
+	}
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		return err
+	}
+	p.ch = ch
+	p.confirms = ch.NotifyPublish(make(chan amqp.Confirmation, 1))
+	p.tag = 0
+	p.declared = map[string]bool{}
+	return nil
+}
+
+func (p *AMQPPublisher) reset() {
+	if p.ch != nil {
+		p.ch.Close()
+	}
+	p.ch = nil
+}
+
+func (p *AMQPPublisher) PublishConfirmed(ctx context.Context, exchange, routingKey string, body []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.ch == nil {
+		if err := p.open(); err != nil {
+			return err
+		}
+	}
+
+	// The default exchange drops messages for queues that do not exist yet
+	if exchange == "" && !p.declared[routingKey] {
+		if _, err := p.ch.QueueDeclare(routingKey, true, false, false, false, nil); err != nil {
+			p.reset()
+			return err
+		}
+		p.declared[routingKey] = true
+	}
+
+	err := p.ch.Publish(exchange, routingKey, false, false, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		Body:         body,
+	})
+	if err != nil {
+		p.reset()
+		return err
+	}
+	p.tag++
+
+	for {
+		select {
+		case c, ok := <-p.confirms:
+			if !ok {
+				p.reset()
+				return errors.New("channel closed before the broker confirmed the message")
+			}
+			if c.DeliveryTag < p.tag {
+				// Late confirmation of a message we stopped waiting for
+				continue
+			}
+			if !c.Ack {
+				return fmt.Errorf("broker rejected message for %s", routingKey)
+			}
+			return nil
+		case <-ctx.Done():
+			// The confirmation may still arrive; drop the channel so it is
+			// not mistaken for the next message's
+			p.reset()
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Package outbox implements the transactional outbox. Services record the
+// messages they want to publish in the same database transaction as the change
+// that produced them, and a Relay publishes them to the broker afterwards, so a
+// message is never lost when the broker is briefly unavailable. Delivery is
+// at least once and not strictly ordered: consumers must tolerate an
+// occasional duplicate and a message arriving after a later one.
+//
+// Each service keeps its own outbox table with this layout:
+//
+//	id BIGSERIAL PRIMARY KEY, exchange TEXT, routing_key TEXT, payload JSONB,
+//	attempts INT, last_error TEXT, next_attempt_at TIMESTAMPTZ,
+//	created_at TIMESTAMPTZ, sent_at TIMESTAMPTZ
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// Message is a message to publish once the surrounding transaction commits.
+// An empty Exchange means the default exchange, where RoutingKey names the
+// queue.
+type Message struct {
+	Exchange   string
+	RoutingKey string
+	Payload    interface{}
+}
+
+// Execer is implemented by *sql.Tx and *sql.DB.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// Store writes messages to one outbox table.
+type Store struct {
+	table string
+}
+
+func NewStore(table string) *Store {
+	return &Store{table: table}
+}
+
+// Add records the messages through tx, normally the transaction that makes
+// the change they announce.
+func (s *Store) Add(ctx context.Context, tx Execer, msgs ...Message) error {
+	for _, m := range msgs {
+		payload, err := json.Marshal(m.Payload)
+		if err != nil {
+			return fmt.Errorf("outbox: marshal %s: %w", m.RoutingKey, err)
+		}
+		_, err = tx.ExecContext(ctx, fmt.Sprintf(
+			`INSERT INTO %s (exchange, routing_key, payload, created_at, next_attempt_at) VALUES ($1, $2, $3, now(), now())`, s.table),
+			m.Exchange, m.RoutingKey, payload)
+		if err != nil {
+			return fmt.Errorf("outbox: insert %s: %w", m.RoutingKey, err)
+		}
+	}
+	return nil
+}
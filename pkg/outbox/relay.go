@@ -0,0 +1,174 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Publisher publishes a message and returns once the broker has confirmed it.
+type Publisher interface {
+	PublishConfirmed(ctx context.Context, exchange, routingKey string, body []byte) error
+}
+
+// Relay moves messages from an outbox table to the broker. Several relays may
+// run against the same table; rows being published are locked and skipped by
+// the others.
+type Relay struct {
+	db    *sql.DB
+	table string
+	pub   Publisher
+
+	BatchSize      int
+	PollInterval   time.Duration
+	PublishTimeout time.Duration
+	// MaxBackoff caps the delay between attempts at a failing message.
+	MaxBackoff time.Duration
+	// Retention is how long sent messages are kept before being deleted.
+	Retention time.Duration
+}
+
+func NewRelay(db *sql.DB, table string, pub Publisher) *Relay {
+	return &Relay{
+		db:             db,
+		table:          table,
+		pub:            pub,
+		BatchSize:      100,
+		PollInterval:   time.Second,
+		PublishTimeout: 5 * time.Second,
+		MaxBackoff:     5 * time.Minute,
+		Retention:      7 * 24 * time.Hour,
+	}
+}
+
+// Run relays messages until ctx is cancelled.
+func (r *Relay) Run(ctx context.Context) {
+	log.Printf("Outbox relay started for %s", r.table)
+	ticker := time.NewTicker(r.PollInterval)
+	defer ticker.Stop()
+
+	var lastPurge time.Time
+	for {
+		for {
+			n, err := r.RelayOnce(ctx)
+			if err != nil {
+				log.Printf("Outbox relay %s: %v", r.table, err)
+				break
+			}
+			if n < r.BatchSize {
+				break
+			}
+		}
+
+		if time.Since(lastPurge) > time.Hour {
+			if _, err := r.db.ExecContext(ctx, fmt.Sprintf(
+				`DELETE FROM %s WHERE sent_at < $1`, r.table), time.Now().Add(-r.Retention)); err != nil {
+				log.Printf("Outbox relay %s: purge sent messages: %v", r.table, err)
+			}
+			lastPurge = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Printf("Outbox relay stopped for %s", r.table)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayOnce publishes one batch of due messages in insertion order and
+// reports how many were sent. It stops at the first failure, which is
+// rescheduled with backoff, and leaves the rest of the batch to the next call.
+// Messages behind a failed one are not held back while it waits, and relays
+// running side by side publish their batches concurrently, so order is not
+// guaranteed and consumers must not depend on it.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id, exchange, routing_key, payload, attempts
+		FROM %s
+		WHERE sent_at IS NULL AND next_attempt_at <= now()
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED`, r.table), r.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	type pending struct {
+		id                   int64
+		exchange, routingKey string
+		payload              []byte
+		attempts             int
+	}
+	var batch []pending
+	for rows.Next() {
+		var p pending
+		if err := rows.Scan(&p.id, &p.exchange, &p.routingKey, &p.payload, &p.attempts); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		batch = append(batch, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, p := range batch {
+		pubCtx, cancel := context.WithTimeout(ctx, r.PublishTimeout)
+		pubErr := r.pub.PublishConfirmed(pubCtx, p.exchange, p.routingKey, p.payload)
+		cancel()
+
+		if pubErr != nil {
+			delay := Backoff(p.attempts+1, r.MaxBackoff)
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf(
+				`UPDATE %s SET attempts = attempts + 1, last_error = $1, next_attempt_at = $2 WHERE id = $3`, r.table),
+				pubErr.Error(), time.Now().Add(delay), p.id); err != nil {
+				return sent, err
+			}
+			log.Printf("Outbox relay %s: publishing %s (message %d) failed, retrying in %s: %v",
+				r.table, p.routingKey, p.id, delay, pubErr)
+			break
+		}
+
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(
+			`UPDATE %s SET sent_at = now(), attempts = attempts + 1, last_error = NULL WHERE id = $1`, r.table), p.id); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return sent, nil
+}
+
+// Backoff returns the delay before the given attempt: one second doubled for
+// every previous attempt, capped at max.
+func Backoff(attempt int, max time.Duration) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	d := time.Second
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= max {
+			return max
+		}
+	}
+	if d > max {
+		return max
+	}
+	return d
+}
@@ -0,0 +1,23 @@
+package outbox
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	max := time.Minute
+	cases := map[int]time.Duration{
+		0:  time.Second,
+		1:  time.Second,
+		2:  2 * time.Second,
+		4:  8 * time.Second,
+		7:  time.Minute,
+		50: time.Minute,
+	}
+	for attempt, want := range cases {
+		if got := Backoff(attempt, max); got != want {
+			t.Errorf("Backoff(%d) = %s, want %s", attempt, got, want)
+		}
+	}
+}
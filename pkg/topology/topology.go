@@ -0,0 +1,90 @@
+// Package topology declares the RabbitMQ exchanges and queues the services
+// share. Each domain publishes to a topic exchange of its own with routing
+// keys such as "bet.created"; each service consumes from queues of its own,
+// named "<service>.<purpose>" and bound to the keys it needs, so every
+// service sees every event it subscribes to.
+package topology
+
+import (
+	"fmt"
+
+	"github.com/streadway/amqp"
+
+	"muchway/pkg/consumer"
+)
+
+// Topic exchanges, one per domain.
+const (
+	ExchangeBets     = "bets"
+	ExchangeUsers    = "users"
+	ExchangeEvents   = "events"
+	ExchangePayments = "payments"
+)
+
+// Exchanges lists every exchange.
+var Exchanges = []string{ExchangeBets, ExchangeUsers, ExchangeEvents, ExchangePayments}
+
+// Routing keys.
+const (
+	BetCreated  = "bet.created"
+	BetUpdated  = "bet.updated"
+	BetDeleted  = "bet.deleted"
+	BetRejected = "bet.rejected"
+
+	UserCreated  = "user.created"
+	UserUpdated  = "user.updated"
+	UserDeleted  = "user.deleted"
+	UserLoggedIn = "user.logged_in"
+	UserExcluded = "user.excluded"
+
+	EventCreated = "event.created"
+	EventSettled = "event.settled"
+
+	PaymentRequested = "payment.requested"
+)
+
+// Binding routes messages published to Exchange with a routing key matching
+// Key (which may use the * and # wildcards) to a queue.
+type Binding struct {
+	Exchange string
+	Key      string
+}
+
+// Queue is a queue owned by one service.
+type Queue struct {
+	Name     string
+	Bindings []Binding
+}
+
+// Declare declares all exchanges and the given queues with their bindings
+// and dead-letter queues. The retry queues depend on the consumer's delays,
+// so the consumer declares them. Declaring is idempotent, so every service
+// does it at startup.
+func Declare(ch *amqp.Channel, queues ...Queue) error {
+	for _, ex := range Exchanges {
+		if err := ch.ExchangeDeclare(ex, amqp.ExchangeTopic, true, false, false, false, nil); err != nil {
+			return fmt.Errorf("exchange declare %q: %w", ex, err)
+		}
+	}
+	for _, q := range queues {
+		if err := consumer.Declare(ch, q.Name); err != nil {
+			return err
+		}
+		for _, b := range q.Bindings {
+			if err := ch.QueueBind(q.Name, b.Key, b.Exchange, false, nil); err != nil {
+				return fmt.Errorf("queue bind %q to %s/%s: %w", q.Name, b.Exchange, b.Key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// DeclareOn opens a channel on conn to declare the topology and closes it.
+func DeclareOn(conn *amqp.Connection, queues ...Queue) error {
+	ch, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+	return Declare(ch, queues...)
+}
@@ -0,0 +1,62 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The SHA-1 test vectors of RFC 6238 appendix B, truncated to six digits.
+func TestCodeRFCVectors(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	cases := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, c := range cases {
+		got, err := Code(secret, Step(time.Unix(c.unix, 0)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want {
+			t.Errorf("Code at %d = %s, want %s", c.unix, got, c.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+
+	code, _ := Code(secret, Step(now)-1)
+	step, ok := Validate(secret, code, now)
+	if !ok || step != Step(now)-1 {
+		t.Errorf("expected the previous step's code to be accepted, got %d %v", step, ok)
+	}
+
+	old, _ := Code(secret, Step(now)-3)
+	if _, ok := Validate(secret, old, now); ok {
+		t.Error("expected a code three steps old to be rejected")
+	}
+	if _, ok := Validate(secret, "12345", now); ok {
+		t.Error("expected a short code to be rejected")
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("MuchWayBet", "alice@example.com", "JBSWY3DPEHPK3PXP")
+	if !strings.HasPrefix(uri, "otpauth://totp/MuchWayBet:alice@example.com?") || !strings.Contains(uri, "secret=JBSWY3DPEHPK3PXP") {
+		t.Errorf("unexpected URI %s", uri)
+	}
+}
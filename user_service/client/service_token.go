@@ -0,0 +1,38 @@
+// Package client helps other services call user_service.
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"muchway/pkg/auth"
+	"muchway/user_service/proto/userpb"
+)
+
+// NewServiceTokenSource returns a TokenSource that authenticates as clientID
+// with user_service at address. It keeps its own connection, which is not
+// authenticated, for the life of the process.
+func NewServiceTokenSource(address, clientID, secret string) (*auth.TokenSource, error) {
+	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to user service: %w", err)
+	}
+	users := userpb.NewUserServiceClient(conn)
+
+	return auth.NewTokenSource(func(ctx context.Context) (string, time.Time, error) {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		resp, err := users.IssueServiceToken(ctx, &userpb.IssueServiceTokenRequest{
+			ClientId:     clientID,
+			ClientSecret: secret,
+		})
+		if err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to get service token: %w", err)
+		}
+		return resp.AccessToken, time.Unix(resp.ExpiresAt, 0), nil
+	}), nil
+}
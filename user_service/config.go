@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"muchway/pkg/clientip"
+	"muchway/pkg/config"
+)
+
+// Config is the user service's configuration, loaded by config.MustLoad.
+type Config struct {
+	GRPCAddr string `yaml:"grpc_addr" env:"GRPC_ADDR" default:":50051" usage:"address the gRPC server listens on"`
+	JWKSAddr string `yaml:"jwks_addr" env:"JWKS_ADDR" default:":8051" usage:"address the signing keys are published on"`
+	AppURL   string `yaml:"app_url" env:"APP_URL" default:"http://localhost:3000" usage:"base URL of the links in emails"`
+
+	TrustedProxies []string `yaml:"trusted_proxies" env:"TRUSTED_PROXIES" default:"127.0.0.1,::1" usage:"addresses or networks of proxies, such as the gateway, trusted to report the client address in x-forwarded-for"`
+
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" default:"30s" usage:"how long shutdown waits for work in flight"`
+	HealthInterval  time.Duration `yaml:"health_interval" env:"HEALTH_INTERVAL" default:"10s" usage:"how often dependencies are checked for /readyz and gRPC health"`
+
+	Database config.Postgres `yaml:"database"`
+	RabbitMQ config.RabbitMQ `yaml:"rabbitmq"`
+	Redis    config.Redis    `yaml:"redis"`
+	SMTP     config.SMTP     `yaml:"smtp"`
+
+	Email struct {
+		RequireVerified  bool          `yaml:"require_verified" env:"REQUIRE_EMAIL_VERIFICATION" default:"true" usage:"refuse logins until the email address is verified"`
+		TokenSecret      string        `yaml:"token_secret" env:"EMAIL_TOKEN_SECRET" required:"true" secret:"true" usage:"key signing email verification and password reset links"`
+		VerifyTTL        time.Duration `yaml:"verify_ttl" env:"VERIFY_EMAIL_TTL" default:"48h" usage:"lifetime of email verification links"`
+		PasswordResetTTL time.Duration `yaml:"password_reset_ttl" env:"PASSWORD_RESET_TTL" default:"1h" usage:"lifetime of password reset links"`
+	} `yaml:"email"`
+
+	Tokens struct {
+		AccessTTL          time.Duration     `yaml:"access_ttl" env:"ACCESS_TOKEN_TTL" default:"15m" usage:"lifetime of access tokens"`
+		RefreshTTL         time.Duration     `yaml:"refresh_ttl" env:"REFRESH_TOKEN_TTL" default:"720h" usage:"lifetime of refresh tokens"`
+		SigningKeyRotation time.Duration     `yaml:"signing_key_rotation" env:"SIGNING_KEY_ROTATION" default:"24h" usage:"how often the signing key is replaced"`
+		ServiceClients     map[string]string `yaml:"service_clients" env:"SERVICE_CLIENTS" required:"true" secret:"true" usage:"client credentials of other services, as id:secret,..."`
+	} `yaml:"tokens"`
+
+	Login struct {
+		LockAfter    int           `yaml:"lock_after" env:"LOGIN_LOCK_AFTER" default:"10" usage:"failed logins, or invalid two-factor codes, that lock an account"`
+		LockDuration time.Duration `yaml:"lock_duration" env:"LOGIN_LOCK_DURATION" default:"30m" usage:"how long a locked account stays locked"`
+	} `yaml:"login"`
+
+	TOTPEncryptionKey string        `yaml:"totp_encryption_key" env:"TOTP_ENCRYPTION_KEY" required:"true" secret:"true" usage:"key encrypting two-factor secrets at rest"`
+	LimitCoolingOff   time.Duration `yaml:"limit_cooling_off" env:"LIMIT_COOLING_OFF" default:"24h" usage:"delay before a raised limit takes effect"`
+
+	KYC struct {
+		DocumentDir     string `yaml:"document_dir" env:"KYC_DOCUMENT_DIR" default:"data/kyc" usage:"directory identity documents are stored in"`
+		MaxDocumentSize int    `yaml:"max_document_mb" env:"KYC_MAX_DOCUMENT_MB" default:"10" usage:"largest accepted document, in megabytes"`
+	} `yaml:"kyc"`
+}
+
+func (c *Config) Validate() error {
+	positive := map[string]int64{
+		"email.verify_ttl":            int64(c.Email.VerifyTTL),
+		"email.password_reset_ttl":    int64(c.Email.PasswordResetTTL),
+		"tokens.access_ttl":           int64(c.Tokens.AccessTTL),
+		"tokens.refresh_ttl":          int64(c.Tokens.RefreshTTL),
+		"tokens.signing_key_rotation": int64(c.Tokens.SigningKeyRotation),
+		"login.lock_after":            int64(c.Login.LockAfter),
+		"login.lock_duration":         int64(c.Login.LockDuration),
+		"limit_cooling_off":           int64(c.LimitCoolingOff),
+		"kyc.max_document_mb":         int64(c.KYC.MaxDocumentSize),
+		"shutdown_timeout":            int64(c.ShutdownTimeout),
+		"health_interval":             int64(c.HealthInterval),
+	}
+	for name, v := range positive {
+		if v <= 0 {
+			return fmt.Errorf("%s must be positive", name)
+		}
+	}
+	if _, err := clientip.ParseProxies(c.TrustedProxies); err != nil {
+		return fmt.Errorf("trusted_proxies: %w", err)
+	}
+	return c.SMTP.Validate()
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// KYCStatus is where a user is in identity verification. A user uploads
+// documents and submits them, which makes the status pending; back office
+// then verifies or rejects them. A rejected user may upload new documents
+// and submit again.
+type KYCStatus string
+
+const (
+	KYCUnverified KYCStatus = "unverified"
+	KYCPending    KYCStatus = "pending"
+	KYCVerified   KYCStatus = "verified"
+	KYCRejected   KYCStatus = "rejected"
+)
+
+type DocumentType string
+
+const (
+	DocumentPassport       DocumentType = "passport"
+	DocumentNationalID     DocumentType = "national_id"
+	DocumentDrivingLicence DocumentType = "driving_licence"
+	DocumentProofOfAddress DocumentType = "proof_of_address"
+)
+
+var (
+	ErrKYCNotAllowed         = errors.New("not allowed in the current verification status")
+	ErrKYCNoDocuments        = errors.New("upload a document before submitting")
+	ErrKYCReasonRequired     = errors.New("a rejection needs a reason")
+	ErrInvalidDocumentType   = errors.New("invalid document type")
+	ErrUnsupportedDocument   = errors.New("documents must be JPEG, PNG or PDF")
+	ErrDocumentTooLarge      = errors.New("document is too large")
+	ErrKYCDocumentNotFound   = errors.New("document not found")
+	ErrKYCSubmissionConflict = errors.New("verification status changed, try again")
+)
+
+// KYC is a user's identity verification. Users who never started have no
+// stored KYC and are unverified.
+type KYC struct {
+	UserID int64
+	Status KYCStatus
+	// RejectionReason is shown to the user when Status is rejected.
+	RejectionReason string
+	SubmittedAt     *time.Time
+	ReviewedAt      *time.Time
+	// ReviewedBy is the subject of the back-office user who decided.
+	ReviewedBy string
+	Documents  []KYCDocument
+}
+
+// KYCDocument is an uploaded document. The file itself is in the blob store
+// under BlobKey.
+type KYCDocument struct {
+	ID          string
+	UserID      int64
+	Type        DocumentType
+	Filename    string
+	ContentType string
+	Size        int64
+	BlobKey     string
+	UploadedAt  time.Time
+}
+
+func (t DocumentType) Valid() bool {
+	switch t {
+	case DocumentPassport, DocumentNationalID, DocumentDrivingLicence, DocumentProofOfAddress:
+		return true
+	}
+	return false
+}
+
+// CanUpload reports whether documents may be added: not while they are
+// being reviewed or once the user is verified.
+func (k *KYC) CanUpload() bool {
+	return k.Status == KYCUnverified || k.Status == KYCRejected
+}
+
+// Submit sends the documents for review. After a rejection at least one new
+// document is needed.
+func (k *KYC) Submit(now time.Time) error {
+	if !k.CanUpload() {
+		return ErrKYCNotAllowed
+	}
+	if !k.hasDocumentSince(k.ReviewedAt) {
+		return ErrKYCNoDocuments
+	}
+	k.Status = KYCPending
+	k.SubmittedAt = &now
+	k.RejectionReason = ""
+	return nil
+}
+
+// Review verifies or rejects a pending submission.
+func (k *KYC) Review(approve bool, reason, reviewer string, now time.Time) error {
+	if k.Status != KYCPending {
+		return ErrKYCNotAllowed
+	}
+	if approve {
+		k.Status = KYCVerified
+		k.RejectionReason = ""
+	} else {
+		if reason == "" {
+			return ErrKYCReasonRequired
+		}
+		k.Status = KYCRejected
+		k.RejectionReason = reason
+	}
+	k.ReviewedAt = &now
+	k.ReviewedBy = reviewer
+	return nil
+}
+
+func (k *KYC) hasDocumentSince(t *time.Time) bool {
+	for _, d := range k.Documents {
+		if t == nil || d.UploadedAt.After(*t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var (
+	uploadedAt = time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	reviewedAt = uploadedAt.Add(time.Hour)
+)
+
+func TestKYCSubmit(t *testing.T) {
+	doc := KYCDocument{ID: "d1", Type: DocumentPassport, UploadedAt: uploadedAt}
+	newDoc := KYCDocument{ID: "d2", Type: DocumentPassport, UploadedAt: reviewedAt.Add(time.Minute)}
+	cases := []struct {
+		name string
+		kyc  KYC
+		want error
+	}{
+		{"unverified with a document", KYC{Status: KYCUnverified, Documents: []KYCDocument{doc}}, nil},
+		{"unverified without documents", KYC{Status: KYCUnverified}, ErrKYCNoDocuments},
+		{"already pending", KYC{Status: KYCPending, Documents: []KYCDocument{doc}}, ErrKYCNotAllowed},
+		{"already verified", KYC{Status: KYCVerified, Documents: []KYCDocument{doc}}, ErrKYCNotAllowed},
+		{"rejected with a new document", KYC{Status: KYCRejected, ReviewedAt: &reviewedAt, RejectionReason: "blurred",
+			Documents: []KYCDocument{doc, newDoc}}, nil},
+		{"rejected with only the rejected documents", KYC{Status: KYCRejected, ReviewedAt: &reviewedAt, RejectionReason: "blurred",
+			Documents: []KYCDocument{doc}}, ErrKYCNoDocuments},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			k := c.kyc
+			from := k.Status
+			now := reviewedAt.Add(time.Hour)
+			err := k.Submit(now)
+			if !errors.Is(err, c.want) {
+				t.Fatalf("Submit = %v, want %v", err, c.want)
+			}
+			if err != nil {
+				if k.Status != from {
+					t.Errorf("status = %s after a refused submission, want %s", k.Status, from)
+				}
+				return
+			}
+			if k.Status != KYCPending || k.SubmittedAt == nil || !k.SubmittedAt.Equal(now) || k.RejectionReason != "" {
+				t.Errorf("after Submit: %+v, want pending, submitted now and no rejection reason", k)
+			}
+		})
+	}
+}
+
+func TestKYCReview(t *testing.T) {
+	cases := []struct {
+		name       string
+		status     KYCStatus
+		approve    bool
+		reason     string
+		want       error
+		wantStatus KYCStatus
+	}{
+		{"approve", KYCPending, true, "", nil, KYCVerified},
+		{"reject", KYCPending, false, "expired passport", nil, KYCRejected},
+		{"reject without a reason", KYCPending, false, "", ErrKYCReasonRequired, KYCPending},
+		{"not submitted", KYCUnverified, true, "", ErrKYCNotAllowed, KYCUnverified},
+		{"already verified", KYCVerified, false, "mistake", ErrKYCNotAllowed, KYCVerified},
+		{"already rejected", KYCRejected, true, "", ErrKYCNotAllowed, KYCRejected},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			k := KYC{Status: c.status}
+			err := k.Review(c.approve, c.reason, "7", reviewedAt)
+			if !errors.Is(err, c.want) {
+				t.Fatalf("Review = %v, want %v", err, c.want)
+			}
+			if k.Status != c.wantStatus {
+				t.Errorf("status = %s, want %s", k.Status, c.wantStatus)
+			}
+			if err != nil {
+				if k.ReviewedAt != nil {
+					t.Error("a refused review recorded a reviewer")
+				}
+				return
+			}
+			if k.RejectionReason != c.reason || k.ReviewedBy != "7" || k.ReviewedAt == nil {
+				t.Errorf("after Review: %+v, want reason %q reviewed by 7", k, c.reason)
+			}
+		})
+	}
+}
+
+func TestRejectedKYCCanBeResubmitted(t *testing.T) {
+	k := KYC{Status: KYCUnverified, Documents: []KYCDocument{{ID: "d1", UploadedAt: uploadedAt}}}
+	if err := k.Submit(uploadedAt); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.Review(false, "blurred", "7", reviewedAt); err != nil {
+		t.Fatal(err)
+	}
+	if !k.CanUpload() {
+		t.Fatal("a rejected user cannot upload new documents")
+	}
+	k.Documents = append(k.Documents, KYCDocument{ID: "d2", UploadedAt: reviewedAt.Add(time.Minute)})
+	if err := k.Submit(reviewedAt.Add(2 * time.Minute)); err != nil {
+		t.Fatalf("resubmitting = %v", err)
+	}
+	if err := k.Review(true, "", "7", reviewedAt.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if k.Status != KYCVerified || k.CanUpload() {
+		t.Errorf("status = %s, can upload %v, want verified and closed to uploads", k.Status, k.CanUpload())
+	}
+}
@@ -0,0 +1,10 @@
+package domain
+
+import "errors"
+
+var (
+	ErrLimitNotFound = errors.New("no such limit")
+	// ErrSessionLimitReached ends a session that has lasted as long as the
+	// user's session limit allows.
+	ErrSessionLimitReached = errors.New("session limit reached, please log in again")
+)
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrAccountDisabled = errors.New("account is disabled")
+
+// ThrottledError is returned when a login is refused because of earlier
+// failures from the same account or address. It does not say which, so it
+// reveals nothing about whether the account exists. Second-factor checks are
+// refused with it too after too many invalid codes, and password reset
+// requests after too many requests.
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("too many attempts, try again in %s", e.RetryAfter.Round(time.Second))
+}
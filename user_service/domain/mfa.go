@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrTOTPNotEnrolled     = errors.New("two-factor authentication is not set up")
+	ErrTOTPAlreadyEnabled  = errors.New("two-factor authentication is already enabled")
+	ErrInvalidMFACode      = errors.New("invalid authentication code")
+	ErrInvalidMFAChallenge = errors.New("invalid or expired login challenge")
+	ErrTooManyMFAAttempts  = errors.New("too many invalid codes, log in again")
+)
+
+// TOTP is a user's authenticator app enrolment.
+type TOTP struct {
+	UserID int64
+	// Secret is the encrypted shared secret.
+	Secret       []byte
+	EnabledAt    *time.Time
+	LastUsedStep int64
+}
+
+func (t *TOTP) Enabled() bool { return t != nil && t.EnabledAt != nil }
+
+// TOTPEnrollment is shown to the user once, when they set up 2FA.
+type TOTPEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+	RecoveryCodes   []string
+}
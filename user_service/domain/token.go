@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidCredentials  = errors.New("invalid email or password")
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used")
+	ErrInvalidClient       = errors.New("invalid client credentials")
+	ErrInvalidEmailToken   = errors.New("invalid or expired link")
+	ErrEmailNotVerified    = errors.New("email address is not verified")
+)
+
+// TokenPair is what a user receives on login and refresh.
+type TokenPair struct {
+	AccessToken           string
+	RefreshToken          string
+	AccessTokenExpiresAt  time.Time
+	RefreshTokenExpiresAt time.Time
+}
@@ -1,10 +1,45 @@
 package domain
 
+import (
+	"errors"
+	"time"
+
+	"muchway/pkg/limits"
+	"muchway/pkg/money"
+)
+
+var (
+	ErrWeakPassword = errors.New("password must be at least 8 characters")
+	ErrUserNotFound = errors.New("user not found")
+)
+
+// User is the public side of an account. Its password hash lives in
+// Credentials, so a User can be cached, returned or published as is.
 type User struct {
-	ID       int64   `bson:"id"`
-	Username string  `bson:"username"`
-	Password string  `bson:"password"`
-	Email    string  `bson:"email"`
-	Balance  float64 `bson:"balance"`
-	Role     string  `bson:"role"`
+	ID       int64       `bson:"id"`
+	Username string      `bson:"username"`
+	Email    string      `bson:"email"`
+	Balance  money.Money `bson:"balance"`
+	Role     string      `bson:"role"`
+	// EmailVerified is set once the user follows the link sent to Email.
+	EmailVerified bool `bson:"email_verified"`
+	// Active is false for accounts disabled by an admin.
+	Active bool `bson:"is_active"`
+	// Exclusion is the user's latest self-exclusion, nil if they never
+	// excluded themselves. It may be over.
+	Exclusion *limits.Exclusion `bson:"exclusion,omitempty"`
+}
+
+// Excluded returns the user's self-exclusion if it is in force at now.
+func (u *User) Excluded(now time.Time) *limits.Exclusion {
+	if u.Exclusion == nil || !u.Exclusion.Active(now) {
+		return nil
+	}
+	return u.Exclusion
+}
+
+// Credentials holds what a user logs in with.
+type Credentials struct {
+	UserID       int64
+	PasswordHash string
 }
@@ -1,13 +1,24 @@
 package email
 
 import (
+	"bytes"
 	"fmt"
+	"html/template"
 	"net/smtp"
 )
 
 // EmailService defines the interface for sending emails
 type EmailService interface {
-	SendRegistrationConfirmation(to, username string) error
+	// SendEmailVerification welcomes a user and asks them to confirm their
+	// address by following link.
+	SendEmailVerification(to, username, link string) error
+	SendPasswordReset(to, username, link string) error
+	// SendAccountLocked tells a user their account was locked after failed
+	// logins and offers link to unlock it.
+	SendAccountLocked(to, username, link string) error
+	// SendKYCReviewed tells a user whether their identity documents were
+	// accepted, and why not when they were rejected.
+	SendKYCReviewed(to, username string, approved bool, reason string) error
 }
 
 // Config holds email configuration
@@ -30,40 +41,49 @@ func NewEmailService(config Config) EmailService {
 	}
 }
 
-// SendRegistrationConfirmation sends a confirmation email to a newly registered user
-func (s *emailService) SendRegistrationConfirmation(to, username string) error {
-	// SMTP server configuration
-	smtpHost := s.config.SMTPHost
-	smtpPort := s.config.SMTPPort
-	smtpAddr := fmt.Sprintf("%s:%s", smtpHost, smtpPort)
+// SendEmailVerification sends the registration email with the verification link
+func (s *emailService) SendEmailVerification(to, username, link string) error {
+	return s.send(to, "Welcome to MuchWayBet - Please confirm your email", verificationTemplate, templateData{Username: username, Link: link})
+}
 
-	// Sender credentials
-	from := s.config.SenderEmail
-	password := s.config.SenderPasswd
+// SendPasswordReset sends the link to choose a new password
+func (s *emailService) SendPasswordReset(to, username, link string) error {
+	return s.send(to, "MuchWayBet - Reset your password", passwordResetTemplate, templateData{Username: username, Link: link})
+}
 
-	// Email content
-	subject := "Welcome to MuchWayBet - Registration Successful!"
-	body := fmt.Sprintf(`
-	<html>
-	<body>
-		<h2>Welcome to MuchWayBet, %s!</h2>
-		<p>Your registration was successful. Thank you for joining our platform!</p>
-		<p>You can now log in and start using all the features of MuchWayBet.</p>
-		<p>If you have any questions or need assistance, please don't hesitate to contact our support team.</p>
-		<p>Best regards,<br>The MuchWayBet Team</p>
-	</body>
-	</html>
-	`, username)
+// SendAccountLocked sends the link to unlock an account locked after failed logins
+func (s *emailService) SendAccountLocked(to, username, link string) error {
+	return s.send(to, "MuchWayBet - Your account has been locked", accountLockedTemplate, templateData{Username: username, Link: link})
+}
+
+// SendKYCReviewed sends the outcome of an identity verification
+func (s *emailService) SendKYCReviewed(to, username string, approved bool, reason string) error {
+	data := templateData{Username: username, Reason: reason}
+	if approved {
+		return s.send(to, "MuchWayBet - Your identity is verified", kycApprovedTemplate, data)
+	}
+	return s.send(to, "MuchWayBet - We could not verify your identity", kycRejectedTemplate, data)
+}
+
+func (s *emailService) send(to, subject string, tmpl *template.Template, data templateData) error {
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, data); err != nil {
+		return fmt.Errorf("failed to render email: %w", err)
+	}
+
+	// SMTP server configuration
+	smtpHost := s.config.SMTPHost
+	smtpAddr := fmt.Sprintf("%s:%s", smtpHost, s.config.SMTPPort)
 
 	// Compose message
 	mime := "MIME-version: 1.0;\nContent-Type: text/html; charset=\"UTF-8\";\n\n"
-	message := fmt.Sprintf("Subject: %s\n%s\n%s", subject, mime, body)
+	message := fmt.Sprintf("Subject: %s\n%s\n%s", subject, mime, body.String())
 
 	// Authentication
-	auth := smtp.PlainAuth("", from, password, smtpHost)
+	auth := smtp.PlainAuth("", s.config.SenderEmail, s.config.SenderPasswd, smtpHost)
 
 	// Send email
-	err := smtp.SendMail(smtpAddr, auth, from, []string{to}, []byte(message))
+	err := smtp.SendMail(smtpAddr, auth, s.config.SenderEmail, []string{to}, []byte(message))
 	if err != nil {
 		return fmt.Errorf("failed to send email: %w", err)
 	}
@@ -0,0 +1,69 @@
+package email
+
+import "html/template"
+
+type templateData struct {
+	Username string
+	Link     string
+	Reason   string
+}
+
+var verificationTemplate = template.Must(template.New("verification").Parse(`
+	<html>
+	<body>
+		<h2>Welcome to MuchWayBet, {{.Username}}!</h2>
+		<p>Your registration was successful. Thank you for joining our platform!</p>
+		<p>Please confirm your email address to start using your account:</p>
+		<p><a href="{{.Link}}">Confirm my email</a></p>
+		<p>If you did not create an account, you can ignore this email.</p>
+		<p>Best regards,<br>The MuchWayBet Team</p>
+	</body>
+	</html>
+`))
+
+var passwordResetTemplate = template.Must(template.New("password_reset").Parse(`
+	<html>
+	<body>
+		<h2>Hello, {{.Username}}</h2>
+		<p>We received a request to reset the password of your MuchWayBet account.</p>
+		<p><a href="{{.Link}}">Choose a new password</a></p>
+		<p>The link can be used once and expires soon. If you did not ask for a reset, you can ignore this email; your password has not changed.</p>
+		<p>Best regards,<br>The MuchWayBet Team</p>
+	</body>
+	</html>
+`))
+
+var accountLockedTemplate = template.Must(template.New("account_locked").Parse(`
+	<html>
+	<body>
+		<h2>Hello, {{.Username}}</h2>
+		<p>Your MuchWayBet account was locked after several failed login attempts.</p>
+		<p>If this was you, you can unlock it now:</p>
+		<p><a href="{{.Link}}">Unlock my account</a></p>
+		<p>If it was not you, someone may be guessing your password. Unlocking is safe, but consider resetting your password and turning on two-factor authentication.</p>
+		<p>Best regards,<br>The MuchWayBet Team</p>
+	</body>
+	</html>
+`))
+
+var kycApprovedTemplate = template.Must(template.New("kyc_approved").Parse(`
+	<html>
+	<body>
+		<h2>Hello, {{.Username}}</h2>
+		<p>Thank you for sending your documents. Your identity is now verified and withdrawals of any amount are open to you.</p>
+		<p>Best regards,<br>The MuchWayBet Team</p>
+	</body>
+	</html>
+`))
+
+var kycRejectedTemplate = template.Must(template.New("kyc_rejected").Parse(`
+	<html>
+	<body>
+		<h2>Hello, {{.Username}}</h2>
+		<p>We could not verify your identity from the documents you sent:</p>
+		<p><em>{{.Reason}}</em></p>
+		<p>Please upload new documents from your account and submit them again.</p>
+		<p>Best regards,<br>The MuchWayBet Team</p>
+	</body>
+	</html>
+`))
@@ -0,0 +1,22 @@
+package server
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"muchway/pkg/clientip"
+)
+
+// clientIP returns the address a call came from. x-forwarded-for is only
+// believed when the peer is one of the trusted proxies, such as the
+// gateway. It is empty when the peer is not known.
+func clientIP(ctx context.Context, proxies clientip.Proxies) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	return proxies.Resolve(p.Addr.String(), md.Get(clientip.Header))
+}
@@ -0,0 +1,204 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+
+	"muchway/pkg/auth"
+	"muchway/pkg/blob"
+	"muchway/user_service/domain"
+	"muchway/user_service/proto/userpb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// documentChunkSize is how much of a document each download message carries.
+const documentChunkSize = 64 << 10
+
+var kycStatuses = map[domain.KYCStatus]userpb.KYCStatus{
+	domain.KYCUnverified: userpb.KYCStatus_KYC_STATUS_UNVERIFIED,
+	domain.KYCPending:    userpb.KYCStatus_KYC_STATUS_PENDING,
+	domain.KYCVerified:   userpb.KYCStatus_KYC_STATUS_VERIFIED,
+	domain.KYCRejected:   userpb.KYCStatus_KYC_STATUS_REJECTED,
+}
+
+var documentTypes = map[userpb.KYCDocumentType]domain.DocumentType{
+	userpb.KYCDocumentType_KYC_DOCUMENT_TYPE_PASSPORT:         domain.DocumentPassport,
+	userpb.KYCDocumentType_KYC_DOCUMENT_TYPE_NATIONAL_ID:      domain.DocumentNationalID,
+	userpb.KYCDocumentType_KYC_DOCUMENT_TYPE_DRIVING_LICENCE:  domain.DocumentDrivingLicence,
+	userpb.KYCDocumentType_KYC_DOCUMENT_TYPE_PROOF_OF_ADDRESS: domain.DocumentProofOfAddress,
+}
+
+func (s *UserServer) UploadKYCDocument(stream userpb.UserService_UploadKYCDocumentServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	info := first.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "the first message must describe the document")
+	}
+	// Documents are the bettor's own; staff only review them
+	if err := s.authz.RequireOwner(stream.Context(), strconv.FormatInt(info.GetUserId(), 10)); err != nil {
+		return err
+	}
+	docType, ok := documentTypes[info.GetType()]
+	if !ok {
+		return status.Error(codes.InvalidArgument, domain.ErrInvalidDocumentType.Error())
+	}
+
+	doc, err := s.kyc.Upload(info.GetUserId(), docType, info.GetFilename(), &chunkReader{stream: stream})
+	if err != nil {
+		return kycError(err)
+	}
+	return stream.SendAndClose(&userpb.UploadKYCDocumentResponse{Document: toKYCDocument(*doc)})
+}
+
+// chunkReader reads the file of an upload from the chunks following the
+// first message.
+type chunkReader struct {
+	stream userpb.UserService_UploadKYCDocumentServer
+	buf    []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if msg.GetInfo() != nil {
+			return 0, status.Error(codes.InvalidArgument, "only the first message may describe the document")
+		}
+		r.buf = msg.GetChunk()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (s *UserServer) GetKYC(ctx context.Context, req *userpb.GetKYCRequest) (*userpb.GetKYCResponse, error) {
+	if err := s.requireOwner(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+	k, err := s.kyc.GetKYC(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	return &userpb.GetKYCResponse{Kyc: toKYC(k)}, nil
+}
+
+func (s *UserServer) SubmitKYC(ctx context.Context, req *userpb.SubmitKYCRequest) (*userpb.SubmitKYCResponse, error) {
+	if err := s.authz.RequireOwner(ctx, strconv.FormatInt(req.GetUserId(), 10)); err != nil {
+		return nil, err
+	}
+	k, err := s.kyc.Submit(req.GetUserId())
+	if err != nil {
+		return nil, kycError(err)
+	}
+	return &userpb.SubmitKYCResponse{Kyc: toKYC(k)}, nil
+}
+
+func (s *UserServer) ListPendingKYC(ctx context.Context, req *userpb.ListPendingKYCRequest) (*userpb.ListPendingKYCResponse, error) {
+	ks, err := s.kyc.ListPending()
+	if err != nil {
+		return nil, err
+	}
+	resp := &userpb.ListPendingKYCResponse{}
+	for _, k := range ks {
+		resp.Submissions = append(resp.Submissions, toKYC(k))
+	}
+	return resp, nil
+}
+
+func (s *UserServer) ReviewKYC(ctx context.Context, req *userpb.ReviewKYCRequest) (*userpb.ReviewKYCResponse, error) {
+	claims, _ := auth.FromContext(ctx)
+	k, err := s.kyc.Review(req.GetUserId(), req.GetApprove(), req.GetReason(), claims.Subject)
+	if err != nil {
+		return nil, kycError(err)
+	}
+	return &userpb.ReviewKYCResponse{Kyc: toKYC(k)}, nil
+}
+
+func (s *UserServer) GetKYCDocument(req *userpb.GetKYCDocumentRequest, stream userpb.UserService_GetKYCDocumentServer) error {
+	doc, f, err := s.kyc.OpenDocument(req.GetDocumentId())
+	if err != nil {
+		return kycError(err)
+	}
+	defer f.Close()
+
+	if err := stream.Send(&userpb.GetKYCDocumentResponse{Data: &userpb.GetKYCDocumentResponse_Document{Document: toKYCDocument(*doc)}}); err != nil {
+		return err
+	}
+	buf := make([]byte, documentChunkSize)
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			chunk := &userpb.GetKYCDocumentResponse_Chunk{Chunk: buf[:n]}
+			if err := stream.Send(&userpb.GetKYCDocumentResponse{Data: chunk}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func toKYC(k *domain.KYC) *userpb.KYC {
+	pb := &userpb.KYC{
+		UserId:          k.UserID,
+		Status:          kycStatuses[k.Status],
+		RejectionReason: k.RejectionReason,
+	}
+	if k.SubmittedAt != nil {
+		pb.SubmittedAt = k.SubmittedAt.Unix()
+	}
+	if k.ReviewedAt != nil {
+		pb.ReviewedAt = k.ReviewedAt.Unix()
+	}
+	for _, d := range k.Documents {
+		pb.Documents = append(pb.Documents, toKYCDocument(d))
+	}
+	return pb
+}
+
+func toKYCDocument(d domain.KYCDocument) *userpb.KYCDocument {
+	pb := &userpb.KYCDocument{
+		Id:          d.ID,
+		Filename:    d.Filename,
+		ContentType: d.ContentType,
+		Size:        d.Size,
+		UploadedAt:  d.UploadedAt.Unix(),
+	}
+	for t, dt := range documentTypes {
+		if dt == d.Type {
+			pb.Type = t
+		}
+	}
+	return pb
+}
+
+func kycError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidDocumentType), errors.Is(err, domain.ErrUnsupportedDocument),
+		errors.Is(err, domain.ErrKYCReasonRequired):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrDocumentTooLarge):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, domain.ErrKYCNotAllowed), errors.Is(err, domain.ErrKYCNoDocuments):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrKYCSubmissionConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, domain.ErrKYCDocumentNotFound), errors.Is(err, blob.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return err
+	}
+}
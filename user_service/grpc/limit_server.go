@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"muchway/pkg/limits"
+	"muchway/pkg/limits/limitspb"
+	"muchway/pkg/money"
+	"muchway/user_service/domain"
+	"muchway/user_service/proto/userpb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *UserServer) GetLimits(ctx context.Context, req *userpb.GetLimitsRequest) (*userpb.GetLimitsResponse, error) {
+	if err := s.requireOwner(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+	ls, err := s.limits.GetLimits(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	resp := &userpb.GetLimitsResponse{}
+	for _, l := range ls {
+		resp.Limits = append(resp.Limits, limits.ToProto(l))
+	}
+	return resp, nil
+}
+
+func (s *UserServer) SetLimit(ctx context.Context, req *userpb.SetLimitRequest) (*userpb.SetLimitResponse, error) {
+	// Limits are the bettor's own choice; staff cannot set them
+	if err := s.authz.RequireOwner(ctx, strconv.FormatInt(req.GetUserId(), 10)); err != nil {
+		return nil, err
+	}
+	if req.GetLimit() == nil {
+		return nil, status.Error(codes.InvalidArgument, "limit must be provided")
+	}
+	l := limits.FromProto(req.GetLimit())
+	l.Pending = nil
+	l, err := s.limits.SetLimit(req.GetUserId(), l)
+	if err != nil {
+		return nil, limitError(err)
+	}
+	return &userpb.SetLimitResponse{Limit: limits.ToProto(l)}, nil
+}
+
+func (s *UserServer) RemoveLimit(ctx context.Context, req *userpb.RemoveLimitRequest) (*userpb.RemoveLimitResponse, error) {
+	if err := s.authz.RequireOwner(ctx, strconv.FormatInt(req.GetUserId(), 10)); err != nil {
+		return nil, err
+	}
+	period := limits.PeriodFromProto(req.GetPeriod())
+	if req.GetKind() == limitspb.LimitKind_LIMIT_KIND_SESSION {
+		period = ""
+	}
+	l, err := s.limits.RemoveLimit(req.GetUserId(), limits.KindFromProto(req.GetKind()), period)
+	if err != nil {
+		return nil, limitError(err)
+	}
+	return &userpb.RemoveLimitResponse{Limit: limits.ToProto(l)}, nil
+}
+
+var exclusionPeriods = map[userpb.ExclusionPeriod]limits.ExclusionPeriod{
+	userpb.ExclusionPeriod_EXCLUSION_PERIOD_24_HOURS:  limits.ExcludeDay,
+	userpb.ExclusionPeriod_EXCLUSION_PERIOD_7_DAYS:    limits.ExcludeWeek,
+	userpb.ExclusionPeriod_EXCLUSION_PERIOD_6_MONTHS:  limits.ExcludeSixMonths,
+	userpb.ExclusionPeriod_EXCLUSION_PERIOD_PERMANENT: limits.ExcludePermanently,
+}
+
+func (s *UserServer) SelfExclude(ctx context.Context, req *userpb.SelfExcludeRequest) (*userpb.SelfExcludeResponse, error) {
+	if err := s.authz.RequireOwner(ctx, strconv.FormatInt(req.GetUserId(), 10)); err != nil {
+		return nil, err
+	}
+	period, ok := exclusionPeriods[req.GetPeriod()]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, limits.ErrInvalidExclusion.Error())
+	}
+	e, err := s.usecase.Exclude(req.GetUserId(), period)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The exclusion starts with this call, so the session ends with it
+	if err := s.tokens.RevokeUser(req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	resp := &userpb.SelfExcludeResponse{Permanent: e.Permanent()}
+	if e.Until != nil {
+		resp.ExcludedUntil = e.Until.Unix()
+	}
+	return resp, nil
+}
+
+func limitError(err error) error {
+	switch {
+	case errors.Is(err, limits.ErrInvalidLimit), errors.Is(err, money.ErrCurrencyMismatch):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrLimitNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return err
+	}
+}
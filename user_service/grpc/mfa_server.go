@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"muchway/pkg/auth"
+	"muchway/user_service/domain"
+	"muchway/user_service/proto/userpb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *UserServer) CompleteLogin(ctx context.Context, req *userpb.CompleteLoginRequest) (*userpb.LoginResponse, error) {
+	userID, err := s.mfa.CompleteChallenge(req.GetMfaChallenge(), req.GetCode())
+	if err != nil {
+		return nil, mfaError(err)
+	}
+	user, err := s.usecase.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, status.Error(codes.Unauthenticated, domain.ErrInvalidMFAChallenge.Error())
+	}
+	// The user may have excluded themselves since entering their password
+	if e := user.Excluded(time.Now()); e != nil {
+		return nil, tokenError(e.Err())
+	}
+
+	tokens, err := s.tokens.Issue(user, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &userpb.LoginResponse{
+		User:   toProfile(user),
+		Tokens: tokenPairToProto(tokens),
+	}, nil
+}
+
+func (s *UserServer) EnrollTOTP(ctx context.Context, req *userpb.EnrollTOTPRequest) (*userpb.EnrollTOTPResponse, error) {
+	user, err := s.ownUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	enrollment, err := s.mfa.Enroll(user)
+	if err != nil {
+		return nil, mfaError(err)
+	}
+	return &userpb.EnrollTOTPResponse{
+		Secret:          enrollment.Secret,
+		ProvisioningUri: enrollment.ProvisioningURI,
+		RecoveryCodes:   enrollment.RecoveryCodes,
+	}, nil
+}
+
+func (s *UserServer) ConfirmTOTP(ctx context.Context, req *userpb.ConfirmTOTPRequest) (*userpb.ConfirmTOTPResponse, error) {
+	if _, err := s.ownUser(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+	if err := s.mfa.Confirm(req.GetUserId(), req.GetCode()); err != nil {
+		return nil, mfaError(err)
+	}
+	return &userpb.ConfirmTOTPResponse{}, nil
+}
+
+func (s *UserServer) DisableTOTP(ctx context.Context, req *userpb.DisableTOTPRequest) (*userpb.DisableTOTPResponse, error) {
+	if _, err := s.ownUser(ctx, req.GetUserId()); err != nil {
+		return nil, err
+	}
+	if err := s.mfa.Disable(req.GetUserId(), req.GetCode()); err != nil {
+		return nil, mfaError(err)
+	}
+	return &userpb.DisableTOTPResponse{}, nil
+}
+
+func (s *UserServer) StepUp(ctx context.Context, req *userpb.StepUpRequest) (*userpb.StepUpResponse, error) {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, "step-up is for user accounts")
+	}
+	user, err := s.ownUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.mfa.Verify(userID, req.GetCode()); err != nil {
+		return nil, mfaError(err)
+	}
+	var sessionStart time.Time
+	if claims.AuthTime != 0 {
+		sessionStart = time.Unix(claims.AuthTime, 0)
+	}
+	token, expiresAt, err := s.tokens.StepUp(user, sessionStart)
+	if err != nil {
+		return nil, tokenError(err)
+	}
+	return &userpb.StepUpResponse{AccessToken: token, ExpiresAt: expiresAt.Unix()}, nil
+}
+
+// ownUser loads the caller's own account. 2FA is managed only by its owner,
+// not even by admins: enrolling hands out the secret and disabling removes
+// the second factor.
+func (s *UserServer) ownUser(ctx context.Context, userID int64) (*domain.User, error) {
+	if err := s.authz.RequireSelf(ctx, strconv.FormatInt(userID, 10)); err != nil {
+		return nil, err
+	}
+	user, err := s.usecase.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return user, nil
+}
+
+func mfaError(err error) error {
+	var throttled *domain.ThrottledError
+	switch {
+	case errors.As(err, &throttled):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, domain.ErrInvalidMFACode), errors.Is(err, domain.ErrInvalidMFAChallenge),
+		errors.Is(err, domain.ErrTooManyMFAAttempts):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, domain.ErrTOTPNotEnrolled), errors.Is(err, domain.ErrTOTPAlreadyEnabled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
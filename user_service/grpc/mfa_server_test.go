@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"muchway/pkg/auth"
+	"muchway/user_service/domain"
+	"muchway/user_service/proto/userpb"
+	"muchway/user_service/usecase"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// enrollments is an MFAUsecase that hands out a fixed secret.
+type enrollments struct {
+	usecase.MFAUsecase
+}
+
+func (enrollments) Enroll(user *domain.User) (*domain.TOTPEnrollment, error) {
+	return &domain.TOTPEnrollment{Secret: "JBSWY3DPEHPK3PXP", RecoveryCodes: []string{"a1b2-c3d4"}}, nil
+}
+
+func (enrollments) Disable(userID int64, code string) error { return nil }
+
+func TestOnlyTheOwnerManagesTOTP(t *testing.T) {
+	cases := []struct {
+		name   string
+		claims *auth.Claims
+		want   codes.Code
+	}{
+		{"owner", &auth.Claims{Subject: "42", Role: auth.RoleBettor}, codes.OK},
+		{"another bettor", &auth.Claims{Subject: "43", Role: auth.RoleBettor}, codes.PermissionDenied},
+		{"admin", &auth.Claims{Subject: "1", Role: auth.RoleAdmin}, codes.PermissionDenied},
+		{"service", &auth.Claims{Subject: "service:bet_service", Role: auth.RoleService}, codes.PermissionDenied},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			s := NewUserServer(&registrations{}, nil, enrollments{}, nil, nil, auth.NewAuthorizer(Policy, nil), nil)
+			ctx := auth.NewContext(context.Background(), c.claims)
+
+			resp, err := s.EnrollTOTP(ctx, &userpb.EnrollTOTPRequest{UserId: 42})
+			if status.Code(err) != c.want {
+				t.Fatalf("EnrollTOTP = %v, want %v", err, c.want)
+			}
+			if err != nil && resp != nil {
+				t.Errorf("a refused enrolment returned the secret")
+			}
+			if _, err := s.DisableTOTP(ctx, &userpb.DisableTOTPRequest{UserId: 42, Code: "123456"}); status.Code(err) != c.want {
+				t.Errorf("DisableTOTP = %v, want %v", err, c.want)
+			}
+		})
+	}
+}
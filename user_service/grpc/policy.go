@@ -0,0 +1,50 @@
+package server
+
+import (
+	"muchway/pkg/auth"
+	"muchway/user_service/proto/userpb"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Policy says who may call each UserService method. Registration, the token
+// RPCs, the email link flows and health checks are open; bettors read and update only their
+// own account, which UserServer checks, and only admins disable or delete accounts.
+// Finance reviews identity documents. Only the payment service reports
+// balances, which UserServer checks.
+var Policy = auth.Policy{
+	userpb.UserService_CreateUser_FullMethodName:           {auth.Anyone},
+	userpb.UserService_Login_FullMethodName:                {auth.Anyone},
+	userpb.UserService_RefreshToken_FullMethodName:         {auth.Anyone},
+	userpb.UserService_Logout_FullMethodName:               {auth.Anyone},
+	userpb.UserService_IssueServiceToken_FullMethodName:    {auth.Anyone},
+	userpb.UserService_VerifyEmail_FullMethodName:          {auth.Anyone},
+	userpb.UserService_RequestPasswordReset_FullMethodName: {auth.Anyone},
+	userpb.UserService_ResetPassword_FullMethodName:        {auth.Anyone},
+	userpb.UserService_CompleteLogin_FullMethodName:        {auth.Anyone},
+	userpb.UserService_UnlockAccount_FullMethodName:        {auth.Anyone},
+	healthpb.Health_Check_FullMethodName:                   {auth.Anyone},
+	healthpb.Health_Watch_FullMethodName:                   {auth.Anyone},
+
+	userpb.UserService_GetUserByID_FullMethodName:       {auth.RoleBettor, auth.RoleFinance, auth.RoleService},
+	userpb.UserService_GetUserByUsername_FullMethodName: {auth.RoleBettor, auth.RoleFinance, auth.RoleService},
+	userpb.UserService_GetUserByEmail_FullMethodName:    {auth.RoleBettor, auth.RoleFinance, auth.RoleService},
+	userpb.UserService_GetAllUsers_FullMethodName:       {auth.RoleFinance, auth.RoleService},
+	userpb.UserService_UpdateUser_FullMethodName:        {auth.RoleBettor},
+	userpb.UserService_ChangePassword_FullMethodName:    {auth.RoleBettor},
+	userpb.UserService_EnrollTOTP_FullMethodName:        {auth.Authenticated},
+	userpb.UserService_ConfirmTOTP_FullMethodName:       {auth.Authenticated},
+	userpb.UserService_DisableTOTP_FullMethodName:       {auth.Authenticated},
+	userpb.UserService_StepUp_FullMethodName:            {auth.Authenticated},
+	userpb.UserService_GetLimits_FullMethodName:         {auth.RoleBettor, auth.RoleFinance, auth.RoleService},
+	userpb.UserService_SetLimit_FullMethodName:          {auth.RoleBettor},
+	userpb.UserService_RemoveLimit_FullMethodName:       {auth.RoleBettor},
+	userpb.UserService_SelfExclude_FullMethodName:       {auth.RoleBettor},
+	userpb.UserService_UploadKYCDocument_FullMethodName: {auth.RoleBettor},
+	userpb.UserService_GetKYC_FullMethodName:            {auth.RoleBettor, auth.RoleFinance, auth.RoleService},
+	userpb.UserService_SubmitKYC_FullMethodName:         {auth.RoleBettor},
+	userpb.UserService_ListPendingKYC_FullMethodName:    {auth.RoleFinance},
+	userpb.UserService_ReviewKYC_FullMethodName:         {auth.RoleFinance},
+	userpb.UserService_GetKYCDocument_FullMethodName:    {auth.RoleFinance},
+	userpb.UserService_SyncBalance_FullMethodName:       {auth.RoleService},
+}
@@ -2,85 +2,176 @@ package server
 
 import (
 	"context"
+	"errors"
+	"muchway/pkg/auth"
+	"muchway/pkg/clientip"
+	"muchway/pkg/limits"
+	"muchway/pkg/money"
 	"muchway/user_service/domain"
 	"muchway/user_service/proto/userpb"
 	"muchway/user_service/usecase"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type UserServer struct {
 	userpb.UnimplementedUserServiceServer
 	usecase usecase.UserUsecase
+	tokens  usecase.TokenUsecase
+	mfa     usecase.MFAUsecase
+	limits  usecase.LimitUsecase
+	kyc     usecase.KYCUsecase
+	authz   *auth.Authorizer
+	proxies clientip.Proxies
 }
 
-func NewUserServer(usecase usecase.UserUsecase) *UserServer {
+// NewUserServer returns the gRPC server. proxies are trusted to report the
+// client address of the calls they pass on.
+func NewUserServer(usecase usecase.UserUsecase, tokens usecase.TokenUsecase, mfa usecase.MFAUsecase, limits usecase.LimitUsecase, kyc usecase.KYCUsecase, authz *auth.Authorizer, proxies clientip.Proxies) *UserServer {
 	return &UserServer{
 		usecase: usecase,
+		tokens:  tokens,
+		mfa:     mfa,
+		limits:  limits,
+		kyc:     kyc,
+		authz:   authz,
+		proxies: proxies,
 	}
 }
 
+func (s *UserServer) requireOwner(ctx context.Context, id int64) error {
+	return s.authz.RequireOwner(ctx, strconv.FormatInt(id, 10), auth.RoleFinance)
+}
+
 func (s *UserServer) CreateUser(ctx context.Context, req *userpb.CreateUserRequest) (*userpb.CreateUserResponse, error) {
 	u := req.GetUser()
 	user := &domain.User{
 		ID:       u.Id,
 		Username: u.Username,
 		Email:    u.Email,
-		Password: u.Password,
-		Balance:  u.Balance,
+		Balance:  money.FromProto(u.Balance),
 		Role:     u.Role,
 	}
+	// Only admins create accounts with another role or a starting balance;
+	// services register users like anyone else
+	if claims, ok := auth.FromContext(ctx); !ok || claims.Role != auth.RoleAdmin {
+		user.Role = auth.RoleBettor
+		user.Balance = money.Money{}
+	}
 
-	err := s.usecase.Register(user)
+	err := s.usecase.Register(user, u.Password)
+	if errors.Is(err, domain.ErrWeakPassword) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	return &userpb.CreateUserResponse{
-		User: &userpb.User{
-			Id:       user.ID,
-			Username: user.Username,
-			Email:    user.Email,
-			Password: user.Password,
-			Balance:  user.Balance,
-			Role:     user.Role,
-		},
+		User: toProfile(user),
 	}, nil
 }
 func (s *UserServer) Login(ctx context.Context, req *userpb.LoginRequest) (*userpb.LoginResponse, error) {
 	email := req.GetEmail()
 	password := req.GetPassword()
 
-	user, err := s.usecase.Login(email, password)
+	user, err := s.usecase.Login(email, password, clientIP(ctx, s.proxies))
+	if err != nil {
+		return nil, tokenError(err)
+	}
+
+	// With 2FA the password only earns a challenge
+	enabled, err := s.mfa.Enabled(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		challenge, err := s.mfa.StartChallenge(user.ID)
+		if err != nil {
+			return nil, err
+		}
+		return &userpb.LoginResponse{MfaRequired: true, MfaChallenge: challenge}, nil
+	}
+
+	tokens, err := s.tokens.Issue(user, time.Time{})
 	if err != nil {
 		return nil, err
 	}
 
 	return &userpb.LoginResponse{
-		User: &userpb.User{
-			Id:       user.ID,
-			Username: user.Username,
-			Email:    user.Email,
-			Password: user.Password,
-			Balance:  user.Balance,
-			Role:     user.Role,
-		},
+		User:   toProfile(user),
+		Tokens: tokenPairToProto(tokens),
 	}, nil
 }
 
+func (s *UserServer) RefreshToken(ctx context.Context, req *userpb.RefreshTokenRequest) (*userpb.RefreshTokenResponse, error) {
+	tokens, err := s.tokens.Refresh(req.GetRefreshToken())
+	if err != nil {
+		return nil, tokenError(err)
+	}
+	return &userpb.RefreshTokenResponse{Tokens: tokenPairToProto(tokens)}, nil
+}
+
+func (s *UserServer) Logout(ctx context.Context, req *userpb.LogoutRequest) (*userpb.LogoutResponse, error) {
+	if err := s.tokens.Logout(req.GetRefreshToken()); err != nil {
+		return nil, err
+	}
+	return &userpb.LogoutResponse{}, nil
+}
+
+func (s *UserServer) IssueServiceToken(ctx context.Context, req *userpb.IssueServiceTokenRequest) (*userpb.IssueServiceTokenResponse, error) {
+	token, expiresAt, err := s.tokens.IssueServiceToken(req.GetClientId(), req.GetClientSecret())
+	if err != nil {
+		return nil, tokenError(err)
+	}
+	return &userpb.IssueServiceTokenResponse{AccessToken: token, ExpiresAt: expiresAt.Unix()}, nil
+}
+
+func tokenPairToProto(t *domain.TokenPair) *userpb.TokenPair {
+	return &userpb.TokenPair{
+		AccessToken:           t.AccessToken,
+		RefreshToken:          t.RefreshToken,
+		AccessTokenExpiresAt:  t.AccessTokenExpiresAt.Unix(),
+		RefreshTokenExpiresAt: t.RefreshTokenExpiresAt.Unix(),
+	}
+}
+
+// tokenError maps credential and token errors onto UNAUTHENTICATED.
+func tokenError(err error) error {
+	var throttled *domain.ThrottledError
+	switch {
+	case errors.As(err, &throttled):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, domain.ErrAccountDisabled), errors.Is(err, limits.ErrSelfExcluded):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrInvalidCredentials), errors.Is(err, domain.ErrInvalidRefreshToken),
+		errors.Is(err, domain.ErrRefreshTokenReused), errors.Is(err, domain.ErrInvalidClient),
+		errors.Is(err, domain.ErrSessionLimitReached):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, domain.ErrEmailNotVerified):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
+
 func (s *UserServer) GetUserByID(ctx context.Context, req *userpb.GetUserByIDRequest) (*userpb.GetUserByIDResponse, error) {
+	if err := s.requireOwner(ctx, req.GetId()); err != nil {
+		return nil, err
+	}
 	user, err := s.usecase.GetUserByID(ctx, req.GetId())
 	if err != nil {
 		return nil, err
 	}
+	if user == nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
 
 	return &userpb.GetUserByIDResponse{
-		User: &userpb.User{
-			Id:       user.ID,
-			Username: user.Username,
-			Email:    user.Email,
-			Password: user.Password,
-			Balance:  user.Balance,
-			Role:     user.Role,
-		},
+		User: toProfile(user),
 	}, nil
 }
 
@@ -90,16 +181,9 @@ func (s *UserServer) GetAllUsers(ctx context.Context, req *userpb.GetAllUsersReq
 		return nil, err
 	}
 
-	var pbUsers []*userpb.User
+	var pbUsers []*userpb.UserProfile
 	for _, user := range users {
-		pbUsers = append(pbUsers, &userpb.User{
-			Id:       user.ID,
-			Username: user.Username,
-			Email:    user.Email,
-			Password: user.Password,
-			Balance:  user.Balance,
-			Role:     user.Role,
-		})
+		pbUsers = append(pbUsers, toProfile(user))
 	}
 
 	return &userpb.GetAllUsersResponse{Users: pbUsers}, nil
@@ -110,16 +194,15 @@ func (s *UserServer) GetUserByUsername(ctx context.Context, req *userpb.GetUserB
 	if err != nil {
 		return nil, err
 	}
+	if user == nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err := s.requireOwner(ctx, user.ID); err != nil {
+		return nil, err
+	}
 
 	return &userpb.GetUserByUsernameResponse{
-		User: &userpb.User{
-			Id:       user.ID,
-			Username: user.Username,
-			Email:    user.Email,
-			Password: user.Password,
-			Balance:  user.Balance,
-			Role:     user.Role,
-		},
+		User: toProfile(user),
 	}, nil
 }
 
@@ -128,16 +211,15 @@ func (s *UserServer) GetUserByEmail(ctx context.Context, req *userpb.GetUserByEm
 	if err != nil {
 		return nil, err
 	}
+	if user == nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err := s.requireOwner(ctx, user.ID); err != nil {
+		return nil, err
+	}
 
 	return &userpb.GetUserByEmailResponse{
-		User: &userpb.User{
-			Id:       user.ID,
-			Username: user.Username,
-			Email:    user.Email,
-			Password: user.Password,
-			Balance:  user.Balance,
-			Role:     user.Role,
-		},
+		User: toProfile(user),
 	}, nil
 }
 
@@ -147,17 +229,131 @@ func (s *UserServer) UpdateUser(ctx context.Context, req *userpb.UpdateUserReque
 		ID:       u.Id,
 		Username: u.Username,
 		Email:    u.Email,
-		Password: u.Password,
-		Balance:  u.Balance,
 		Role:     u.Role,
 	}
+	if err := s.requireOwner(ctx, user.ID); err != nil {
+		return nil, err
+	}
+	// Only admins change roles; owners and services edit the details
+	if claims, _ := auth.FromContext(ctx); claims.Role != auth.RoleAdmin {
+		current, err := s.usecase.GetUserByID(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if current == nil {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+		user.Role = current.Role
+	}
 
 	err := s.usecase.UpdateUser(user)
 	if err != nil {
 		return nil, err
 	}
 
-	return &userpb.UpdateUserResponse{User: u}, nil
+	return &userpb.UpdateUserResponse{
+		User: toProfile(user),
+	}, nil
+}
+
+func (s *UserServer) ChangePassword(ctx context.Context, req *userpb.ChangePasswordRequest) (*userpb.ChangePasswordResponse, error) {
+	if err := s.authz.RequireOwner(ctx, strconv.FormatInt(req.GetUserId(), 10)); err != nil {
+		return nil, err
+	}
+	err := s.usecase.ChangePassword(req.GetUserId(), req.GetCurrentPassword(), req.GetNewPassword())
+	switch {
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		return nil, status.Error(codes.PermissionDenied, "current password is incorrect")
+	case errors.Is(err, domain.ErrWeakPassword):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return nil, err
+	}
+
+	// Sessions started with the old password end with it
+	if err := s.tokens.RevokeUser(req.GetUserId()); err != nil {
+		return nil, err
+	}
+	return &userpb.ChangePasswordResponse{}, nil
+}
+
+func (s *UserServer) VerifyEmail(ctx context.Context, req *userpb.VerifyEmailRequest) (*userpb.VerifyEmailResponse, error) {
+	if err := s.usecase.VerifyEmail(req.GetToken()); err != nil {
+		return nil, accountError(err)
+	}
+	return &userpb.VerifyEmailResponse{}, nil
+}
+
+func (s *UserServer) RequestPasswordReset(ctx context.Context, req *userpb.RequestPasswordResetRequest) (*userpb.RequestPasswordResetResponse, error) {
+	if err := s.usecase.RequestPasswordReset(req.GetEmail(), clientIP(ctx, s.proxies)); err != nil {
+		return nil, accountError(err)
+	}
+	return &userpb.RequestPasswordResetResponse{}, nil
+}
+
+func (s *UserServer) ResetPassword(ctx context.Context, req *userpb.ResetPasswordRequest) (*userpb.ResetPasswordResponse, error) {
+	userID, err := s.usecase.ResetPassword(req.GetToken(), req.GetNewPassword())
+	if err != nil {
+		return nil, accountError(err)
+	}
+	// Whoever knew the old password is logged out
+	if err := s.tokens.RevokeUser(userID); err != nil {
+		return nil, err
+	}
+	return &userpb.ResetPasswordResponse{}, nil
+}
+
+func (s *UserServer) UnlockAccount(ctx context.Context, req *userpb.UnlockAccountRequest) (*userpb.UnlockAccountResponse, error) {
+	if err := s.usecase.UnlockAccount(req.GetToken()); err != nil {
+		return nil, accountError(err)
+	}
+	return &userpb.UnlockAccountResponse{}, nil
+}
+
+func (s *UserServer) SetUserActive(ctx context.Context, req *userpb.SetUserActiveRequest) (*userpb.SetUserActiveResponse, error) {
+	if err := s.usecase.SetActive(req.GetUserId(), req.GetActive()); err != nil {
+		return nil, err
+	}
+	// A disabled account loses its sessions straight away
+	if !req.GetActive() {
+		if err := s.tokens.RevokeUser(req.GetUserId()); err != nil {
+			return nil, err
+		}
+	}
+	return &userpb.SetUserActiveResponse{}, nil
+}
+
+// balanceOwner is the only client allowed to report balances.
+const balanceOwner = "service:payment_service"
+
+// SyncBalance stores the wallet balance the payment service reports after
+// each posting. Nothing else writes users.balance.
+func (s *UserServer) SyncBalance(ctx context.Context, req *userpb.SyncBalanceRequest) (*userpb.SyncBalanceResponse, error) {
+	if claims, ok := auth.FromContext(ctx); !ok || claims.Subject != balanceOwner {
+		return nil, status.Error(codes.PermissionDenied, "only the payment service reports balances")
+	}
+	if req.GetBalance() == nil || req.GetVersion() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "balance and a positive version are required")
+	}
+	applied, err := s.usecase.SyncBalance(req.GetUserId(), money.FromProto(req.GetBalance()), req.GetVersion())
+	if err != nil {
+		return nil, err
+	}
+	return &userpb.SyncBalanceResponse{Applied: applied}, nil
+}
+
+// accountError maps errors of the email verification and password reset
+// flows onto gRPC statuses.
+func accountError(err error) error {
+	var throttled *domain.ThrottledError
+	switch {
+	case errors.Is(err, domain.ErrInvalidEmailToken), errors.Is(err, domain.ErrWeakPassword):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.As(err, &throttled):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return err
+	}
 }
 
 func (s *UserServer) DeleteUser(ctx context.Context, req *userpb.DeleteUserRequest) (*userpb.DeleteUserResponse, error) {
@@ -168,3 +364,22 @@ func (s *UserServer) DeleteUser(ctx context.Context, req *userpb.DeleteUserReque
 
 	return &userpb.DeleteUserResponse{Success: true}, nil
 }
+
+func toProfile(user *domain.User) *userpb.UserProfile {
+	p := &userpb.UserProfile{
+		Id:            user.ID,
+		Username:      user.Username,
+		Email:         user.Email,
+		Balance:       money.ToProto(user.Balance),
+		Role:          user.Role,
+		EmailVerified: user.EmailVerified,
+		Active:        user.Active,
+	}
+	if e := user.Excluded(time.Now()); e != nil {
+		p.Excluded = true
+		if e.Until != nil {
+			p.ExcludedUntil = e.Until.Unix()
+		}
+	}
+	return p
+}
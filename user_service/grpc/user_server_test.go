@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"muchway/pkg/auth"
+	"muchway/pkg/money"
+	"muchway/user_service/domain"
+	"muchway/user_service/proto/userpb"
+	"muchway/user_service/usecase"
+)
+
+// registrations is a UserUsecase that records the users it registers.
+type registrations struct {
+	usecase.UserUsecase
+	users []*domain.User
+}
+
+func (r *registrations) Register(user *domain.User, password string) error {
+	r.users = append(r.users, user)
+	return nil
+}
+
+func TestOnlyAdminsSetRoleAndBalance(t *testing.T) {
+	cases := []struct {
+		name        string
+		claims      *auth.Claims
+		wantRole    string
+		wantBalance money.Money
+	}{
+		{"anonymous", nil, auth.RoleBettor, money.Money{}},
+		{"bettor", &auth.Claims{Subject: "42", Role: auth.RoleBettor}, auth.RoleBettor, money.Money{}},
+		{"service", &auth.Claims{Subject: "service:bet_service", Role: auth.RoleService}, auth.RoleBettor, money.Money{}},
+		{"finance", &auth.Claims{Subject: "7", Role: auth.RoleFinance}, auth.RoleBettor, money.Money{}},
+		{"admin", &auth.Claims{Subject: "1", Role: auth.RoleAdmin}, auth.RoleAdmin, money.MustParse("500.00")},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			users := &registrations{}
+			s := NewUserServer(users, nil, nil, nil, nil, auth.NewAuthorizer(Policy, nil), nil)
+			ctx := context.Background()
+			if c.claims != nil {
+				ctx = auth.NewContext(ctx, c.claims)
+			}
+			_, err := s.CreateUser(ctx, &userpb.CreateUserRequest{User: &userpb.User{
+				Username: "eve",
+				Email:    "eve@example.com",
+				Password: "correct horse battery staple",
+				Role:     auth.RoleAdmin,
+				Balance:  money.ToProto(money.MustParse("500.00")),
+			}})
+			if err != nil {
+				t.Fatalf("CreateUser = %v", err)
+			}
+			got := users.users[0]
+			if got.Role != c.wantRole || got.Balance != c.wantBalance {
+				t.Errorf("registered %s with %s, want %s with %s", got.Role, got.Balance, c.wantRole, c.wantBalance)
+			}
+		})
+	}
+}
+
+func (r *registrations) GetUserByID(ctx context.Context, id int64) (*domain.User, error) {
+	return &domain.User{ID: id, Username: "eve", Role: auth.RoleBettor}, nil
+}
+
+func (r *registrations) UpdateUser(user *domain.User) error {
+	r.users = append(r.users, user)
+	return nil
+}
+
+func TestOnlyAdminsChangeRoles(t *testing.T) {
+	cases := []struct {
+		name     string
+		claims   *auth.Claims
+		wantRole string
+	}{
+		{"owner", &auth.Claims{Subject: "42", Role: auth.RoleBettor}, auth.RoleBettor},
+		{"service", &auth.Claims{Subject: "service:bet_service", Role: auth.RoleService}, auth.RoleBettor},
+		{"admin", &auth.Claims{Subject: "1", Role: auth.RoleAdmin}, auth.RoleAdmin},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			users := &registrations{}
+			s := NewUserServer(users, nil, nil, nil, nil, auth.NewAuthorizer(Policy, nil), nil)
+			ctx := auth.NewContext(context.Background(), c.claims)
+			_, err := s.UpdateUser(ctx, &userpb.UpdateUserRequest{User: &userpb.UserProfile{Id: 42, Username: "eve", Role: auth.RoleAdmin}})
+			if err != nil {
+				t.Fatalf("UpdateUser = %v", err)
+			}
+			if got := users.users[0].Role; got != c.wantRole {
+				t.Errorf("role = %s, want %s", got, c.wantRole)
+			}
+		})
+	}
+}
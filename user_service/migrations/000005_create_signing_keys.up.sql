@@ -0,0 +1,8 @@
+-- Ed25519 keys that sign access tokens. The seed is the private key; a key
+-- is published in the JWKS until expires_at.
+CREATE TABLE IF NOT EXISTS signing_keys (
+    id TEXT PRIMARY KEY,
+    seed BYTEA NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at TIMESTAMPTZ NOT NULL
+);
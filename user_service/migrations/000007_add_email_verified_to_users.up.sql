@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMPTZ;
+-- Accounts created before verification existed are treated as verified
+UPDATE users SET email_verified_at = now() WHERE email_verified_at IS NULL;
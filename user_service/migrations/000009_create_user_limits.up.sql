@@ -0,0 +1,16 @@
+-- Responsible gambling limits. Money limits have an amount per period;
+-- session limits a duration and an empty period. A raise or removal waits
+-- in the pending_ columns until pending_effective_at.
+CREATE TABLE IF NOT EXISTS user_limits (
+    user_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    kind TEXT NOT NULL,
+    period TEXT NOT NULL DEFAULT '',
+    amount NUMERIC(12, 2),
+    duration_seconds BIGINT,
+    pending_amount NUMERIC(12, 2),
+    pending_duration_seconds BIGINT,
+    pending_remove BOOLEAN NOT NULL DEFAULT false,
+    pending_effective_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, kind, period)
+);
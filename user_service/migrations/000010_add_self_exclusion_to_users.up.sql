@@ -0,0 +1,4 @@
+-- Self-exclusion. excluded_until is NULL for a permanent exclusion; an
+-- exclusion whose excluded_until has passed is over.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS excluded_at TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS excluded_until TIMESTAMPTZ;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS user_kyc_documents;
+DROP TABLE IF EXISTS user_kyc;
@@ -0,0 +1,27 @@
+-- Identity verification. Users without a row are unverified. Review moves
+-- a pending row to verified or rejected.
+CREATE TABLE IF NOT EXISTS user_kyc (
+    user_id BIGINT PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'unverified',
+    rejection_reason TEXT NOT NULL DEFAULT '',
+    submitted_at TIMESTAMPTZ,
+    reviewed_at TIMESTAMPTZ,
+    reviewed_by TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS user_kyc_status_idx ON user_kyc (status, submitted_at);
+
+-- Uploaded documents. The files are in the blob store under blob_key.
+CREATE TABLE IF NOT EXISTS user_kyc_documents (
+    id UUID PRIMARY KEY,
+    user_id BIGINT NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    filename TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    blob_key TEXT NOT NULL,
+    uploaded_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS user_kyc_documents_user_idx ON user_kyc_documents (user_id, uploaded_at);
@@ -0,0 +1,4 @@
+-- users.balance is a copy of the wallet balance the payment service owns,
+-- kept current through SyncBalance. balance_version is the ledger version it
+-- was copied at, so that late updates are ignored.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS balance_version BIGINT NOT NULL DEFAULT 0;
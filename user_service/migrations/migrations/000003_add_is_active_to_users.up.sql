@@ -1 +0,0 @@
-ALTER TABLE users ADD COLUMN is_active BOOLEAN DEFAULT true;
@@ -5,6 +5,8 @@ import (
 	"log"
 
 	"github.com/streadway/amqp"
+
+	"muchway/pkg/consumer"
 )
 
 func StartConsumer(amqpURL, queueName string) error {
@@ -13,30 +15,14 @@ func StartConsumer(amqpURL, queueName string) error {
 		return fmt.Errorf("failed to connect to RabbitMQ: %w", err)
 	}
 
-	ch, err := conn.Channel()
-	if err != nil {
-		return fmt.Errorf("failed to open a channel: %w", err)
-	}
-
-	msgs, err := ch.Consume(
-		queueName,
-		"",
-		true,
-		false,
-		false,
-		false,
-		nil,
-	)
+	err = consumer.New(conn).Consume(queueName, func(body []byte) error {
+		log.Printf("Received message: %s", body)
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("failed to register a consumer: %w", err)
 	}
 
-	go func() {
-		log.Println("RabbitMQ consumer started...")
-		for d := range msgs {
-			log.Printf("Received message: %s", d.Body)
-		}
-	}()
-
+	log.Println("RabbitMQ consumer started...")
 	return nil
 }
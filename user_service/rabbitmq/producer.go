@@ -5,6 +5,8 @@ import (
 	"log"
 
 	"github.com/streadway/amqp"
+
+	"muchway/pkg/topology"
 )
 
 type Publisher struct {
@@ -19,27 +21,16 @@ func NewPublisher(conn *amqp.Connection) (*Publisher, error) {
 	return &Publisher{channel: ch}, nil
 }
 
-func (p *Publisher) Publish(eventName string, data interface{}) error {
+// Publish sends data to the users exchange with the given routing key.
+func (p *Publisher) Publish(routingKey string, data interface{}) error {
 	body, err := json.Marshal(data)
 	if err != nil {
 		return err
 	}
 
-	_, err = p.channel.QueueDeclare(
-		eventName,
-		true,
-		false,
-		false,
-		false,
-		nil,
-	)
-	if err != nil {
-		return err
-	}
-
 	err = p.channel.Publish(
-		"",
-		eventName,
+		topology.ExchangeUsers,
+		routingKey,
 		false,
 		false,
 		amqp.Publishing{
@@ -48,10 +39,10 @@ func (p *Publisher) Publish(eventName string, data interface{}) error {
 		},
 	)
 	if err != nil {
-		log.Printf("Failed to publish %s: %v", eventName, err)
+		log.Printf("Failed to publish %s: %v", routingKey, err)
 		return err
 	}
 
-	log.Printf("Published %s: %s", eventName, string(body))
+	log.Printf("Published %s: %s", routingKey, string(body))
 	return nil
 }
@@ -0,0 +1,18 @@
+package repository
+
+import "muchway/user_service/domain"
+
+type KYCRepository interface {
+	// GetKYC returns the user's verification with its documents, nil when
+	// the user never uploaded anything.
+	GetKYC(userID int64) (*domain.KYC, error)
+	// SaveKYC stores k provided its status is still from, and reports
+	// whether it was. This keeps two reviewers from deciding at once.
+	SaveKYC(k *domain.KYC, from domain.KYCStatus) (bool, error)
+	// ListByStatus returns the verifications in status, oldest submission
+	// first, without their documents.
+	ListByStatus(status domain.KYCStatus) ([]*domain.KYC, error)
+	AddDocument(d *domain.KYCDocument) error
+	// GetDocument returns nil when there is no such document.
+	GetDocument(id string) (*domain.KYCDocument, error)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import "muchway/pkg/limits"
+
+type LimitRepository interface {
+	// GetLimits returns the user's limits as stored, pending changes
+	// included.
+	GetLimits(userID int64) ([]limits.Limit, error)
+	// SaveLimit creates or replaces the user's limit of that kind and period.
+	SaveLimit(userID int64, l limits.Limit) error
+	DeleteLimit(userID int64, kind limits.Kind, period limits.Period) error
+}
@@ -0,0 +1,19 @@
+package repository
+
+import "muchway/user_service/domain"
+
+type MFARepository interface {
+	// GetTOTP returns nil when the user has not enrolled.
+	GetTOTP(userID int64) (*domain.TOTP, error)
+	// SaveTOTP starts a new, not yet enabled, enrolment and replaces the
+	// user's recovery codes.
+	SaveTOTP(t *domain.TOTP, recoveryCodeHashes []string) error
+	EnableTOTP(userID int64) error
+	DeleteTOTP(userID int64) error
+	// AdvanceStep records that the code for step was used. It reports false
+	// when that step or a later one was used already.
+	AdvanceStep(userID, step int64) (bool, error)
+	// UseRecoveryCode marks an unused code as used and reports whether there
+	// was one.
+	UseRecoveryCode(userID int64, codeHash string) (bool, error)
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"muchway/user_service/domain"
+	"muchway/user_service/repository"
+)
+
+type PostgresKYCRepository struct {
+	DB *sql.DB
+}
+
+func NewPostgresKYCRepository(db *sql.DB) repository.KYCRepository {
+	return &PostgresKYCRepository{DB: db}
+}
+
+const kycColumns = `user_id, status, rejection_reason, submitted_at, reviewed_at, reviewed_by`
+
+func scanKYC(row interface{ Scan(...any) error }) (*domain.KYC, error) {
+	var k domain.KYC
+	var submittedAt, reviewedAt sql.NullTime
+	if err := row.Scan(&k.UserID, &k.Status, &k.RejectionReason, &submittedAt, &reviewedAt, &k.ReviewedBy); err != nil {
+		return nil, err
+	}
+	if submittedAt.Valid {
+		k.SubmittedAt = &submittedAt.Time
+	}
+	if reviewedAt.Valid {
+		k.ReviewedAt = &reviewedAt.Time
+	}
+	return &k, nil
+}
+
+func (r *PostgresKYCRepository) GetKYC(userID int64) (*domain.KYC, error) {
+	k, err := scanKYC(r.DB.QueryRow(`SELECT `+kycColumns+` FROM user_kyc WHERE user_id = $1`, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	query := `SELECT id, user_id, type, filename, content_type, size_bytes, blob_key, uploaded_at
+		FROM user_kyc_documents WHERE user_id = $1 ORDER BY uploaded_at`
+	rows, err := r.DB.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var d domain.KYCDocument
+		if err := rows.Scan(&d.ID, &d.UserID, &d.Type, &d.Filename, &d.ContentType, &d.Size, &d.BlobKey, &d.UploadedAt); err != nil {
+			return nil, err
+		}
+		k.Documents = append(k.Documents, d)
+	}
+	return k, rows.Err()
+}
+
+func (r *PostgresKYCRepository) SaveKYC(k *domain.KYC, from domain.KYCStatus) (bool, error) {
+	query := `INSERT INTO user_kyc (user_id, status, rejection_reason, submitted_at, reviewed_at, reviewed_by)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id) DO UPDATE SET status = EXCLUDED.status, rejection_reason = EXCLUDED.rejection_reason,
+			submitted_at = EXCLUDED.submitted_at, reviewed_at = EXCLUDED.reviewed_at,
+			reviewed_by = EXCLUDED.reviewed_by, updated_at = now()
+		WHERE user_kyc.status = $7`
+	res, err := r.DB.Exec(query, k.UserID, k.Status, k.RejectionReason, k.SubmittedAt, k.ReviewedAt, k.ReviewedBy, from)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (r *PostgresKYCRepository) ListByStatus(status domain.KYCStatus) ([]*domain.KYC, error) {
+	rows, err := r.DB.Query(`SELECT `+kycColumns+` FROM user_kyc WHERE status = $1 ORDER BY submitted_at, user_id`, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ks []*domain.KYC
+	for rows.Next() {
+		k, err := scanKYC(rows)
+		if err != nil {
+			return nil, err
+		}
+		ks = append(ks, k)
+	}
+	return ks, rows.Err()
+}
+
+func (r *PostgresKYCRepository) AddDocument(d *domain.KYCDocument) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// The first upload starts the user's verification
+	if _, err := tx.Exec(`INSERT INTO user_kyc (user_id) VALUES ($1) ON CONFLICT (user_id) DO NOTHING`, d.UserID); err != nil {
+		return err
+	}
+	query := `INSERT INTO user_kyc_documents (id, user_id, type, filename, content_type, size_bytes, blob_key, uploaded_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	if _, err := tx.Exec(query, d.ID, d.UserID, d.Type, d.Filename, d.ContentType, d.Size, d.BlobKey, d.UploadedAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresKYCRepository) GetDocument(id string) (*domain.KYCDocument, error) {
+	query := `SELECT id, user_id, type, filename, content_type, size_bytes, blob_key, uploaded_at
+		FROM user_kyc_documents WHERE id = $1`
+	var d domain.KYCDocument
+	err := r.DB.QueryRow(query, id).Scan(&d.ID, &d.UserID, &d.Type, &d.Filename, &d.ContentType, &d.Size, &d.BlobKey, &d.UploadedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &d, nil
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"muchway/pkg/limits"
+	"muchway/pkg/money"
+	"muchway/user_service/repository"
+)
+
+type PostgresLimitRepository struct {
+	DB *sql.DB
+}
+
+func NewPostgresLimitRepository(db *sql.DB) repository.LimitRepository {
+	return &PostgresLimitRepository{DB: db}
+}
+
+func (r *PostgresLimitRepository) GetLimits(userID int64) ([]limits.Limit, error) {
+	query := `SELECT kind, period, amount, COALESCE(duration_seconds, 0), pending_amount,
+		COALESCE(pending_duration_seconds, 0), pending_remove, pending_effective_at
+		FROM user_limits WHERE user_id = $1 ORDER BY kind, period`
+	rows, err := r.DB.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ls []limits.Limit
+	for rows.Next() {
+		var l limits.Limit
+		var seconds, pendingSeconds int64
+		var pendingAmount money.Money
+		var pendingRemove bool
+		var effectiveAt sql.NullTime
+		if err := rows.Scan(&l.Kind, &l.Period, &l.Amount, &seconds, &pendingAmount, &pendingSeconds, &pendingRemove, &effectiveAt); err != nil {
+			return nil, err
+		}
+		l.Duration = time.Duration(seconds) * time.Second
+		if effectiveAt.Valid {
+			l.Pending = &limits.Change{
+				Amount:      pendingAmount,
+				Duration:    time.Duration(pendingSeconds) * time.Second,
+				Remove:      pendingRemove,
+				EffectiveAt: effectiveAt.Time,
+			}
+		}
+		ls = append(ls, l)
+	}
+	return ls, rows.Err()
+}
+
+func (r *PostgresLimitRepository) SaveLimit(userID int64, l limits.Limit) error {
+	var amount, pendingAmount interface{}
+	var seconds, pendingSeconds sql.NullInt64
+	var pendingRemove bool
+	var effectiveAt sql.NullTime
+	if l.Kind == limits.Session {
+		seconds = sql.NullInt64{Int64: int64(l.Duration / time.Second), Valid: true}
+	} else {
+		amount = l.Amount
+	}
+	if c := l.Pending; c != nil {
+		pendingRemove = c.Remove
+		effectiveAt = sql.NullTime{Time: c.EffectiveAt, Valid: true}
+		switch {
+		case c.Remove:
+		case l.Kind == limits.Session:
+			pendingSeconds = sql.NullInt64{Int64: int64(c.Duration / time.Second), Valid: true}
+		default:
+			pendingAmount = c.Amount
+		}
+	}
+
+	query := `INSERT INTO user_limits (user_id, kind, period, amount, duration_seconds, pending_amount,
+			pending_duration_seconds, pending_remove, pending_effective_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, now())
+		ON CONFLICT (user_id, kind, period) DO UPDATE SET amount = EXCLUDED.amount,
+			duration_seconds = EXCLUDED.duration_seconds, pending_amount = EXCLUDED.pending_amount,
+			pending_duration_seconds = EXCLUDED.pending_duration_seconds, pending_remove = EXCLUDED.pending_remove,
+			pending_effective_at = EXCLUDED.pending_effective_at, updated_at = now()`
+	_, err := r.DB.Exec(query, userID, l.Kind, l.Period, amount, seconds, pendingAmount, pendingSeconds, pendingRemove, effectiveAt)
+	return err
+}
+
+func (r *PostgresLimitRepository) DeleteLimit(userID int64, kind limits.Kind, period limits.Period) error {
+	_, err := r.DB.Exec(`DELETE FROM user_limits WHERE user_id = $1 AND kind = $2 AND period = $3`, userID, kind, period)
+	return err
+}
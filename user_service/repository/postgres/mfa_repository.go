@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"muchway/user_service/domain"
+	"muchway/user_service/repository"
+)
+
+type PostgresMFARepository struct {
+	DB *sql.DB
+}
+
+func NewPostgresMFARepository(db *sql.DB) repository.MFARepository {
+	return &PostgresMFARepository{DB: db}
+}
+
+func (r *PostgresMFARepository) GetTOTP(userID int64) (*domain.TOTP, error) {
+	query := `SELECT user_id, secret, enabled_at, last_used_step FROM user_totp WHERE user_id = $1`
+	var t domain.TOTP
+	var enabledAt sql.NullTime
+	err := r.DB.QueryRow(query, userID).Scan(&t.UserID, &t.Secret, &enabledAt, &t.LastUsedStep)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if enabledAt.Valid {
+		t.EnabledAt = &enabledAt.Time
+	}
+	return &t, nil
+}
+
+func (r *PostgresMFARepository) SaveTOTP(t *domain.TOTP, recoveryCodeHashes []string) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO user_totp (user_id, secret) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, enabled_at = NULL, last_used_step = 0, created_at = now()`
+	if _, err := tx.Exec(query, t.UserID, t.Secret); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM user_recovery_codes WHERE user_id = $1`, t.UserID); err != nil {
+		return err
+	}
+	for _, h := range recoveryCodeHashes {
+		if _, err := tx.Exec(`INSERT INTO user_recovery_codes (user_id, code_hash) VALUES ($1, $2)`, t.UserID, h); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresMFARepository) EnableTOTP(userID int64) error {
+	_, err := r.DB.Exec(`UPDATE user_totp SET enabled_at = now() WHERE user_id = $1`, userID)
+	return err
+}
+
+func (r *PostgresMFARepository) DeleteTOTP(userID int64) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM user_totp WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresMFARepository) AdvanceStep(userID, step int64) (bool, error) {
+	res, err := r.DB.Exec(`UPDATE user_totp SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2`, userID, step)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (r *PostgresMFARepository) UseRecoveryCode(userID int64, codeHash string) (bool, error) {
+	res, err := r.DB.Exec(`UPDATE user_recovery_codes SET used_at = now() WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`, userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
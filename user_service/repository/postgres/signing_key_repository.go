@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"crypto/ed25519"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"muchway/pkg/auth"
+	"muchway/user_service/repository"
+)
+
+type PostgresSigningKeyRepository struct {
+	DB *sql.DB
+}
+
+func NewPostgresSigningKeyRepository(db *sql.DB) repository.SigningKeyRepository {
+	return &PostgresSigningKeyRepository{DB: db}
+}
+
+func (r *PostgresSigningKeyRepository) List(ctx context.Context) ([]*auth.SigningKey, error) {
+	rows, err := r.DB.QueryContext(ctx,
+		`SELECT id, seed, created_at, expires_at FROM signing_keys WHERE expires_at > now() ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*auth.SigningKey
+	for rows.Next() {
+		var k auth.SigningKey
+		var seed []byte
+		if err := rows.Scan(&k.ID, &seed, &k.CreatedAt, &k.ExpiresAt); err != nil {
+			return nil, err
+		}
+		if len(seed) != ed25519.SeedSize {
+			return nil, fmt.Errorf("signing key %s has a malformed seed", k.ID)
+		}
+		k.Private = ed25519.NewKeyFromSeed(seed)
+		keys = append(keys, &k)
+	}
+	return keys, rows.Err()
+}
+
+func (r *PostgresSigningKeyRepository) Create(ctx context.Context, key *auth.SigningKey) error {
+	_, err := r.DB.ExecContext(ctx,
+		`INSERT INTO signing_keys (id, seed, created_at, expires_at) VALUES ($1, $2, $3, $4)`,
+		key.ID, key.Private.Seed(), key.CreatedAt, key.ExpiresAt)
+	return err
+}
+
+func (r *PostgresSigningKeyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	res, err := r.DB.ExecContext(ctx, `DELETE FROM signing_keys WHERE expires_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -1,72 +1,104 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
+	"muchway/pkg/limits"
+	"muchway/pkg/money"
+	"muchway/pkg/outbox"
 	"muchway/user_service/domain"
 	"muchway/user_service/repository"
 )
 
+// OutboxTable holds the messages waiting to be relayed to RabbitMQ.
+const OutboxTable = "user_outbox"
+
+// AuditTable records the calls refused for lack of permission.
+const AuditTable = "user_audit_log"
+
 type PostgresUserRepository struct {
-	DB *sql.DB
+	DB     *sql.DB
+	outbox *outbox.Store
 }
 
 func NewPostgresUserRepository(db *sql.DB) repository.UserRepository {
-	return &PostgresUserRepository{DB: db}
-}
-
-func (r *PostgresUserRepository) Create(user *domain.User) error {
-	query := `INSERT INTO users (username, password, email, balance, role) VALUES ($1, $2, $3, $4, $5)`
-	_, err := r.DB.Exec(query, user.Username, user.Password, user.Email, user.Balance, user.Role)
-	return err
+	return &PostgresUserRepository{DB: db, outbox: outbox.NewStore(OutboxTable)}
 }
 
-func (r *PostgresUserRepository) GetByID(id int64) (*domain.User, error) {
-	query := `SELECT id, username, password, email, balance, role FROM users WHERE id = $1`
-	row := r.DB.QueryRow(query, id)
+// userColumns are the columns scanUser reads, in order.
+const userColumns = `id, username, email, balance, role, email_verified_at IS NOT NULL, COALESCE(is_active, true),
+	excluded_at, excluded_until`
 
+func scanUser(row interface{ Scan(...interface{}) error }) (*domain.User, error) {
 	var user domain.User
-	err := row.Scan(&user.ID, &user.Username, &user.Password, &user.Email, &user.Balance, &user.Role)
+	var excludedAt, excludedUntil sql.NullTime
+	err := row.Scan(&user.ID, &user.Username, &user.Email, &user.Balance, &user.Role, &user.EmailVerified, &user.Active,
+		&excludedAt, &excludedUntil)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
 		return nil, err
 	}
+	if excludedAt.Valid {
+		user.Exclusion = &limits.Exclusion{Since: excludedAt.Time}
+		if excludedUntil.Valid {
+			user.Exclusion.Until = &excludedUntil.Time
+		}
+	}
 	return &user, nil
 }
 
+// Create inserts the user and sets user.ID.
+func (r *PostgresUserRepository) Create(user *domain.User, passwordHash string, msgs ...outbox.Message) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO users (username, password, email, balance, role) VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	if err := tx.QueryRow(query, user.Username, passwordHash, user.Email, user.Balance, user.Role).Scan(&user.ID); err != nil {
+		return err
+	}
+	if err := r.outbox.Add(context.Background(), tx, msgs...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresUserRepository) GetByID(id int64) (*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+	row := r.DB.QueryRow(query, id)
+
+	user, err := scanUser(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return user, err
+}
+
 func (r *PostgresUserRepository) GetByUsername(username string) (*domain.User, error) {
-	query := `SELECT id, username, password, email, balance, role FROM users WHERE username = $1`
+	query := `SELECT ` + userColumns + ` FROM users WHERE username = $1`
 	row := r.DB.QueryRow(query, username)
 
-	var user domain.User
-	err := row.Scan(&user.ID, &user.Username, &user.Password, &user.Email, &user.Balance, &user.Role)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
-		return nil, err
+	user, err := scanUser(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
 	}
-	return &user, nil
+	return user, err
 }
 
 func (r *PostgresUserRepository) GetByEmail(email string) (*domain.User, error) {
-	query := `SELECT id, username, password, email, balance, role FROM users WHERE email = $1`
+	query := `SELECT ` + userColumns + ` FROM users WHERE email = $1`
 	row := r.DB.QueryRow(query, email)
 
-	var user domain.User
-	err := row.Scan(&user.ID, &user.Username, &user.Password, &user.Email, &user.Balance, &user.Role)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
-		return nil, err
+	user, err := scanUser(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
 	}
-	return &user, nil
+	return user, err
 }
 
 func (r *PostgresUserRepository) GetAll() ([]*domain.User, error) {
-	query := `SELECT id, username, password, email, balance, role FROM users`
+	query := `SELECT ` + userColumns + ` FROM users`
 	rows, err := r.DB.Query(query)
 	if err != nil {
 		return nil, err
@@ -75,24 +107,105 @@ func (r *PostgresUserRepository) GetAll() ([]*domain.User, error) {
 
 	var users []*domain.User
 	for rows.Next() {
-		var user domain.User
-		err := rows.Scan(&user.ID, &user.Username, &user.Password, &user.Email, &user.Balance, &user.Role)
+		user, err := scanUser(rows)
 		if err != nil {
 			return nil, err
 		}
-		users = append(users, &user)
+		users = append(users, user)
 	}
 	return users, nil
 }
 
-func (r *PostgresUserRepository) Update(user *domain.User) error {
-	query := `UPDATE users SET password = $1, email = $2, balance = $3, role = $4 WHERE id = $5`
-	_, err := r.DB.Exec(query, user.Password, user.Email, user.Balance, user.Role, user.ID)
+func (r *PostgresUserRepository) Update(user *domain.User, msgs ...outbox.Message) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE users SET email = $1, role = $2,
+		email_verified_at = CASE WHEN email = $1 THEN email_verified_at END
+		WHERE id = $3`
+	if _, err := tx.Exec(query, user.Email, user.Role, user.ID); err != nil {
+		return err
+	}
+	if err := r.outbox.Add(context.Background(), tx, msgs...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresUserRepository) GetCredentials(userID int64) (*domain.Credentials, error) {
+	query := `SELECT id, password FROM users WHERE id = $1`
+	var c domain.Credentials
+	err := r.DB.QueryRow(query, userID).Scan(&c.UserID, &c.PasswordHash)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *PostgresUserRepository) UpdatePassword(userID int64, passwordHash string) error {
+	query := `UPDATE users SET password = $1 WHERE id = $2`
+	_, err := r.DB.Exec(query, passwordHash, userID)
 	return err
 }
 
-func (r *PostgresUserRepository) Delete(username string) error {
-	query := `DELETE FROM users WHERE username = $1`
-	_, err := r.DB.Exec(query, username)
+func (r *PostgresUserRepository) MarkEmailVerified(userID int64) error {
+	query := `UPDATE users SET email_verified_at = now() WHERE id = $1 AND email_verified_at IS NULL`
+	_, err := r.DB.Exec(query, userID)
+	return err
+}
+
+func (r *PostgresUserRepository) SetActive(userID int64, active bool) error {
+	query := `UPDATE users SET is_active = $1 WHERE id = $2`
+	_, err := r.DB.Exec(query, active, userID)
 	return err
 }
+
+func (r *PostgresUserRepository) SetBalance(userID int64, balance money.Money, version int64) (bool, error) {
+	query := `UPDATE users SET balance = $1, balance_version = $2 WHERE id = $3 AND balance_version < $2`
+	res, err := r.DB.Exec(query, balance, version, userID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (r *PostgresUserRepository) SetExclusion(userID int64, e limits.Exclusion, msgs ...outbox.Message) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE users SET excluded_at = $1, excluded_until = $2 WHERE id = $3`
+	if _, err := tx.Exec(query, e.Since, e.Until, userID); err != nil {
+		return err
+	}
+	if err := r.outbox.Add(context.Background(), tx, msgs...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *PostgresUserRepository) Delete(username string, msgs ...outbox.Message) error {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `DELETE FROM users WHERE username = $1`
+	if _, err := tx.Exec(query, username); err != nil {
+		return err
+	}
+	if err := r.outbox.Add(context.Background(), tx, msgs...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"muchway/pkg/auth"
+)
+
+type SigningKeyRepository interface {
+	// List returns the keys that have not expired, newest first.
+	List(ctx context.Context) ([]*auth.SigningKey, error)
+	Create(ctx context.Context, key *auth.SigningKey) error
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
@@ -1,13 +1,33 @@
 package repository
 
-import "muchway/user_service/domain"
+import (
+	"muchway/pkg/limits"
+	"muchway/pkg/money"
+	"muchway/pkg/outbox"
+	"muchway/user_service/domain"
+)
 
 type UserRepository interface {
-	Create(user *domain.User) error
+	// Create, Update and Delete write the given outbox messages in the same
+	// transaction as the change.
+	Create(user *domain.User, passwordHash string, msgs ...outbox.Message) error
 	GetByID(id int64) (*domain.User, error)
 	GetByUsername(username string) (*domain.User, error)
 	GetByEmail(email string) (*domain.User, error)
 	GetAll() ([]*domain.User, error)
-	Update(user *domain.User) error
-	Delete(username string) error
+	// Update changes the profile; the password is changed with
+	// UpdatePassword and the balance with SetBalance. Changing the email
+	// clears its verification.
+	Update(user *domain.User, msgs ...outbox.Message) error
+	GetCredentials(userID int64) (*domain.Credentials, error)
+	UpdatePassword(userID int64, passwordHash string) error
+	MarkEmailVerified(userID int64) error
+	SetActive(userID int64, active bool) error
+	// SetBalance stores the wallet balance at the given ledger version,
+	// unless a newer one is already stored. It reports whether it did.
+	SetBalance(userID int64, balance money.Money, version int64) (bool, error)
+	// SetExclusion records a self-exclusion and writes the given outbox
+	// messages in the same transaction.
+	SetExclusion(userID int64, e limits.Exclusion, msgs ...outbox.Message) error
+	Delete(username string, msgs ...outbox.Message) error
 }
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"muchway/user_service/domain"
+)
+
+// Purposes of the tokens sent by email. A token only works for the purpose
+// it was issued for.
+const (
+	purposeVerifyEmail   = "verify_email"
+	purposeResetPassword = "reset_password"
+	purposeUnlockAccount = "unlock_account"
+)
+
+// emailTokens issues the single-use tokens sent in verification and reset
+// links. A token is signed, so a forged one is rejected without a lookup,
+// and is also recorded in Redis until it is used or expires, which makes it
+// single-use.
+type emailTokens struct {
+	redis  *redis.Client
+	secret []byte
+}
+
+type emailTokenClaims struct {
+	Purpose   string `json:"p"`
+	UserID    int64  `json:"u"`
+	Nonce     string `json:"n"`
+	ExpiresAt int64  `json:"e"`
+}
+
+var b64 = base64.RawURLEncoding
+
+func emailTokenKey(purpose, nonce string) string {
+	return "email_token:" + purpose + ":" + nonce
+}
+
+// latestEmailTokenKey points at the newest token of a purpose for a user, so
+// issuing a token retires the previous one.
+func latestEmailTokenKey(purpose string, userID int64) string {
+	return "email_token_latest:" + purpose + ":" + strconv.FormatInt(userID, 10)
+}
+
+func (t *emailTokens) issue(ctx context.Context, purpose string, userID int64, ttl time.Duration) (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	claims := emailTokenClaims{
+		Purpose:   purpose,
+		UserID:    userID,
+		Nonce:     hex.EncodeToString(nonce),
+		ExpiresAt: time.Now().Add(ttl).Unix(),
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	latest := latestEmailTokenKey(purpose, userID)
+	previous, err := t.redis.Get(ctx, latest).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return "", err
+	}
+	pipe := t.redis.TxPipeline()
+	if previous != "" {
+		pipe.Del(ctx, emailTokenKey(purpose, previous))
+	}
+	pipe.Set(ctx, emailTokenKey(purpose, claims.Nonce), userID, ttl)
+	pipe.Set(ctx, latest, claims.Nonce, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", err
+	}
+
+	encoded := b64.EncodeToString(payload)
+	return encoded + "." + b64.EncodeToString(t.sign(encoded)), nil
+}
+
+// consume checks a token and marks it used, returning the user it was issued
+// to.
+func (t *emailTokens) consume(ctx context.Context, purpose, token string) (int64, error) {
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, domain.ErrInvalidEmailToken
+	}
+	gotSig, err := b64.DecodeString(sig)
+	if err != nil || !hmac.Equal(gotSig, t.sign(encoded)) {
+		return 0, domain.ErrInvalidEmailToken
+	}
+	payload, err := b64.DecodeString(encoded)
+	if err != nil {
+		return 0, domain.ErrInvalidEmailToken
+	}
+	var claims emailTokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return 0, domain.ErrInvalidEmailToken
+	}
+	if claims.Purpose != purpose || time.Now().Unix() >= claims.ExpiresAt {
+		return 0, domain.ErrInvalidEmailToken
+	}
+
+	stored, err := t.redis.GetDel(ctx, emailTokenKey(purpose, claims.Nonce)).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, domain.ErrInvalidEmailToken
+	}
+	if err != nil {
+		return 0, err
+	}
+	if stored != claims.UserID {
+		return 0, domain.ErrInvalidEmailToken
+	}
+	return claims.UserID, nil
+}
+
+func (t *emailTokens) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"muchway/user_service/domain"
+)
+
+func newEmailTokens(t *testing.T) (*emailTokens, func(time.Duration)) {
+	t.Helper()
+	mr, rdb := newTestRedis(t)
+	return &emailTokens{redis: rdb, secret: []byte("test-secret")}, mr.FastForward
+}
+
+// forge signs claims with the tokens' secret and records the nonce, as
+// issue would, whatever the claims say.
+func forge(t *testing.T, tokens *emailTokens, claims emailTokenClaims) string {
+	t.Helper()
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tokens.redis.Set(context.Background(), emailTokenKey(claims.Purpose, claims.Nonce), claims.UserID, time.Hour).Err(); err != nil {
+		t.Fatal(err)
+	}
+	encoded := b64.EncodeToString(payload)
+	return encoded + "." + b64.EncodeToString(tokens.sign(encoded))
+}
+
+func TestConsumeEmailToken(t *testing.T) {
+	ctx := context.Background()
+	cases := []struct {
+		name    string
+		token   func(*testing.T, *emailTokens) string
+		purpose string
+		want    error
+	}{
+		{"valid", func(t *testing.T, tokens *emailTokens) string {
+			token, _ := tokens.issue(ctx, purposeResetPassword, 42, time.Hour)
+			return token
+		}, purposeResetPassword, nil},
+		{"other purpose", func(t *testing.T, tokens *emailTokens) string {
+			token, _ := tokens.issue(ctx, purposeVerifyEmail, 42, time.Hour)
+			return token
+		}, purposeResetPassword, domain.ErrInvalidEmailToken},
+		{"expired claims", func(t *testing.T, tokens *emailTokens) string {
+			return forge(t, tokens, emailTokenClaims{Purpose: purposeResetPassword, UserID: 42, Nonce: "n1",
+				ExpiresAt: time.Now().Add(-time.Second).Unix()})
+		}, purposeResetPassword, domain.ErrInvalidEmailToken},
+		{"another user's record", func(t *testing.T, tokens *emailTokens) string {
+			token := forge(t, tokens, emailTokenClaims{Purpose: purposeResetPassword, UserID: 42, Nonce: "n1",
+				ExpiresAt: time.Now().Add(time.Hour).Unix()})
+			tokens.redis.Set(ctx, emailTokenKey(purposeResetPassword, "n1"), 7, time.Hour)
+			return token
+		}, purposeResetPassword, domain.ErrInvalidEmailToken},
+		{"claims changed after signing", func(t *testing.T, tokens *emailTokens) string {
+			token, _ := tokens.issue(ctx, purposeResetPassword, 7, time.Hour)
+			payload, _ := b64.DecodeString(strings.Split(token, ".")[0])
+			var claims emailTokenClaims
+			json.Unmarshal(payload, &claims)
+			claims.UserID = 42
+			payload, _ = json.Marshal(claims)
+			return b64.EncodeToString(payload) + "." + strings.Split(token, ".")[1]
+		}, purposeResetPassword, domain.ErrInvalidEmailToken},
+		{"signed with another secret", func(t *testing.T, tokens *emailTokens) string {
+			other := &emailTokens{redis: tokens.redis, secret: []byte("other-secret")}
+			token, _ := other.issue(ctx, purposeResetPassword, 42, time.Hour)
+			return token
+		}, purposeResetPassword, domain.ErrInvalidEmailToken},
+		{"not a token", func(*testing.T, *emailTokens) string { return "garbage" }, purposeResetPassword, domain.ErrInvalidEmailToken},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tokens, _ := newEmailTokens(t)
+			userID, err := tokens.consume(ctx, c.purpose, c.token(t, tokens))
+			if !errors.Is(err, c.want) {
+				t.Fatalf("consume = %v, want %v", err, c.want)
+			}
+			if err == nil && userID != 42 {
+				t.Errorf("consume = user %d, want 42", userID)
+			}
+		})
+	}
+}
+
+func TestEmailTokensAreSingleUse(t *testing.T) {
+	ctx := context.Background()
+	tokens, _ := newEmailTokens(t)
+	token, err := tokens.issue(ctx, purposeVerifyEmail, 42, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tokens.consume(ctx, purposeVerifyEmail, token); err != nil {
+		t.Fatalf("first use = %v", err)
+	}
+	if _, err := tokens.consume(ctx, purposeVerifyEmail, token); !errors.Is(err, domain.ErrInvalidEmailToken) {
+		t.Errorf("second use = %v, want ErrInvalidEmailToken", err)
+	}
+}
+
+func TestEmailTokenExpires(t *testing.T) {
+	ctx := context.Background()
+	tokens, fastForward := newEmailTokens(t)
+	token, err := tokens.issue(ctx, purposeResetPassword, 42, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The Redis record lapses with the token
+	fastForward(time.Hour)
+	if _, err := tokens.consume(ctx, purposeResetPassword, token); !errors.Is(err, domain.ErrInvalidEmailToken) {
+		t.Errorf("consume after the TTL = %v, want ErrInvalidEmailToken", err)
+	}
+}
+
+func TestNewEmailTokenRetiresThePrevious(t *testing.T) {
+	ctx := context.Background()
+	tokens, _ := newEmailTokens(t)
+	first, _ := tokens.issue(ctx, purposeResetPassword, 42, time.Hour)
+	second, _ := tokens.issue(ctx, purposeResetPassword, 42, time.Hour)
+	// A token for another purpose or user is not affected
+	verify, _ := tokens.issue(ctx, purposeVerifyEmail, 42, time.Hour)
+	other, _ := tokens.issue(ctx, purposeResetPassword, 7, time.Hour)
+
+	if _, err := tokens.consume(ctx, purposeResetPassword, first); !errors.Is(err, domain.ErrInvalidEmailToken) {
+		t.Errorf("superseded token = %v, want ErrInvalidEmailToken", err)
+	}
+	for _, c := range []struct{ name, purpose, token string }{
+		{"newest", purposeResetPassword, second},
+		{"verification", purposeVerifyEmail, verify},
+		{"other user's", purposeResetPassword, other},
+	} {
+		if _, err := tokens.consume(ctx, c.purpose, c.token); err != nil {
+			t.Errorf("%s token = %v", c.name, err)
+		}
+	}
+}
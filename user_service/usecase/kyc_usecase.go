@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"muchway/pkg/blob"
+	"muchway/pkg/lifecycle"
+	"muchway/user_service/domain"
+	"muchway/user_service/email"
+	"muchway/user_service/repository"
+)
+
+type KYCConfig struct {
+	// MaxDocumentSize is the largest upload accepted, in bytes.
+	MaxDocumentSize int64
+	// Background tracks the review emails, so that shutdown waits for them.
+	Background *lifecycle.Group
+}
+
+// documentTypes are the content types accepted for documents. The type is
+// sniffed from the upload rather than taken from the client.
+var documentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"application/pdf": true,
+}
+
+// KYCUsecase runs identity verification. Users upload documents and submit
+// them; back office verifies or rejects the submission. payment_service
+// asks for the status before large withdrawals.
+type KYCUsecase interface {
+	// GetKYC returns the user's verification, unverified with no documents
+	// if they never started.
+	GetKYC(userID int64) (*domain.KYC, error)
+	// Upload stores a document read from r.
+	Upload(userID int64, docType domain.DocumentType, filename string, r io.Reader) (*domain.KYCDocument, error)
+	Submit(userID int64) (*domain.KYC, error)
+	// ListPending returns the submissions waiting for review, oldest first.
+	ListPending() ([]*domain.KYC, error)
+	// Review verifies or rejects a pending submission and emails the user.
+	Review(userID int64, approve bool, reason, reviewer string) (*domain.KYC, error)
+	// OpenDocument returns a document and its file, which the caller closes.
+	OpenDocument(id string) (*domain.KYCDocument, io.ReadCloser, error)
+}
+
+type kycUsecase struct {
+	repo         repository.KYCRepository
+	users        repository.UserRepository
+	blobs        blob.Store
+	emailService email.EmailService
+	cfg          KYCConfig
+}
+
+func NewKYCUsecase(repo repository.KYCRepository, users repository.UserRepository, blobs blob.Store, emailService email.EmailService, cfg KYCConfig) KYCUsecase {
+	return &kycUsecase{repo: repo, users: users, blobs: blobs, emailService: emailService, cfg: cfg}
+}
+
+func (u *kycUsecase) GetKYC(userID int64) (*domain.KYC, error) {
+	k, err := u.repo.GetKYC(userID)
+	if err != nil {
+		return nil, err
+	}
+	if k == nil {
+		return &domain.KYC{UserID: userID, Status: domain.KYCUnverified}, nil
+	}
+	return k, nil
+}
+
+func (u *kycUsecase) Upload(userID int64, docType domain.DocumentType, filename string, r io.Reader) (*domain.KYCDocument, error) {
+	if !docType.Valid() {
+		return nil, domain.ErrInvalidDocumentType
+	}
+	k, err := u.GetKYC(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !k.CanUpload() {
+		return nil, domain.ErrKYCNotAllowed
+	}
+
+	br := bufio.NewReaderSize(r, 512)
+	head, err := br.Peek(512)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	contentType := strings.SplitN(http.DetectContentType(head), ";", 2)[0]
+	if !documentTypes[contentType] {
+		return nil, domain.ErrUnsupportedDocument
+	}
+
+	doc := &domain.KYCDocument{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		Type:        docType,
+		Filename:    path.Base(strings.ReplaceAll(filename, `\`, "/")),
+		ContentType: contentType,
+		UploadedAt:  time.Now(),
+	}
+	doc.BlobKey = fmt.Sprintf("kyc/%d/%s", userID, doc.ID)
+
+	ctx := context.Background()
+	// Read one byte past the limit to tell a file of exactly the maximum
+	// size from a larger one
+	n, err := u.blobs.Put(ctx, doc.BlobKey, io.LimitReader(br, u.cfg.MaxDocumentSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if n > u.cfg.MaxDocumentSize {
+		u.deleteBlob(doc.BlobKey)
+		return nil, fmt.Errorf("%w: the limit is %d MB", domain.ErrDocumentTooLarge, u.cfg.MaxDocumentSize>>20)
+	}
+	doc.Size = n
+	if err := u.repo.AddDocument(doc); err != nil {
+		u.deleteBlob(doc.BlobKey)
+		return nil, err
+	}
+	return doc, nil
+}
+
+func (u *kycUsecase) Submit(userID int64) (*domain.KYC, error) {
+	k, err := u.GetKYC(userID)
+	if err != nil {
+		return nil, err
+	}
+	from := k.Status
+	if err := k.Submit(time.Now()); err != nil {
+		return nil, err
+	}
+	if err := u.save(k, from); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+func (u *kycUsecase) ListPending() ([]*domain.KYC, error) {
+	return u.repo.ListByStatus(domain.KYCPending)
+}
+
+func (u *kycUsecase) Review(userID int64, approve bool, reason, reviewer string) (*domain.KYC, error) {
+	k, err := u.GetKYC(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := k.Review(approve, strings.TrimSpace(reason), reviewer, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := u.save(k, domain.KYCPending); err != nil {
+		return nil, err
+	}
+
+	user, err := u.users.GetByID(userID)
+	if err != nil || user == nil {
+		log.Println("Failed to load user for KYC email:", err)
+		return k, nil
+	}
+	u.cfg.Background.Go(func() {
+		if err := u.emailService.SendKYCReviewed(user.Email, user.Username, approve, k.RejectionReason); err != nil {
+			log.Println("Failed to send KYC review email:", err)
+		}
+	})
+	return k, nil
+}
+
+func (u *kycUsecase) OpenDocument(id string) (*domain.KYCDocument, io.ReadCloser, error) {
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, nil, domain.ErrKYCDocumentNotFound
+	}
+	doc, err := u.repo.GetDocument(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if doc == nil {
+		return nil, nil, domain.ErrKYCDocumentNotFound
+	}
+	f, err := u.blobs.Get(context.Background(), doc.BlobKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return doc, f, nil
+}
+
+func (u *kycUsecase) save(k *domain.KYC, from domain.KYCStatus) error {
+	saved, err := u.repo.SaveKYC(k, from)
+	if err != nil {
+		return err
+	}
+	if !saved {
+		return domain.ErrKYCSubmissionConflict
+	}
+	return nil
+}
+
+func (u *kycUsecase) deleteBlob(key string) {
+	if err := u.blobs.Delete(context.Background(), key); err != nil {
+		log.Println("Failed to delete KYC document:", err)
+	}
+}
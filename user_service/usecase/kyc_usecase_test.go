@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+
+	"muchway/pkg/blob"
+	"muchway/user_service/domain"
+)
+
+// fakeKYCRepo keeps verifications and documents in memory.
+type fakeKYCRepo struct {
+	mu        sync.Mutex
+	statuses  map[int64]domain.KYCStatus
+	documents []domain.KYCDocument
+}
+
+func newFakeKYCRepo() *fakeKYCRepo {
+	return &fakeKYCRepo{statuses: map[int64]domain.KYCStatus{}}
+}
+
+func (r *fakeKYCRepo) GetKYC(userID int64) (*domain.KYC, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	k := &domain.KYC{UserID: userID, Status: r.statuses[userID]}
+	for _, d := range r.documents {
+		if d.UserID == userID {
+			k.Documents = append(k.Documents, d)
+		}
+	}
+	if k.Status == "" {
+		if len(k.Documents) == 0 {
+			return nil, nil
+		}
+		k.Status = domain.KYCUnverified
+	}
+	return k, nil
+}
+
+func (r *fakeKYCRepo) SaveKYC(k *domain.KYC, from domain.KYCStatus) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current := r.statuses[k.UserID]
+	if current == "" {
+		current = domain.KYCUnverified
+	}
+	if current != from {
+		return false, nil
+	}
+	r.statuses[k.UserID] = k.Status
+	return true, nil
+}
+
+func (r *fakeKYCRepo) ListByStatus(status domain.KYCStatus) ([]*domain.KYC, error) {
+	return nil, nil
+}
+
+func (r *fakeKYCRepo) AddDocument(d *domain.KYCDocument) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.documents = append(r.documents, *d)
+	return nil
+}
+
+func (r *fakeKYCRepo) GetDocument(id string) (*domain.KYCDocument, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, d := range r.documents {
+		if d.ID == id {
+			return &d, nil
+		}
+	}
+	return nil, nil
+}
+
+var (
+	pngFile = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 100)...)
+	pdfFile = []byte("%PDF-1.7\n" + strings.Repeat("x", 100))
+)
+
+// newKYC returns a KYCUsecase storing files under the directory it returns.
+func newKYC(t *testing.T, maxSize int64) (KYCUsecase, *fakeKYCRepo, string) {
+	t.Helper()
+	dir := t.TempDir()
+	blobs, err := blob.NewFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := newFakeKYCRepo()
+	return NewKYCUsecase(repo, newFakeUsers(), blobs, nil, KYCConfig{MaxDocumentSize: maxSize}), repo, dir
+}
+
+func TestUploadDocument(t *testing.T) {
+	cases := []struct {
+		name            string
+		docType         domain.DocumentType
+		file            io.Reader
+		want            error
+		wantContentType string
+	}{
+		{"png", domain.DocumentPassport, bytes.NewReader(pngFile), nil, "image/png"},
+		{"pdf", domain.DocumentProofOfAddress, bytes.NewReader(pdfFile), nil, "application/pdf"},
+		// Uploads arrive in chunks smaller than the sniffed header
+		{"png in small chunks", domain.DocumentNationalID, iotest.OneByteReader(bytes.NewReader(pngFile)), nil, "image/png"},
+		{"text", domain.DocumentPassport, strings.NewReader("not a scan"), domain.ErrUnsupportedDocument, ""},
+		{"empty", domain.DocumentPassport, strings.NewReader(""), domain.ErrUnsupportedDocument, ""},
+		{"unknown type", domain.DocumentType("selfie"), bytes.NewReader(pngFile), domain.ErrInvalidDocumentType, ""},
+		{"too large", domain.DocumentPassport, io.MultiReader(bytes.NewReader(pngFile), bytes.NewReader(make([]byte, 1024))),
+			domain.ErrDocumentTooLarge, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			kyc, repo, _ := newKYC(t, 1024)
+			doc, err := kyc.Upload(42, c.docType, `C:\scans\doc.bin`, c.file)
+			if !errors.Is(err, c.want) {
+				t.Fatalf("Upload = %v, want %v", err, c.want)
+			}
+			if err != nil {
+				if len(repo.documents) != 0 {
+					t.Errorf("a refused upload was stored: %+v", repo.documents)
+				}
+				return
+			}
+			if doc.ContentType != c.wantContentType || doc.Filename != "doc.bin" || doc.Type != c.docType {
+				t.Errorf("document = %+v, want %s named doc.bin", doc, c.wantContentType)
+			}
+
+			stored, f, err := kyc.OpenDocument(doc.ID)
+			if err != nil {
+				t.Fatalf("OpenDocument = %v", err)
+			}
+			defer f.Close()
+			b, _ := io.ReadAll(f)
+			if int64(len(b)) != doc.Size || stored.BlobKey != doc.BlobKey {
+				t.Errorf("stored %d bytes under %s, want %d under %s", len(b), stored.BlobKey, doc.Size, doc.BlobKey)
+			}
+		})
+	}
+}
+
+func TestOversizedUploadLeavesNoFile(t *testing.T) {
+	kyc, _, dir := newKYC(t, 64)
+	_, err := kyc.Upload(42, domain.DocumentPassport, "scan.png", bytes.NewReader(pngFile))
+	if !errors.Is(err, domain.ErrDocumentTooLarge) {
+		t.Fatalf("Upload = %v, want ErrDocumentTooLarge", err)
+	}
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			t.Errorf("the oversized file was kept at %s", path)
+		}
+		return err
+	})
+}
+
+func TestUploadsCloseOnSubmission(t *testing.T) {
+	kyc, _, _ := newKYC(t, 1024)
+	if _, err := kyc.Submit(42); !errors.Is(err, domain.ErrKYCNoDocuments) {
+		t.Errorf("Submit without documents = %v, want ErrKYCNoDocuments", err)
+	}
+	if _, err := kyc.Upload(42, domain.DocumentPassport, "scan.png", bytes.NewReader(pngFile)); err != nil {
+		t.Fatal(err)
+	}
+	k, err := kyc.Submit(42)
+	if err != nil {
+		t.Fatalf("Submit = %v", err)
+	}
+	if k.Status != domain.KYCPending {
+		t.Errorf("status = %s, want pending", k.Status)
+	}
+	if _, err := kyc.Upload(42, domain.DocumentPassport, "other.png", bytes.NewReader(pngFile)); !errors.Is(err, domain.ErrKYCNotAllowed) {
+		t.Errorf("Upload while pending = %v, want ErrKYCNotAllowed", err)
+	}
+}
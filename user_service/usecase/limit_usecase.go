@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"log"
+	"time"
+
+	"muchway/pkg/limits"
+	"muchway/user_service/domain"
+	"muchway/user_service/repository"
+)
+
+type LimitConfig struct {
+	// CoolingOff is how long a raised or removed limit waits before it
+	// takes effect.
+	CoolingOff time.Duration
+}
+
+// LimitUsecase manages the responsible gambling limits users set on
+// themselves. payment_service and bet_service enforce the money limits;
+// tokenUsecase enforces the session limit.
+type LimitUsecase interface {
+	// GetLimits returns the limits in force, with changes whose cooling-off
+	// period is over applied.
+	GetLimits(userID int64) ([]limits.Limit, error)
+	// SetLimit sets a limit. A new or lower limit applies at once, a higher
+	// one after the cooling-off period.
+	SetLimit(userID int64, l limits.Limit) (limits.Limit, error)
+	// RemoveLimit schedules a limit's removal after the cooling-off period.
+	RemoveLimit(userID int64, kind limits.Kind, period limits.Period) (limits.Limit, error)
+	// SessionLimit returns how long the user's sessions may last, zero if
+	// they have no session limit.
+	SessionLimit(userID int64) (time.Duration, error)
+}
+
+type limitUsecase struct {
+	repo repository.LimitRepository
+	cfg  LimitConfig
+}
+
+func NewLimitUsecase(repo repository.LimitRepository, cfg LimitConfig) LimitUsecase {
+	return &limitUsecase{repo: repo, cfg: cfg}
+}
+
+func (u *limitUsecase) GetLimits(userID int64) ([]limits.Limit, error) {
+	stored, err := u.repo.GetLimits(userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var ls []limits.Limit
+	for _, l := range stored {
+		current, ok := l.Current(now)
+		// Store changes that have come into force so they are only applied once
+		switch {
+		case !ok:
+			if err := u.repo.DeleteLimit(userID, l.Kind, l.Period); err != nil {
+				log.Println("Failed to delete expired limit:", err)
+			}
+			continue
+		case l.Pending != nil && current.Pending == nil:
+			if err := u.repo.SaveLimit(userID, current); err != nil {
+				log.Println("Failed to apply pending limit change:", err)
+			}
+		}
+		ls = append(ls, current)
+	}
+	return ls, nil
+}
+
+func (u *limitUsecase) find(userID int64, kind limits.Kind, period limits.Period) (*limits.Limit, error) {
+	ls, err := u.GetLimits(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range ls {
+		if l.Kind == kind && l.Period == period {
+			return &l, nil
+		}
+	}
+	return nil, nil
+}
+
+func (u *limitUsecase) SetLimit(userID int64, l limits.Limit) (limits.Limit, error) {
+	current, err := u.find(userID, l.Kind, l.Period)
+	if err != nil {
+		return limits.Limit{}, err
+	}
+	next, err := limits.Request(current, l, time.Now(), u.cfg.CoolingOff)
+	if err != nil {
+		return limits.Limit{}, err
+	}
+	if err := u.repo.SaveLimit(userID, next); err != nil {
+		return limits.Limit{}, err
+	}
+	return next, nil
+}
+
+func (u *limitUsecase) RemoveLimit(userID int64, kind limits.Kind, period limits.Period) (limits.Limit, error) {
+	current, err := u.find(userID, kind, period)
+	if err != nil {
+		return limits.Limit{}, err
+	}
+	if current == nil {
+		return limits.Limit{}, domain.ErrLimitNotFound
+	}
+	next := limits.Remove(*current, time.Now(), u.cfg.CoolingOff)
+	if err := u.repo.SaveLimit(userID, next); err != nil {
+		return limits.Limit{}, err
+	}
+	return next, nil
+}
+
+func (u *limitUsecase) SessionLimit(userID int64) (time.Duration, error) {
+	ls, err := u.GetLimits(userID)
+	if err != nil {
+		return 0, err
+	}
+	if session := limits.Of(ls, limits.Session); len(session) > 0 {
+		return session[0].Duration, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"muchway/pkg/limits"
+	"muchway/pkg/money"
+	"muchway/user_service/domain"
+)
+
+type limitKey struct {
+	userID int64
+	kind   limits.Kind
+	period limits.Period
+}
+
+// fakeLimitRepo keeps limits in memory.
+type fakeLimitRepo struct {
+	mu     sync.Mutex
+	limits map[limitKey]limits.Limit
+}
+
+func newFakeLimitRepo() *fakeLimitRepo {
+	return &fakeLimitRepo{limits: map[limitKey]limits.Limit{}}
+}
+
+func (r *fakeLimitRepo) GetLimits(userID int64) ([]limits.Limit, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ls []limits.Limit
+	for k, l := range r.limits {
+		if k.userID == userID {
+			ls = append(ls, l)
+		}
+	}
+	return ls, nil
+}
+
+func (r *fakeLimitRepo) SaveLimit(userID int64, l limits.Limit) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.limits[limitKey{userID, l.Kind, l.Period}] = l
+	return nil
+}
+
+func (r *fakeLimitRepo) DeleteLimit(userID int64, kind limits.Kind, period limits.Period) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.limits, limitKey{userID, kind, period})
+	return nil
+}
+
+// elapse moves pending changes d closer to taking effect.
+func (r *fakeLimitRepo) elapse(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for k, l := range r.limits {
+		if l.Pending != nil {
+			c := *l.Pending
+			c.EffectiveAt = c.EffectiveAt.Add(-d)
+			l.Pending = &c
+			r.limits[k] = l
+		}
+	}
+}
+
+const testCoolingOff = 24 * time.Hour
+
+func dailyDeposit(amount string) limits.Limit {
+	return limits.Limit{Kind: limits.Deposit, Period: limits.Day, Amount: money.MustParse(amount)}
+}
+
+func TestSetLimit(t *testing.T) {
+	cases := []struct {
+		name        string
+		current     string
+		next        string
+		wantAmount  string
+		wantPending bool
+	}{
+		{"new limit applies at once", "", "100", "100", false},
+		{"lower limit applies at once", "100", "50", "50", false},
+		{"same limit applies at once", "100", "100", "100", false},
+		{"higher limit waits", "100", "200", "100", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			repo := newFakeLimitRepo()
+			u := NewLimitUsecase(repo, LimitConfig{CoolingOff: testCoolingOff})
+			if c.current != "" {
+				repo.SaveLimit(1, dailyDeposit(c.current))
+			}
+
+			got, err := u.SetLimit(1, dailyDeposit(c.next))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Amount != money.MustParse(c.wantAmount) || (got.Pending != nil) != c.wantPending {
+				t.Errorf("SetLimit = %s pending %v, want %s pending %v", got.Amount, got.Pending != nil, c.wantAmount, c.wantPending)
+			}
+			ls, _ := u.GetLimits(1)
+			if len(ls) != 1 || ls[0].Amount != money.MustParse(c.wantAmount) {
+				t.Errorf("GetLimits = %+v, want the %s limit in force", ls, c.wantAmount)
+			}
+		})
+	}
+}
+
+func TestRaisedLimitAppliesAfterCoolingOff(t *testing.T) {
+	repo := newFakeLimitRepo()
+	u := NewLimitUsecase(repo, LimitConfig{CoolingOff: testCoolingOff})
+	if _, err := u.SetLimit(1, dailyDeposit("100")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := u.SetLimit(1, dailyDeposit("200")); err != nil {
+		t.Fatal(err)
+	}
+
+	repo.elapse(testCoolingOff - time.Minute)
+	if ls, _ := u.GetLimits(1); ls[0].Amount != money.MustParse("100") {
+		t.Errorf("limit before the cooling-off is over = %s, want 100", ls[0].Amount)
+	}
+	repo.elapse(time.Minute)
+	if ls, _ := u.GetLimits(1); ls[0].Amount != money.MustParse("200") || ls[0].Pending != nil {
+		t.Errorf("limit after the cooling-off = %+v, want 200 with nothing pending", ls[0])
+	}
+	// The change is stored once it applies
+	if stored, _ := repo.GetLimits(1); stored[0].Pending != nil {
+		t.Errorf("stored limit still has a pending change: %+v", stored[0])
+	}
+}
+
+func TestRemoveLimit(t *testing.T) {
+	repo := newFakeLimitRepo()
+	u := NewLimitUsecase(repo, LimitConfig{CoolingOff: testCoolingOff})
+
+	if _, err := u.RemoveLimit(1, limits.Deposit, limits.Day); !errors.Is(err, domain.ErrLimitNotFound) {
+		t.Errorf("removing a limit that is not set = %v, want ErrLimitNotFound", err)
+	}
+
+	if _, err := u.SetLimit(1, dailyDeposit("100")); err != nil {
+		t.Fatal(err)
+	}
+	removed, err := u.RemoveLimit(1, limits.Deposit, limits.Day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed.Pending == nil || !removed.Pending.Remove {
+		t.Fatalf("RemoveLimit = %+v, want a pending removal", removed)
+	}
+	if ls, _ := u.GetLimits(1); len(ls) != 1 {
+		t.Errorf("GetLimits during the cooling-off = %+v, want the limit still in force", ls)
+	}
+
+	repo.elapse(testCoolingOff)
+	if ls, _ := u.GetLimits(1); len(ls) != 0 {
+		t.Errorf("GetLimits after the cooling-off = %+v, want none", ls)
+	}
+	if stored, _ := repo.GetLimits(1); len(stored) != 0 {
+		t.Errorf("removed limit is still stored: %+v", stored)
+	}
+}
+
+func TestSessionLimit(t *testing.T) {
+	repo := newFakeLimitRepo()
+	u := NewLimitUsecase(repo, LimitConfig{CoolingOff: testCoolingOff})
+
+	if d, err := u.SessionLimit(1); err != nil || d != 0 {
+		t.Errorf("SessionLimit without a limit = %s, %v, want 0", d, err)
+	}
+	if _, err := u.SetLimit(1, dailyDeposit("100")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := u.SetLimit(1, limits.Limit{Kind: limits.Session, Duration: 2 * time.Hour}); err != nil {
+		t.Fatal(err)
+	}
+	if d, err := u.SessionLimit(1); err != nil || d != 2*time.Hour {
+		t.Errorf("SessionLimit = %s, %v, want 2h", d, err)
+	}
+	if _, err := u.SetLimit(1, limits.Limit{Kind: limits.Session, Period: limits.Day, Duration: time.Hour}); !errors.Is(err, limits.ErrInvalidLimit) {
+		t.Errorf("session limit with a period = %v, want ErrInvalidLimit", err)
+	}
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"muchway/user_service/domain"
+)
+
+// LockoutConfig tunes the brute-force protection of Login.
+type LockoutConfig struct {
+	// FreeAttempts failures within Window are allowed without delay. Each
+	// further failure doubles the wait before the next attempt, starting at
+	// BaseDelay and capped at MaxDelay.
+	FreeAttempts int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+	Window       time.Duration
+	// AccountLockAt failures lock the account for LockDuration and send its
+	// owner an unlock link.
+	AccountLockAt int
+	LockDuration  time.Duration
+	// IPLockAt failures from one address, across any accounts, block that
+	// address for LockDuration.
+	IPLockAt int
+}
+
+// DefaultLockoutConfig is the protection used unless configured otherwise.
+var DefaultLockoutConfig = LockoutConfig{
+	FreeAttempts:  3,
+	BaseDelay:     time.Second,
+	MaxDelay:      time.Minute,
+	Window:        15 * time.Minute,
+	AccountLockAt: 10,
+	LockDuration:  30 * time.Minute,
+	IPLockAt:      50,
+}
+
+// DefaultPasswordResetLimit throttles password reset requests, so that the
+// open RequestPasswordReset cannot be used to flood an inbox.
+var DefaultPasswordResetLimit = LockoutConfig{
+	FreeAttempts:  3,
+	BaseDelay:     time.Minute,
+	MaxDelay:      30 * time.Minute,
+	Window:        time.Hour,
+	AccountLockAt: 10,
+	LockDuration:  time.Hour,
+	IPLockAt:      30,
+}
+
+// loginGuard counts failed logins in Redis per account and per client
+// address. Accounts are keyed by the email typed in, so unknown emails are
+// throttled exactly like real ones. The same counters, under another scope,
+// throttle password reset requests.
+type loginGuard struct {
+	redis *redis.Client
+	cfg   LockoutConfig
+	// scope prefixes the Redis keys, keeping each guard's counts apart
+	scope string
+}
+
+func accountKey(email string) string { return "acct:" + strings.ToLower(strings.TrimSpace(email)) }
+func ipKey(ip string) string         { return "ip:" + ip }
+
+func (g *loginGuard) failuresKey(key string) string { return g.scope + "_fail:" + key }
+func (g *loginGuard) waitKey(key string) string     { return g.scope + "_wait:" + key }
+func (g *loginGuard) lockKey(key string) string     { return g.scope + "_lock:" + key }
+
+// check refuses an attempt while the account or address must wait or is
+// locked.
+func (g *loginGuard) check(ctx context.Context, email, ip string) error {
+	keys := []string{g.lockKey(accountKey(email)), g.waitKey(accountKey(email))}
+	if ip != "" {
+		keys = append(keys, g.lockKey(ipKey(ip)), g.waitKey(ipKey(ip)))
+	}
+	var longest time.Duration
+	for _, k := range keys {
+		ttl, err := g.redis.PTTL(ctx, k).Result()
+		if err != nil {
+			return err
+		}
+		if ttl > longest {
+			longest = ttl
+		}
+	}
+	if longest > 0 {
+		return &domain.ThrottledError{RetryAfter: longest}
+	}
+	return nil
+}
+
+// fail records a failed attempt and reports whether it locked the account.
+func (g *loginGuard) fail(ctx context.Context, email, ip string) (bool, error) {
+	n, err := g.count(ctx, accountKey(email))
+	if err != nil {
+		return false, err
+	}
+	locked := false
+	if n >= int64(g.cfg.AccountLockAt) {
+		locked, err = g.redis.SetNX(ctx, g.lockKey(accountKey(email)), 1, g.cfg.LockDuration).Result()
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if ip != "" {
+		n, err := g.count(ctx, ipKey(ip))
+		if err != nil {
+			return locked, err
+		}
+		if n >= int64(g.cfg.IPLockAt) {
+			if err := g.redis.SetNX(ctx, g.lockKey(ipKey(ip)), 1, g.cfg.LockDuration).Err(); err != nil {
+				return locked, err
+			}
+		}
+	}
+	return locked, nil
+}
+
+// count adds a failure for key and sets the wait before its next attempt.
+func (g *loginGuard) count(ctx context.Context, key string) (int64, error) {
+	n, err := g.redis.Incr(ctx, g.failuresKey(key)).Result()
+	if err != nil {
+		return 0, err
+	}
+	// The window starts at the first failure
+	if n == 1 {
+		if err := g.redis.Expire(ctx, g.failuresKey(key), g.cfg.Window).Err(); err != nil {
+			return n, err
+		}
+	}
+	if delay := g.delay(n); delay > 0 {
+		if err := g.redis.Set(ctx, g.waitKey(key), 1, delay).Err(); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func (g *loginGuard) delay(failures int64) time.Duration {
+	over := failures - int64(g.cfg.FreeAttempts)
+	if over <= 0 {
+		return 0
+	}
+	d := g.cfg.BaseDelay
+	for i := int64(1); i < over && d < g.cfg.MaxDelay; i++ {
+		d *= 2
+	}
+	if d > g.cfg.MaxDelay {
+		d = g.cfg.MaxDelay
+	}
+	return d
+}
+
+// reset clears the account's failures after a successful login or unlock.
+// Failures from the address are kept, since it may be trying many accounts.
+func (g *loginGuard) reset(ctx context.Context, email string) error {
+	key := accountKey(email)
+	return g.redis.Del(ctx, g.failuresKey(key), g.waitKey(key), g.lockKey(key)).Err()
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"muchway/user_service/domain"
+)
+
+var testLockout = LockoutConfig{
+	FreeAttempts:  2,
+	BaseDelay:     time.Second,
+	MaxDelay:      8 * time.Second,
+	Window:        15 * time.Minute,
+	AccountLockAt: 5,
+	LockDuration:  30 * time.Minute,
+	IPLockAt:      8,
+}
+
+func newLoginGuard(t *testing.T) (*loginGuard, func(time.Duration)) {
+	t.Helper()
+	mr, rdb := newTestRedis(t)
+	return &loginGuard{redis: rdb, cfg: testLockout, scope: "login"}, mr.FastForward
+}
+
+// retryAfter returns how long check makes the attempt wait, zero if it is
+// allowed.
+func retryAfter(t *testing.T, g *loginGuard, email, ip string) time.Duration {
+	t.Helper()
+	err := g.check(context.Background(), email, ip)
+	var throttled *domain.ThrottledError
+	if errors.As(err, &throttled) {
+		return throttled.RetryAfter
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return 0
+}
+
+func TestLoginDelay(t *testing.T) {
+	g := &loginGuard{cfg: testLockout}
+	cases := []struct {
+		failures int64
+		want     time.Duration
+	}{
+		{1, 0},
+		{2, 0},
+		{3, time.Second},
+		{4, 2 * time.Second},
+		{5, 4 * time.Second},
+		{6, 8 * time.Second},
+		{7, 8 * time.Second},
+		{100, 8 * time.Second},
+	}
+	for _, c := range cases {
+		if got := g.delay(c.failures); got != c.want {
+			t.Errorf("delay(%d) = %s, want %s", c.failures, got, c.want)
+		}
+	}
+}
+
+func TestAccountLock(t *testing.T) {
+	ctx := context.Background()
+	g, fastForward := newLoginGuard(t)
+
+	for i := 1; i <= testLockout.AccountLockAt; i++ {
+		locked, err := g.fail(ctx, "Bob@example.com", fmt.Sprint("10.0.0.", i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := i == testLockout.AccountLockAt; locked != want {
+			t.Errorf("failure %d: locked = %v, want %v", i, locked, want)
+		}
+	}
+	// Only the failure that locks the account reports it, so one email is sent
+	if locked, _ := g.fail(ctx, "bob@example.com", "10.0.0.9"); locked {
+		t.Error("a failure on a locked account locked it again")
+	}
+
+	// The lock outlasts the progressive wait and holds whatever the address
+	fastForward(testLockout.MaxDelay)
+	if wait := retryAfter(t, g, "bob@example.com", "192.0.2.1"); wait <= testLockout.MaxDelay {
+		t.Errorf("locked account waits %s, want up to %s", wait, testLockout.LockDuration)
+	}
+	if wait := retryAfter(t, g, "ann@example.com", "192.0.2.1"); wait != 0 {
+		t.Errorf("another account waits %s", wait)
+	}
+
+	fastForward(testLockout.LockDuration)
+	if wait := retryAfter(t, g, "bob@example.com", "192.0.2.1"); wait != 0 {
+		t.Errorf("account still waits %s after the lock", wait)
+	}
+}
+
+func TestIPLock(t *testing.T) {
+	ctx := context.Background()
+	g, fastForward := newLoginGuard(t)
+
+	for i := 1; i <= testLockout.IPLockAt; i++ {
+		if _, err := g.fail(ctx, fmt.Sprint("user", i, "@example.com"), "10.0.0.1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fastForward(testLockout.MaxDelay)
+	if wait := retryAfter(t, g, "fresh@example.com", "10.0.0.1"); wait <= testLockout.MaxDelay {
+		t.Errorf("locked address waits %s, want up to %s", wait, testLockout.LockDuration)
+	}
+	if wait := retryAfter(t, g, "fresh@example.com", "10.0.0.2"); wait != 0 {
+		t.Errorf("another address waits %s", wait)
+	}
+	// Unlocking an account does not lift the address's lock
+	if err := g.reset(ctx, "user1@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if wait := retryAfter(t, g, "user1@example.com", "10.0.0.1"); wait == 0 {
+		t.Error("resetting an account lifted the lock on the address")
+	}
+	if wait := retryAfter(t, g, "user1@example.com", "10.0.0.2"); wait != 0 {
+		t.Errorf("reset account waits %s from another address", wait)
+	}
+}
+
+func TestUnlockAccount(t *testing.T) {
+	_, rdb := newTestRedis(t)
+	users := newFakeUsers(bob)
+	users.withPassword(t, bob.ID, "old password", bcrypt.MinCost)
+	mail := &fakeMail{}
+	lockout := testLockout
+	lockout.FreeAttempts = lockout.AccountLockAt
+	u := NewUserUsecase(users, nil, rdb, mail, UserConfig{EmailTokenSecret: []byte("test-secret"), Lockout: lockout})
+
+	for i := 0; i < lockout.AccountLockAt; i++ {
+		if _, err := u.Login(bob.Email, "wrong password", "10.0.0.1"); !errors.Is(err, domain.ErrInvalidCredentials) {
+			t.Fatalf("attempt %d = %v, want ErrInvalidCredentials", i+1, err)
+		}
+	}
+	var throttled *domain.ThrottledError
+	if _, err := u.Login(bob.Email, "old password", "10.0.0.2"); !errors.As(err, &throttled) {
+		t.Fatalf("Login with the right password on a locked account = %v, want ThrottledError", err)
+	}
+	if len(mail.unlockLinks) != 1 {
+		t.Fatalf("sent %d unlock emails, want 1", len(mail.unlockLinks))
+	}
+
+	link, err := url.Parse(mail.unlockLinks[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := link.Query().Get("token")
+	if err := u.UnlockAccount(token); err != nil {
+		t.Fatalf("UnlockAccount = %v", err)
+	}
+	if _, err := u.Login(bob.Email, "old password", "10.0.0.2"); err != nil {
+		t.Errorf("Login after unlocking = %v", err)
+	}
+	if err := u.UnlockAccount(token); !errors.Is(err, domain.ErrInvalidEmailToken) {
+		t.Errorf("reusing the unlock link = %v, want ErrInvalidEmailToken", err)
+	}
+}
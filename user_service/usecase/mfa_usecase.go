@@ -0,0 +1,321 @@
+package usecase
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"muchway/pkg/totp"
+	"muchway/user_service/domain"
+	"muchway/user_service/repository"
+)
+
+const (
+	recoveryCodeCount  = 10
+	maxMFAAttempts     = 5
+	mfaChallengePrefix = "mfa_challenge:"
+	mfaFailuresPrefix  = "mfa_fail:"
+	mfaLockPrefix      = "mfa_lock:"
+)
+
+type MFAConfig struct {
+	// Issuer names the account in authenticator apps.
+	Issuer string
+	// EncryptionKey encrypts TOTP secrets at rest. Any length; it is hashed
+	// to an AES-256 key.
+	EncryptionKey []byte
+	// ChallengeTTL is how long a user has to enter their code after the
+	// password step of a login.
+	ChallengeTTL time.Duration
+	// MaxFailures invalid codes within FailureWindow, whether at login, step-up
+	// or when disabling 2FA, lock the user's second factor for LockDuration.
+	// New login challenges do not reset the count.
+	MaxFailures   int
+	FailureWindow time.Duration
+	LockDuration  time.Duration
+}
+
+type MFAUsecase interface {
+	Enabled(userID int64) (bool, error)
+	// Enroll starts setting up TOTP for user. It is not enforced until
+	// Confirm is called with a code from the app.
+	Enroll(user *domain.User) (*domain.TOTPEnrollment, error)
+	Confirm(userID int64, code string) error
+	// Disable turns 2FA off after checking a current code.
+	Disable(userID int64, code string) error
+	// Verify checks a TOTP code or an unused recovery code. Too many invalid
+	// codes lock it with a domain.ThrottledError.
+	Verify(userID int64, code string) error
+	// StartChallenge is called after the password step of a login by a user
+	// with 2FA, and returns the challenge to answer with CompleteChallenge.
+	StartChallenge(userID int64) (string, error)
+	CompleteChallenge(challenge, code string) (int64, error)
+}
+
+type mfaUsecase struct {
+	repo  repository.MFARepository
+	redis *redis.Client
+	cfg   MFAConfig
+	aead  cipher.AEAD
+}
+
+func NewMFAUsecase(repo repository.MFARepository, redis *redis.Client, cfg MFAConfig) (MFAUsecase, error) {
+	key := sha256.Sum256(cfg.EncryptionKey)
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &mfaUsecase{repo: repo, redis: redis, cfg: cfg, aead: aead}, nil
+}
+
+func (m *mfaUsecase) Enabled(userID int64) (bool, error) {
+	t, err := m.repo.GetTOTP(userID)
+	if err != nil {
+		return false, err
+	}
+	return t.Enabled(), nil
+}
+
+func (m *mfaUsecase) Enroll(user *domain.User) (*domain.TOTPEnrollment, error) {
+	current, err := m.repo.GetTOTP(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current.Enabled() {
+		return nil, domain.ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := m.seal(secret)
+	if err != nil {
+		return nil, err
+	}
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		if codes[i], err = recoveryCode(); err != nil {
+			return nil, err
+		}
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+	if err := m.repo.SaveTOTP(&domain.TOTP{UserID: user.ID, Secret: sealed}, hashes); err != nil {
+		return nil, err
+	}
+
+	return &domain.TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.URI(m.cfg.Issuer, user.Email, secret),
+		RecoveryCodes:   codes,
+	}, nil
+}
+
+func (m *mfaUsecase) Confirm(userID int64, code string) error {
+	t, err := m.repo.GetTOTP(userID)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return domain.ErrTOTPNotEnrolled
+	}
+	if t.Enabled() {
+		return domain.ErrTOTPAlreadyEnabled
+	}
+	if err := m.checkTOTP(t, code); err != nil {
+		return err
+	}
+	return m.repo.EnableTOTP(userID)
+}
+
+func (m *mfaUsecase) Disable(userID int64, code string) error {
+	if err := m.Verify(userID, code); err != nil {
+		return err
+	}
+	return m.repo.DeleteTOTP(userID)
+}
+
+func (m *mfaUsecase) Verify(userID int64, code string) error {
+	t, err := m.repo.GetTOTP(userID)
+	if err != nil {
+		return err
+	}
+	if !t.Enabled() {
+		return domain.ErrTOTPNotEnrolled
+	}
+	ctx := context.Background()
+	if err := m.attempt(ctx, userID); err != nil {
+		return err
+	}
+	if err := m.verify(t, code); err != nil {
+		return err
+	}
+	return m.redis.Del(ctx, mfaFailuresPrefix+strconv.FormatInt(userID, 10)).Err()
+}
+
+func (m *mfaUsecase) verify(t *domain.TOTP, code string) error {
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		return m.checkTOTP(t, code)
+	}
+	used, err := m.repo.UseRecoveryCode(t.UserID, hashRecoveryCode(code))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return domain.ErrInvalidMFACode
+	}
+	return nil
+}
+
+// attempt counts a code check against the user's allowance before it is
+// made, so that concurrent guesses cannot get past the limit. A correct code
+// clears the count.
+func (m *mfaUsecase) attempt(ctx context.Context, userID int64) error {
+	id := strconv.FormatInt(userID, 10)
+	ttl, err := m.redis.PTTL(ctx, mfaLockPrefix+id).Result()
+	if err != nil {
+		return err
+	}
+	if ttl > 0 {
+		return &domain.ThrottledError{RetryAfter: ttl}
+	}
+
+	n, err := m.redis.Incr(ctx, mfaFailuresPrefix+id).Result()
+	if err != nil {
+		return err
+	}
+	// The window starts at the first failure
+	if n == 1 {
+		if err := m.redis.Expire(ctx, mfaFailuresPrefix+id, m.cfg.FailureWindow).Err(); err != nil {
+			return err
+		}
+	}
+	if n <= int64(m.cfg.MaxFailures) {
+		return nil
+	}
+	pipe := m.redis.TxPipeline()
+	pipe.SetNX(ctx, mfaLockPrefix+id, 1, m.cfg.LockDuration)
+	pipe.Del(ctx, mfaFailuresPrefix+id)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+	return &domain.ThrottledError{RetryAfter: m.cfg.LockDuration}
+}
+
+func (m *mfaUsecase) checkTOTP(t *domain.TOTP, code string) error {
+	secret, err := m.open(t.Secret)
+	if err != nil {
+		return err
+	}
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok {
+		return domain.ErrInvalidMFACode
+	}
+	fresh, err := m.repo.AdvanceStep(t.UserID, step)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return domain.ErrInvalidMFACode
+	}
+	return nil
+}
+
+func (m *mfaUsecase) StartChallenge(userID int64) (string, error) {
+	challenge, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	ctx := context.Background()
+	key := mfaChallengePrefix + hashToken(challenge)
+	pipe := m.redis.TxPipeline()
+	pipe.HSet(ctx, key, "user_id", userID, "attempts", 0)
+	pipe.Expire(ctx, key, m.cfg.ChallengeTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", err
+	}
+	return challenge, nil
+}
+
+func (m *mfaUsecase) CompleteChallenge(challenge, code string) (int64, error) {
+	ctx := context.Background()
+	key := mfaChallengePrefix + hashToken(challenge)
+	userID, err := m.redis.HGet(ctx, key, "user_id").Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, domain.ErrInvalidMFAChallenge
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err := m.Verify(userID, code); err != nil {
+		if !errors.Is(err, domain.ErrInvalidMFACode) {
+			return 0, err
+		}
+		// Guessing codes costs the challenge after a few attempts
+		attempts, incrErr := m.redis.HIncrBy(ctx, key, "attempts", 1).Result()
+		if incrErr == nil && attempts >= maxMFAAttempts {
+			m.redis.Del(ctx, key)
+			return 0, domain.ErrTooManyMFAAttempts
+		}
+		return 0, err
+	}
+	// A challenge is answered once
+	if n, err := m.redis.Del(ctx, key).Result(); err != nil || n == 0 {
+		return 0, domain.ErrInvalidMFAChallenge
+	}
+	return userID, nil
+}
+
+func (m *mfaUsecase) seal(secret string) ([]byte, error) {
+	nonce := make([]byte, m.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return m.aead.Seal(nonce, nonce, []byte(secret), nil), nil
+}
+
+func (m *mfaUsecase) open(sealed []byte) (string, error) {
+	n := m.aead.NonceSize()
+	if len(sealed) < n {
+		return "", errors.New("malformed TOTP secret")
+	}
+	plain, err := m.aead.Open(nil, sealed[:n], sealed[n:], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+// recoveryCode returns a code such as "K7QF-2MXD".
+func recoveryCode() (string, error) {
+	b := make([]byte, 5)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	s := base32.StdEncoding.EncodeToString(b)
+	return s[:4] + "-" + s[4:], nil
+}
+
+// hashRecoveryCode ignores case and the dash, which users often get wrong.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
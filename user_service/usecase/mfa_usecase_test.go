@@ -0,0 +1,306 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"muchway/pkg/totp"
+	"muchway/user_service/domain"
+)
+
+type fakeMFARepo struct {
+	mu        sync.Mutex
+	totps     map[int64]*domain.TOTP
+	recovery  map[int64]map[string]bool
+	lastSteps map[int64]int64
+}
+
+func newFakeMFARepo() *fakeMFARepo {
+	return &fakeMFARepo{totps: map[int64]*domain.TOTP{}, recovery: map[int64]map[string]bool{}, lastSteps: map[int64]int64{}}
+}
+
+func (r *fakeMFARepo) GetTOTP(userID int64) (*domain.TOTP, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t, ok := r.totps[userID]
+	if !ok {
+		return nil, nil
+	}
+	c := *t
+	return &c, nil
+}
+
+func (r *fakeMFARepo) SaveTOTP(t *domain.TOTP, hashes []string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.totps[t.UserID] = t
+	r.recovery[t.UserID] = map[string]bool{}
+	for _, h := range hashes {
+		r.recovery[t.UserID][h] = true
+	}
+	return nil
+}
+
+func (r *fakeMFARepo) EnableTOTP(userID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	r.totps[userID].EnabledAt = &now
+	return nil
+}
+
+func (r *fakeMFARepo) DeleteTOTP(userID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.totps, userID)
+	delete(r.recovery, userID)
+	return nil
+}
+
+func (r *fakeMFARepo) AdvanceStep(userID, step int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if step <= r.lastSteps[userID] {
+		return false, nil
+	}
+	r.lastSteps[userID] = step
+	return true, nil
+}
+
+func (r *fakeMFARepo) UseRecoveryCode(userID int64, hash string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.recovery[userID][hash] {
+		return false, nil
+	}
+	delete(r.recovery[userID], hash)
+	return true, nil
+}
+
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return mr, client
+}
+
+var testMFAConfig = MFAConfig{
+	Issuer:        "MuchWayBet",
+	EncryptionKey: []byte("test-key"),
+	ChallengeTTL:  5 * time.Minute,
+	MaxFailures:   3,
+	FailureWindow: 15 * time.Minute,
+	LockDuration:  30 * time.Minute,
+}
+
+// enrolled returns an MFA usecase with 2FA enabled for user 1, and the
+// enrolment.
+func enrolled(t *testing.T) (*miniredis.Miniredis, MFAUsecase, *domain.TOTPEnrollment) {
+	t.Helper()
+	mr, rdb := newTestRedis(t)
+	m, err := NewMFAUsecase(newFakeMFARepo(), rdb, testMFAConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enrollment, err := m.Enroll(&domain.User{ID: 1, Email: "ann@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Confirm(1, codeAt(t, enrollment.Secret, 0)); err != nil {
+		t.Fatalf("Confirm: %v", err)
+	}
+	return mr, m, enrollment
+}
+
+// codeAt returns the code offset steps from now, which is still accepted
+// within totp.Skew and has not been used yet.
+func codeAt(t *testing.T, secret string, offset int64) string {
+	t.Helper()
+	code, err := totp.Code(secret, totp.Step(time.Now())+offset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
+
+func TestVerify(t *testing.T) {
+	_, m, enrollment := enrolled(t)
+
+	cases := []struct {
+		name string
+		code string
+		want error
+	}{
+		{"reused code", codeAt(t, enrollment.Secret, 0), domain.ErrInvalidMFACode},
+		{"wrong code", "000000", domain.ErrInvalidMFACode},
+		{"next code", codeAt(t, enrollment.Secret, 1), nil},
+		{"recovery code", enrollment.RecoveryCodes[0], nil},
+		{"recovery code in lower case without the dash", strings.ToLower(strings.ReplaceAll(enrollment.RecoveryCodes[1], "-", "")), nil},
+		{"used recovery code", enrollment.RecoveryCodes[0], domain.ErrInvalidMFACode},
+	}
+	for _, c := range cases {
+		if err := m.Verify(1, c.code); !errors.Is(err, c.want) {
+			t.Errorf("%s: Verify = %v, want %v", c.name, err, c.want)
+		}
+	}
+	if err := m.Verify(2, "000000"); !errors.Is(err, domain.ErrTOTPNotEnrolled) {
+		t.Errorf("Verify for a user without 2FA = %v, want ErrTOTPNotEnrolled", err)
+	}
+}
+
+func TestVerifyLocksAfterTooManyFailures(t *testing.T) {
+	mr, m, enrollment := enrolled(t)
+
+	for i := 0; i < testMFAConfig.MaxFailures; i++ {
+		if err := m.Verify(1, "000000"); !errors.Is(err, domain.ErrInvalidMFACode) {
+			t.Fatalf("failure %d: Verify = %v, want ErrInvalidMFACode", i+1, err)
+		}
+	}
+	var throttled *domain.ThrottledError
+	if err := m.Verify(1, "000000"); !errors.As(err, &throttled) {
+		t.Fatalf("Verify after %d failures = %v, want a ThrottledError", testMFAConfig.MaxFailures, err)
+	}
+	if err := m.Verify(1, codeAt(t, enrollment.Secret, 1)); !errors.As(err, &throttled) {
+		t.Errorf("Verify of a correct code while locked = %v, want a ThrottledError", err)
+	}
+
+	mr.FastForward(testMFAConfig.LockDuration)
+	if err := m.Verify(1, codeAt(t, enrollment.Secret, 1)); err != nil {
+		t.Errorf("Verify after the lock expired = %v, want nil", err)
+	}
+}
+
+func TestVerifyCorrectCodeClearsFailures(t *testing.T) {
+	_, m, enrollment := enrolled(t)
+
+	for i := 0; i < testMFAConfig.MaxFailures-1; i++ {
+		m.Verify(1, "000000")
+	}
+	if err := m.Verify(1, enrollment.RecoveryCodes[0]); err != nil {
+		t.Fatalf("Verify of a recovery code = %v", err)
+	}
+	for i := 0; i < testMFAConfig.MaxFailures; i++ {
+		if err := m.Verify(1, "000000"); !errors.Is(err, domain.ErrInvalidMFACode) {
+			t.Fatalf("failure %d after a success: Verify = %v, want ErrInvalidMFACode rather than a lock", i+1, err)
+		}
+	}
+}
+
+func TestFailuresAreSharedAcrossChallenges(t *testing.T) {
+	_, m, enrollment := enrolled(t)
+
+	// A new login gives a new challenge, but not new guesses
+	for i := 0; i < testMFAConfig.MaxFailures; i++ {
+		challenge, err := m.StartChallenge(1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := m.CompleteChallenge(challenge, "000000"); !errors.Is(err, domain.ErrInvalidMFACode) {
+			t.Fatalf("challenge %d: CompleteChallenge = %v, want ErrInvalidMFACode", i+1, err)
+		}
+	}
+	challenge, _ := m.StartChallenge(1)
+	var throttled *domain.ThrottledError
+	if _, err := m.CompleteChallenge(challenge, codeAt(t, enrollment.Secret, 1)); !errors.As(err, &throttled) {
+		t.Errorf("CompleteChallenge after failed logins = %v, want a ThrottledError", err)
+	}
+	// Step-up and disabling share the same count
+	if err := m.Disable(1, codeAt(t, enrollment.Secret, 1)); !errors.As(err, &throttled) {
+		t.Errorf("Disable while locked = %v, want a ThrottledError", err)
+	}
+}
+
+func TestConcurrentGuessesStayWithinTheLimit(t *testing.T) {
+	_, m, _ := enrolled(t)
+
+	var mu sync.Mutex
+	invalid := 0
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if errors.Is(m.Verify(1, "000000"), domain.ErrInvalidMFACode) {
+				mu.Lock()
+				invalid++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if invalid > testMFAConfig.MaxFailures {
+		t.Errorf("%d codes were checked, want at most %d", invalid, testMFAConfig.MaxFailures)
+	}
+}
+
+func TestCompleteChallenge(t *testing.T) {
+	_, m, enrollment := enrolled(t)
+
+	challenge, err := m.StartChallenge(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	userID, err := m.CompleteChallenge(challenge, codeAt(t, enrollment.Secret, 1))
+	if err != nil || userID != 1 {
+		t.Fatalf("CompleteChallenge = %d, %v, want 1", userID, err)
+	}
+	if _, err := m.CompleteChallenge(challenge, enrollment.RecoveryCodes[0]); !errors.Is(err, domain.ErrInvalidMFAChallenge) {
+		t.Errorf("reusing a challenge = %v, want ErrInvalidMFAChallenge", err)
+	}
+	if _, err := m.CompleteChallenge("made-up", "000000"); !errors.Is(err, domain.ErrInvalidMFAChallenge) {
+		t.Errorf("unknown challenge = %v, want ErrInvalidMFAChallenge", err)
+	}
+}
+
+func TestEnrollment(t *testing.T) {
+	_, rdb := newTestRedis(t)
+	m, err := NewMFAUsecase(newFakeMFARepo(), rdb, testMFAConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &domain.User{ID: 1, Email: "ann@example.com"}
+	var first, second *domain.TOTPEnrollment
+
+	// Each step runs on the state the previous ones left
+	steps := []struct {
+		name        string
+		do          func() error
+		want        error
+		wantEnabled bool
+	}{
+		{"confirm before enrolling", func() error { return m.Confirm(1, "000000") }, domain.ErrTOTPNotEnrolled, false},
+		{"enroll", func() (err error) { first, err = m.Enroll(user); return err }, nil, false},
+		{"verify before confirming", func() error { return m.Verify(1, codeAt(t, first.Secret, 0)) }, domain.ErrTOTPNotEnrolled, false},
+		{"confirm with a wrong code", func() error { return m.Confirm(1, "000000") }, domain.ErrInvalidMFACode, false},
+		{"enroll again before confirming", func() (err error) { second, err = m.Enroll(user); return err }, nil, false},
+		{"confirm with the replaced secret", func() error { return m.Confirm(1, codeAt(t, first.Secret, 0)) }, domain.ErrInvalidMFACode, false},
+		{"confirm", func() error { return m.Confirm(1, codeAt(t, second.Secret, 0)) }, nil, true},
+		{"confirm again", func() error { return m.Confirm(1, codeAt(t, second.Secret, 1)) }, domain.ErrTOTPAlreadyEnabled, true},
+		{"enroll while enabled", func() error { _, err := m.Enroll(user); return err }, domain.ErrTOTPAlreadyEnabled, true},
+		{"disable with a wrong code", func() error { return m.Disable(1, "000000") }, domain.ErrInvalidMFACode, true},
+		{"disable with a recovery code", func() error { return m.Disable(1, second.RecoveryCodes[0]) }, nil, false},
+		{"verify once disabled", func() error { return m.Verify(1, codeAt(t, second.Secret, 1)) }, domain.ErrTOTPNotEnrolled, false},
+	}
+	for _, s := range steps {
+		if err := s.do(); !errors.Is(err, s.want) {
+			t.Fatalf("%s: got %v, want %v", s.name, err, s.want)
+		}
+		if enabled, _ := m.Enabled(1); enabled != s.wantEnabled {
+			t.Fatalf("%s: enabled = %v, want %v", s.name, enabled, s.wantEnabled)
+		}
+	}
+
+	if len(second.RecoveryCodes) != recoveryCodeCount {
+		t.Errorf("got %d recovery codes, want %d", len(second.RecoveryCodes), recoveryCodeCount)
+	}
+	if !strings.HasPrefix(second.ProvisioningURI, "otpauth://totp/") || !strings.Contains(second.ProvisioningURI, second.Secret) {
+		t.Errorf("provisioning URI = %s, want an otpauth URI with the secret", second.ProvisioningURI)
+	}
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"muchway/pkg/auth"
+	"muchway/user_service/repository"
+)
+
+// KeyManager holds the keys that sign access tokens and rotates them. The
+// newest key signs; older keys stay in the published JWKS until every token
+// they signed has expired.
+type KeyManager struct {
+	repo        repository.SigningKeyRepository
+	rotateEvery time.Duration
+	tokenTTL    time.Duration
+
+	mu   sync.RWMutex
+	keys []*auth.SigningKey
+}
+
+func NewKeyManager(repo repository.SigningKeyRepository, rotateEvery, tokenTTL time.Duration) *KeyManager {
+	return &KeyManager{repo: repo, rotateEvery: rotateEvery, tokenTTL: tokenTTL}
+}
+
+// Rotate reloads the keys, which may have been rotated by another instance,
+// and adds a new key when the newest one is due for rotation.
+func (m *KeyManager) Rotate(ctx context.Context) error {
+	keys, err := m.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 || time.Since(keys[0].CreatedAt) >= m.rotateEvery {
+		// A key signs for rotateEvery and must verify for a token lifetime
+		// after that
+		key, err := auth.GenerateKey(time.Now().Add(m.rotateEvery + m.tokenTTL))
+		if err != nil {
+			return err
+		}
+		if err := m.repo.Create(ctx, key); err != nil {
+			return err
+		}
+		log.Printf("Rotated token signing key, new key %s", key.ID)
+		keys = append([]*auth.SigningKey{key}, keys...)
+	}
+	if _, err := m.repo.DeleteExpired(ctx, time.Now()); err != nil {
+		log.Printf("Failed to delete expired signing keys: %v", err)
+	}
+
+	m.mu.Lock()
+	m.keys = keys
+	m.mu.Unlock()
+	return nil
+}
+
+// Run rotates keys until ctx is cancelled.
+func (m *KeyManager) Run(ctx context.Context, every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := m.Rotate(ctx); err != nil {
+				log.Printf("Failed to rotate signing keys: %v", err)
+			}
+		}
+	}
+}
+
+// SigningKey returns the key new tokens are signed with.
+func (m *KeyManager) SigningKey() (*auth.SigningKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if len(m.keys) == 0 {
+		return nil, errors.New("no signing key loaded")
+	}
+	return m.keys[0], nil
+}
+
+// JWKS returns the public keys tokens may currently be signed with.
+func (m *KeyManager) JWKS() *auth.JWKS {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	set := &auth.JWKS{Keys: []auth.JWK{}}
+	for _, k := range m.keys {
+		set.Keys = append(set.Keys, k.JWK())
+	}
+	return set
+}
+
+// PublicKey makes the manager a KeySource, so user_service verifies its own
+// tokens without fetching its JWKS.
+func (m *KeyManager) PublicKey(_ context.Context, kid string) (ed25519.PublicKey, error) {
+	return m.JWKS().Key(kid)
+}
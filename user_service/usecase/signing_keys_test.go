@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"muchway/pkg/auth"
+)
+
+// fakeSigningKeys keeps signing keys in memory, newest first.
+type fakeSigningKeys struct {
+	mu   sync.Mutex
+	keys []*auth.SigningKey
+}
+
+func (r *fakeSigningKeys) List(context.Context) ([]*auth.SigningKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var live []*auth.SigningKey
+	for _, k := range r.keys {
+		if k.ExpiresAt.After(time.Now()) {
+			live = append(live, k)
+		}
+	}
+	return live, nil
+}
+
+func (r *fakeSigningKeys) Create(_ context.Context, key *auth.SigningKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys = append([]*auth.SigningKey{key}, r.keys...)
+	return nil
+}
+
+func (r *fakeSigningKeys) DeleteExpired(_ context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var n int64
+	live := r.keys[:0]
+	for _, k := range r.keys {
+		if k.ExpiresAt.After(before) {
+			live = append(live, k)
+		} else {
+			n++
+		}
+	}
+	r.keys = live
+	return n, nil
+}
+
+// newKeyManager returns a key manager with one key loaded. A rotateEvery of
+// zero rotates on every call to Rotate.
+func newKeyManager(t *testing.T, rotateEvery time.Duration) *KeyManager {
+	t.Helper()
+	keys := NewKeyManager(&fakeSigningKeys{}, rotateEvery, time.Hour)
+	if err := keys.Rotate(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+func TestRotateKeepsTheKeyUntilItIsDue(t *testing.T) {
+	keys := newKeyManager(t, time.Hour)
+	first, _ := keys.SigningKey()
+	if err := keys.Rotate(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if key, _ := keys.SigningKey(); key.ID != first.ID {
+		t.Errorf("signing key changed to %s before it was due", key.ID)
+	}
+	if n := len(keys.JWKS().Keys); n != 1 {
+		t.Errorf("JWKS has %d keys, want 1", n)
+	}
+}
+
+func TestTokensSignedBeforeRotationStillVerify(t *testing.T) {
+	keys := newKeyManager(t, 0)
+	tokens := NewTokenUsecase(nil, keys, nil, nil, TokenConfig{AccessTTL: 15 * time.Minute, ServiceClients: map[string]string{"bet_service": "secret"}})
+	old, _, err := tokens.IssueServiceToken("bet_service", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous, _ := keys.SigningKey()
+
+	if err := keys.Rotate(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if key, _ := keys.SigningKey(); key.ID == previous.ID {
+		t.Fatal("Rotate kept signing with the previous key")
+	}
+	current, _, err := tokens.IssueServiceToken("bet_service", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Other services verify against the published JWKS
+	jwks := httptest.NewServer(auth.JWKSHandler(keys.JWKS))
+	defer jwks.Close()
+	verifiers := map[string]*auth.Verifier{
+		"key manager": auth.NewVerifier(keys, Issuer),
+		"JWKS":        auth.NewVerifier(auth.NewRemoteKeySet(jwks.URL), Issuer),
+	}
+	for name, v := range verifiers {
+		for _, token := range []string{old, current} {
+			if _, err := v.Verify(context.Background(), token); err != nil {
+				t.Errorf("%s: Verify = %v", name, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,311 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"muchway/pkg/auth"
+	"muchway/user_service/domain"
+	"muchway/user_service/repository"
+)
+
+// Issuer is the issuer claim of the tokens user_service signs.
+const Issuer = "user_service"
+
+type TokenConfig struct {
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+	// ServiceClients maps the client IDs of other services to their secrets.
+	ServiceClients map[string]string
+}
+
+type TokenUsecase interface {
+	// Issue starts a session for a user who has just logged in. mfaAt is
+	// when they proved a second factor, zero if they did not.
+	Issue(user *domain.User, mfaAt time.Time) (*domain.TokenPair, error)
+	// StepUp returns a new access token for a user who has just proved a
+	// second factor during the session that started at sessionStart, zero
+	// if unknown. Like any access token it ends with the session limit.
+	StepUp(user *domain.User, sessionStart time.Time) (string, time.Time, error)
+	// Refresh exchanges a refresh token for a new pair. Each refresh token
+	// works once; presenting a used one revokes the whole session.
+	Refresh(refreshToken string) (*domain.TokenPair, error)
+	// Logout revokes the session the refresh token belongs to.
+	Logout(refreshToken string) error
+	// RevokeUser ends every session of the user.
+	RevokeUser(userID int64) error
+	IssueServiceToken(clientID, secret string) (string, time.Time, error)
+}
+
+type tokenUsecase struct {
+	repo   repository.UserRepository
+	keys   *KeyManager
+	redis  *redis.Client
+	limits LimitUsecase
+	cfg    TokenConfig
+}
+
+func NewTokenUsecase(repo repository.UserRepository, keys *KeyManager, redis *redis.Client, limits LimitUsecase, cfg TokenConfig) TokenUsecase {
+	return &tokenUsecase{repo: repo, keys: keys, redis: redis, limits: limits, cfg: cfg}
+}
+
+// refreshSession is stored under the hash of each live refresh token. All
+// tokens handed out since a login share its family, so a session can be
+// revoked as a whole, and remember when the login happened for the session
+// limit.
+type refreshSession struct {
+	UserID    int64  `json:"user_id"`
+	Family    string `json:"family"`
+	MFAAt     int64  `json:"mfa_at,omitempty"`
+	StartedAt int64  `json:"started_at,omitempty"`
+}
+
+func refreshKey(hash string) string     { return "refresh:" + hash }
+func usedRefreshKey(hash string) string { return "refresh_used:" + hash }
+func familyKey(family string) string    { return "refresh_family:" + family }
+func userFamiliesKey(userID int64) string {
+	return "refresh_user:" + strconv.FormatInt(userID, 10)
+}
+
+func (t *tokenUsecase) Issue(user *domain.User, mfaAt time.Time) (*domain.TokenPair, error) {
+	var at int64
+	if !mfaAt.IsZero() {
+		at = mfaAt.Unix()
+	}
+	return t.issue(context.Background(), user, refreshSession{
+		UserID:    user.ID,
+		Family:    uuid.New().String(),
+		MFAAt:     at,
+		StartedAt: time.Now().Unix(),
+	})
+}
+
+func (t *tokenUsecase) StepUp(user *domain.User, sessionStart time.Time) (string, time.Time, error) {
+	var startedAt int64
+	if !sessionStart.IsZero() {
+		startedAt = sessionStart.Unix()
+	}
+	left, err := t.sessionLeft(user.ID, startedAt)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return t.sign(strconv.FormatInt(user.ID, 10), user.Username, userRole(user), time.Now().Unix(), startedAt, capTTL(t.cfg.AccessTTL, left))
+}
+
+func userRole(user *domain.User) string {
+	if user.Role == "" {
+		return auth.RoleBettor
+	}
+	return user.Role
+}
+
+func (t *tokenUsecase) issue(ctx context.Context, user *domain.User, session refreshSession) (*domain.TokenPair, error) {
+	left, err := t.sessionLeft(user.ID, session.StartedAt)
+	if err != nil {
+		return nil, err
+	}
+	access, accessExp, err := t.sign(strconv.FormatInt(user.ID, 10), user.Username, userRole(user), session.MFAAt, session.StartedAt, capTTL(t.cfg.AccessTTL, left))
+	if err != nil {
+		return nil, err
+	}
+	// The refresh token is no use once the session limit is reached
+	refreshTTL := capTTL(t.cfg.RefreshTTL, left)
+
+	refresh, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	hash := hashToken(refresh)
+	family := session.Family
+	raw, err := json.Marshal(session)
+	if err != nil {
+		return nil, err
+	}
+	pipe := t.redis.TxPipeline()
+	pipe.Set(ctx, refreshKey(hash), raw, refreshTTL)
+	pipe.SAdd(ctx, familyKey(family), hash)
+	pipe.Expire(ctx, familyKey(family), refreshTTL)
+	pipe.SAdd(ctx, userFamiliesKey(user.ID), family)
+	pipe.Expire(ctx, userFamiliesKey(user.ID), t.cfg.RefreshTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	return &domain.TokenPair{
+		AccessToken:           access,
+		RefreshToken:          refresh,
+		AccessTokenExpiresAt:  accessExp,
+		RefreshTokenExpiresAt: time.Now().Add(refreshTTL),
+	}, nil
+}
+
+func (t *tokenUsecase) Refresh(refreshToken string) (*domain.TokenPair, error) {
+	ctx := context.Background()
+	hash := hashToken(refreshToken)
+
+	raw, err := t.redis.GetDel(ctx, refreshKey(hash)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		// A token that was already exchanged has leaked or been replayed
+		family, usedErr := t.redis.Get(ctx, usedRefreshKey(hash)).Result()
+		if usedErr == nil {
+			log.Printf("Refresh token reused, revoking session %s", family)
+			if err := t.revokeFamily(ctx, family); err != nil {
+				log.Printf("Failed to revoke session %s: %v", family, err)
+			}
+			return nil, domain.ErrRefreshTokenReused
+		}
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var session refreshSession
+	if err := json.Unmarshal(raw, &session); err != nil {
+		return nil, err
+	}
+	if err := t.redis.Set(ctx, usedRefreshKey(hash), session.Family, t.cfg.RefreshTTL).Err(); err != nil {
+		return nil, err
+	}
+	t.redis.SRem(ctx, familyKey(session.Family), hash)
+
+	// Reload the user so a changed role or a deleted, disabled or excluded
+	// account takes effect
+	user, err := t.repo.GetByID(session.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !user.Active || user.Excluded(time.Now()) != nil {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	// Sessions from before session limits are timed from their next refresh
+	if session.StartedAt == 0 {
+		session.StartedAt = time.Now().Unix()
+	}
+	return t.issue(ctx, user, session)
+}
+
+// sessionLeft is how much longer the session that started at startedAt may
+// last under the user's session limit, zero if there is no limit.
+func (t *tokenUsecase) sessionLeft(userID, startedAt int64) (time.Duration, error) {
+	limit, err := t.limits.SessionLimit(userID)
+	if err != nil {
+		return 0, err
+	}
+	if limit == 0 || startedAt == 0 {
+		return 0, nil
+	}
+	left := time.Until(time.Unix(startedAt, 0).Add(limit))
+	if left <= 0 {
+		return 0, domain.ErrSessionLimitReached
+	}
+	return left, nil
+}
+
+// capTTL cuts ttl short so a token does not outlive the session, which has
+// left to go, or no limit if left is zero.
+func capTTL(ttl, left time.Duration) time.Duration {
+	if left > 0 && left < ttl {
+		return left
+	}
+	return ttl
+}
+
+func (t *tokenUsecase) Logout(refreshToken string) error {
+	ctx := context.Background()
+	raw, err := t.redis.Get(ctx, refreshKey(hashToken(refreshToken))).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var session refreshSession
+	if err := json.Unmarshal(raw, &session); err != nil {
+		return err
+	}
+	return t.revokeFamily(ctx, session.Family)
+}
+
+func (t *tokenUsecase) RevokeUser(userID int64) error {
+	ctx := context.Background()
+	families, err := t.redis.SMembers(ctx, userFamiliesKey(userID)).Result()
+	if err != nil {
+		return err
+	}
+	for _, family := range families {
+		if err := t.revokeFamily(ctx, family); err != nil {
+			return err
+		}
+	}
+	return t.redis.Del(ctx, userFamiliesKey(userID)).Err()
+}
+
+func (t *tokenUsecase) revokeFamily(ctx context.Context, family string) error {
+	hashes, err := t.redis.SMembers(ctx, familyKey(family)).Result()
+	if err != nil {
+		return err
+	}
+	keys := []string{familyKey(family)}
+	for _, h := range hashes {
+		keys = append(keys, refreshKey(h))
+	}
+	return t.redis.Del(ctx, keys...).Err()
+}
+
+func (t *tokenUsecase) IssueServiceToken(clientID, secret string) (string, time.Time, error) {
+	want, ok := t.cfg.ServiceClients[clientID]
+	if !ok || want == "" || subtle.ConstantTimeCompare([]byte(want), []byte(secret)) != 1 {
+		return "", time.Time{}, domain.ErrInvalidClient
+	}
+	return t.sign("service:"+clientID, clientID, auth.RoleService, 0, 0, t.cfg.AccessTTL)
+}
+
+func (t *tokenUsecase) sign(subject, username, role string, mfaAt, authTime int64, ttl time.Duration) (string, time.Time, error) {
+	key, err := t.keys.SigningKey()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	now := time.Now()
+	exp := now.Add(ttl)
+	token, err := auth.Sign(key, &auth.Claims{
+		ID:        uuid.New().String(),
+		Issuer:    Issuer,
+		Subject:   subject,
+		Username:  username,
+		Role:      role,
+		Type:      auth.TokenTypeAccess,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: exp.Unix(),
+		MFAAt:     mfaAt,
+		AuthTime:  authTime,
+	})
+	return token, exp, err
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken is how refresh tokens are stored, so a copy of Redis does not
+// hand out live tokens.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"muchway/pkg/auth"
+	"muchway/pkg/outbox"
+	"muchway/user_service/domain"
+	"muchway/user_service/repository"
+)
+
+// fakeUsers keeps users in memory. Methods the tests do not need are left
+// to the embedded nil interface.
+type fakeUsers struct {
+	repository.UserRepository
+
+	mu     sync.Mutex
+	users  map[int64]*domain.User
+	hashes map[int64]string
+	// outbox collects the messages written with changes
+	outbox []outbox.Message
+}
+
+func newFakeUsers(users ...*domain.User) *fakeUsers {
+	r := &fakeUsers{users: map[int64]*domain.User{}, hashes: map[int64]string{}}
+	for _, u := range users {
+		c := *u
+		r.users[u.ID] = &c
+	}
+	return r
+}
+
+func (r *fakeUsers) GetByID(id int64) (*domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u, ok := r.users[id]
+	if !ok {
+		return nil, nil
+	}
+	c := *u
+	return &c, nil
+}
+
+func (r *fakeUsers) SetActive(userID int64, active bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users[userID].Active = active
+	return nil
+}
+
+// fakeSessionLimit is a LimitUsecase that only knows a session limit.
+type fakeSessionLimit struct {
+	LimitUsecase
+	limit time.Duration
+}
+
+func (l fakeSessionLimit) SessionLimit(int64) (time.Duration, error) { return l.limit, nil }
+
+var testTokenConfig = TokenConfig{AccessTTL: 15 * time.Minute, RefreshTTL: 24 * time.Hour}
+
+var ann = &domain.User{ID: 1, Username: "ann", Active: true}
+
+func newTokens(t *testing.T, sessionLimit time.Duration) (TokenUsecase, *fakeUsers, *auth.Verifier) {
+	t.Helper()
+	_, rdb := newTestRedis(t)
+	keys := newKeyManager(t, time.Hour)
+	users := newFakeUsers(ann)
+	return NewTokenUsecase(users, keys, rdb, fakeSessionLimit{limit: sessionLimit}, testTokenConfig), users, auth.NewVerifier(keys, Issuer)
+}
+
+func TestRefreshRotatesTheRefreshToken(t *testing.T) {
+	tokens, _, verifier := newTokens(t, 0)
+	pair, err := tokens.Issue(ann, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next, err := tokens.Refresh(pair.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh = %v", err)
+	}
+	if next.RefreshToken == pair.RefreshToken {
+		t.Error("Refresh handed back the same refresh token")
+	}
+	claims, err := verifier.Verify(context.Background(), next.AccessToken)
+	if err != nil {
+		t.Fatalf("new access token: %v", err)
+	}
+	if claims.Subject != "1" || claims.Role != auth.RoleBettor {
+		t.Errorf("claims = %s %s, want 1 %s", claims.Subject, claims.Role, auth.RoleBettor)
+	}
+	if _, err := tokens.Refresh(next.RefreshToken); err != nil {
+		t.Errorf("Refresh of the new token = %v", err)
+	}
+}
+
+func TestReusedRefreshTokenRevokesTheSession(t *testing.T) {
+	tokens, _, _ := newTokens(t, 0)
+	pair, _ := tokens.Issue(ann, time.Time{})
+	other, _ := tokens.Issue(ann, time.Time{})
+
+	next, err := tokens.Refresh(pair.RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tokens.Refresh(pair.RefreshToken); !errors.Is(err, domain.ErrRefreshTokenReused) {
+		t.Fatalf("reusing a refresh token = %v, want ErrRefreshTokenReused", err)
+	}
+	if _, err := tokens.Refresh(next.RefreshToken); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+		t.Errorf("Refresh of the session's newest token = %v, want ErrInvalidRefreshToken", err)
+	}
+	if _, err := tokens.Refresh(other.RefreshToken); err != nil {
+		t.Errorf("Refresh of another session = %v, want it untouched", err)
+	}
+}
+
+func TestRevokedSessionsCannotRefresh(t *testing.T) {
+	cases := []struct {
+		name   string
+		revoke func(TokenUsecase, *fakeUsers, *domain.TokenPair) error
+	}{
+		{"logout", func(tokens TokenUsecase, _ *fakeUsers, p *domain.TokenPair) error {
+			return tokens.Logout(p.RefreshToken)
+		}},
+		{"all sessions revoked", func(tokens TokenUsecase, _ *fakeUsers, _ *domain.TokenPair) error {
+			return tokens.RevokeUser(ann.ID)
+		}},
+		{"account disabled", func(_ TokenUsecase, users *fakeUsers, _ *domain.TokenPair) error {
+			return users.SetActive(ann.ID, false)
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tokens, users, _ := newTokens(t, 0)
+			pair, err := tokens.Issue(ann, time.Time{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := c.revoke(tokens, users, pair); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := tokens.Refresh(pair.RefreshToken); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+				t.Errorf("Refresh = %v, want ErrInvalidRefreshToken", err)
+			}
+		})
+	}
+}
+
+func TestRevokeUserEndsEverySession(t *testing.T) {
+	tokens, _, _ := newTokens(t, 0)
+	var pairs []*domain.TokenPair
+	for i := 0; i < 3; i++ {
+		pair, err := tokens.Issue(ann, time.Time{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		pairs = append(pairs, pair)
+	}
+	// A refreshed session is revoked too
+	refreshed, err := tokens.Refresh(pairs[0].RefreshToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pairs[0] = refreshed
+
+	if err := tokens.RevokeUser(ann.ID); err != nil {
+		t.Fatal(err)
+	}
+	for i, p := range pairs {
+		if _, err := tokens.Refresh(p.RefreshToken); !errors.Is(err, domain.ErrInvalidRefreshToken) {
+			t.Errorf("session %d: Refresh = %v, want ErrInvalidRefreshToken", i+1, err)
+		}
+	}
+}
+
+func TestTokensEndWithTheSessionLimit(t *testing.T) {
+	tokens, _, verifier := newTokens(t, 10*time.Minute)
+	loginAt := time.Now()
+	sessionEnd := loginAt.Add(10 * time.Minute)
+
+	pair, err := tokens.Issue(ann, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pair.AccessTokenExpiresAt.After(sessionEnd.Add(time.Second)) || pair.RefreshTokenExpiresAt.After(sessionEnd.Add(time.Second)) {
+		t.Errorf("login tokens expire at %s and %s, after the session ends at %s",
+			pair.AccessTokenExpiresAt, pair.RefreshTokenExpiresAt, sessionEnd)
+	}
+	claims, err := verifier.Verify(context.Background(), pair.AccessToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, expiresAt, err := tokens.StepUp(ann, time.Unix(claims.AuthTime, 0))
+	if err != nil {
+		t.Fatalf("StepUp = %v", err)
+	}
+	if expiresAt.After(sessionEnd.Add(time.Second)) {
+		t.Errorf("step-up token expires at %s, after the session ends at %s", expiresAt, sessionEnd)
+	}
+
+	if _, _, err := tokens.StepUp(ann, loginAt.Add(-11*time.Minute)); !errors.Is(err, domain.ErrSessionLimitReached) {
+		t.Errorf("StepUp after the session limit = %v, want ErrSessionLimitReached", err)
+	}
+}
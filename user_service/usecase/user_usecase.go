@@ -3,12 +3,20 @@ package usecase
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"muchway/pkg/events"
+	"muchway/pkg/limits"
+	"muchway/pkg/money"
+	"muchway/pkg/outbox"
+	"muchway/pkg/topology"
 	"muchway/user_service/domain"
 	"muchway/user_service/email"
 	"muchway/user_service/rabbitmq"
 	"muchway/user_service/repository"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -17,14 +25,57 @@ import (
 )
 
 type UserUsecase interface {
-	Register(user *domain.User) error
-	Login(email, password string) (*domain.User, error)
+	Register(user *domain.User, password string) error
+	// Login checks the password and, when the stored hash is weaker than
+	// passwordCost, replaces it with a stronger one. Failed attempts are
+	// counted per account and per client address ip, which may be empty.
+	Login(email, password, ip string) (*domain.User, error)
+	ChangePassword(userID int64, currentPassword, newPassword string) error
 	GetAllUsers() ([]*domain.User, error)
 	GetUserByID(ctx context.Context, id int64) (*domain.User, error) // ← updated
 	GetUserByUsername(username string) (*domain.User, error)
 	GetUserByEmail(email string) (*domain.User, error)
 	UpdateUser(user *domain.User) error
 	DeleteUser(username string) error
+
+	VerifyEmail(token string) error
+	// RequestPasswordReset emails a reset link. It succeeds whether or not
+	// the address belongs to an account, so it cannot be used to find one.
+	// Requests are throttled per address and per client address ip, which
+	// may be empty.
+	RequestPasswordReset(email, ip string) error
+	// ResetPassword sets a new password with a token from a reset link and
+	// returns the user it belongs to.
+	ResetPassword(token, newPassword string) (int64, error)
+	// UnlockAccount lifts a lockout with the token from the unlock email.
+	UnlockAccount(token string) error
+	// SetActive enables or disables an account.
+	SetActive(userID int64, active bool) error
+	// SyncBalance records the wallet balance reported by the payment
+	// service, which owns it. An update older than the stored balance is
+	// ignored; the result says whether it was applied.
+	SyncBalance(userID int64, balance money.Money, version int64) (bool, error)
+	// Exclude excludes the user from gambling for the period. An exclusion
+	// already in force is only ever extended; the one in force afterwards is
+	// returned.
+	Exclude(userID int64, period limits.ExclusionPeriod) (*limits.Exclusion, error)
+}
+
+// UserConfig configures the account flows.
+type UserConfig struct {
+	// RequireVerifiedEmail blocks login until the email is verified.
+	RequireVerifiedEmail bool
+	// EmailTokenSecret signs the tokens in verification and reset links.
+	EmailTokenSecret []byte
+	VerifyEmailTTL   time.Duration
+	PasswordResetTTL time.Duration
+	// LinkBaseURL is where the links in emails point, e.g.
+	// "https://muchwaybet.example".
+	LinkBaseURL string
+	Lockout     LockoutConfig
+	// PasswordResetLimit throttles reset requests like Lockout throttles
+	// failed logins, except that every request counts.
+	PasswordResetLimit LockoutConfig
 }
 
 type userUsecase struct {
@@ -32,59 +83,234 @@ type userUsecase struct {
 	publisher    *rabbitmq.Publisher
 	redis        *redis.Client
 	emailService email.EmailService
+	cfg          UserConfig
+	emailTokens  *emailTokens
+	guard        *loginGuard
+	resetGuard   *loginGuard
 }
 
-func NewUserUsecase(repo repository.UserRepository, publisher *rabbitmq.Publisher, redis *redis.Client, emailService email.EmailService) UserUsecase {
+func NewUserUsecase(repo repository.UserRepository, publisher *rabbitmq.Publisher, redis *redis.Client, emailService email.EmailService, cfg UserConfig) UserUsecase {
+	if cfg.Lockout == (LockoutConfig{}) {
+		cfg.Lockout = DefaultLockoutConfig
+	}
+	if cfg.PasswordResetLimit == (LockoutConfig{}) {
+		cfg.PasswordResetLimit = DefaultPasswordResetLimit
+	}
 	return &userUsecase{
 		repo:         repo,
 		publisher:    publisher,
 		redis:        redis,
 		emailService: emailService,
+		cfg:          cfg,
+		emailTokens:  &emailTokens{redis: redis, secret: cfg.EmailTokenSecret},
+		guard:        &loginGuard{redis: redis, cfg: cfg.Lockout, scope: "login"},
+		resetGuard:   &loginGuard{redis: redis, cfg: cfg.PasswordResetLimit, scope: "reset"},
 	}
 }
 
-func (u *userUsecase) Register(user *domain.User) error {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+// passwordCost is the bcrypt cost new hashes are made with. Hashes made with
+// a lower cost are upgraded when their owner next logs in.
+const passwordCost = 12
+
+const minPasswordLength = 8
+
+func hashPassword(password string) (string, error) {
+	if len(password) < minPasswordLength {
+		return "", domain.ErrWeakPassword
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), passwordCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (u *userUsecase) Register(user *domain.User, password string) error {
+	hashedPassword, err := hashPassword(password)
 	if err != nil {
 		return err
 	}
-	user.Password = string(hashedPassword)
 
-	if err := u.repo.Create(user); err != nil {
+	if err := u.repo.Create(user, hashedPassword, userChanged(topology.UserCreated, user)); err != nil {
 		return err
 	}
 
-	if u.publisher != nil {
-		if err := u.publisher.Publish("user.created", user); err != nil {
-			log.Println("Failed to publish user.created:", err)
-		}
+	// We don't fail the registration if the email cannot be sent
+	u.sendVerification(user)
+
+	return nil
+}
+
+// sendVerification emails user a link to verify their address.
+func (u *userUsecase) sendVerification(user *domain.User) {
+	if u.emailService == nil {
+		return
 	}
+	token, err := u.emailTokens.issue(context.Background(), purposeVerifyEmail, user.ID, u.cfg.VerifyEmailTTL)
+	if err != nil {
+		log.Println("Failed to issue email verification token:", err)
+		return
+	}
+	link := u.cfg.LinkBaseURL + "/verify-email?token=" + url.QueryEscape(token)
+	if err := u.emailService.SendEmailVerification(user.Email, user.Username, link); err != nil {
+		log.Println("Failed to send verification email:", err)
+		return
+	}
+	log.Println("Verification email sent to:", user.Email)
+}
 
-	// Send registration confirmation email
-	if u.emailService != nil {
-		if err := u.emailService.SendRegistrationConfirmation(user.Email, user.Username); err != nil {
-			log.Println("Failed to send registration confirmation email:", err)
-			// We don't return the error here to avoid failing the registration process
-			// if email sending fails
-		} else {
-			log.Println("Registration confirmation email sent to:", user.Email)
-		}
+func (u *userUsecase) VerifyEmail(token string) error {
+	userID, err := u.emailTokens.consume(context.Background(), purposeVerifyEmail, token)
+	if err != nil {
+		return err
+	}
+	if err := u.repo.MarkEmailVerified(userID); err != nil {
+		return err
+	}
+	u.forget(userID)
+	return nil
+}
+
+func (u *userUsecase) RequestPasswordReset(email, ip string) error {
+	ctx := context.Background()
+	if err := u.resetGuard.check(ctx, email, ip); err != nil {
+		return err
+	}
+	// Every request counts, whether or not the address has an account
+	if _, err := u.resetGuard.fail(ctx, email, ip); err != nil {
+		log.Println("Failed to record password reset request:", err)
 	}
 
+	user, err := u.repo.GetByEmail(email)
+	if err != nil {
+		return err
+	}
+	if user == nil || u.emailService == nil {
+		return nil
+	}
+	token, err := u.emailTokens.issue(ctx, purposeResetPassword, user.ID, u.cfg.PasswordResetTTL)
+	if err != nil {
+		return err
+	}
+	link := u.cfg.LinkBaseURL + "/reset-password?token=" + url.QueryEscape(token)
+	if err := u.emailService.SendPasswordReset(user.Email, user.Username, link); err != nil {
+		log.Println("Failed to send password reset email:", err)
+	}
 	return nil
 }
 
-func (u *userUsecase) Login(email, password string) (*domain.User, error) {
+func (u *userUsecase) ResetPassword(token, newPassword string) (int64, error) {
+	// Check the password first so a weak one does not use up the token
+	hash, err := hashPassword(newPassword)
+	if err != nil {
+		return 0, err
+	}
+	userID, err := u.emailTokens.consume(context.Background(), purposeResetPassword, token)
+	if err != nil {
+		return 0, err
+	}
+	if err := u.repo.UpdatePassword(userID, hash); err != nil {
+		return 0, err
+	}
+	// Following the link proves the user owns the address
+	if err := u.repo.MarkEmailVerified(userID); err != nil {
+		log.Println("Failed to mark email verified:", err)
+	}
+	if err := u.unlock(userID); err != nil {
+		log.Println("Failed to unlock account:", err)
+	}
+	u.forget(userID)
+	return userID, nil
+}
+
+// forget drops the cached copy of a user.
+func (u *userUsecase) forget(userID int64) {
+	if err := u.redis.Del(context.Background(), fmt.Sprintf("user:%d", userID)).Err(); err != nil {
+		log.Println("Failed to evict cached user:", err)
+	}
+}
+
+// eventSource stamps the messages user_service publishes.
+var eventSource = events.Source{Producer: "user_service"}
+
+// userMessage is an outbox message for the users exchange. Its envelope is
+// built when the outbox writes it, after the insert has assigned the user ID.
+func userMessage(routingKey string, version int, build func() (string, interface{})) outbox.Message {
+	return outbox.Message{
+		Exchange:   topology.ExchangeUsers,
+		RoutingKey: routingKey,
+		Payload:    eventSource.Deferred(routingKey, version, build),
+	}
+}
+
+func userChanged(routingKey string, user *domain.User) outbox.Message {
+	return userMessage(routingKey, events.UserVersion, func() (string, interface{}) {
+		return strconv.FormatInt(user.ID, 10), events.UserV1{
+			ID:       user.ID,
+			Username: user.Username,
+			Email:    user.Email,
+			Role:     user.Role,
+		}
+	})
+}
+
+// dummyHash is compared against when the email is unknown, so such logins
+// take as long as real ones.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not a real password"), passwordCost)
+
+func (u *userUsecase) Login(email, password, ip string) (*domain.User, error) {
+	ctx := context.Background()
+	if err := u.guard.check(ctx, email, ip); err != nil {
+		return nil, err
+	}
+
 	user, err := u.repo.GetByEmail(email)
 	if err != nil {
 		return nil, err
 	}
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+	var creds *domain.Credentials
+	if user == nil {
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		err = domain.ErrInvalidCredentials
+	} else {
+		creds, err = u.checkPassword(user.ID, password)
+	}
+	if errors.Is(err, domain.ErrInvalidCredentials) {
+		u.loginFailed(ctx, email, ip, user)
+		return nil, err
+	}
+	if err != nil {
 		return nil, err
 	}
+	if err := u.guard.reset(ctx, email); err != nil {
+		log.Println("Failed to reset login failures:", err)
+	}
+
+	// Only someone who knows the password learns the account is disabled
+	if !user.Active {
+		return nil, domain.ErrAccountDisabled
+	}
+	if e := user.Excluded(time.Now()); e != nil {
+		return nil, e.Err()
+	}
+	if u.cfg.RequireVerifiedEmail && !user.EmailVerified {
+		return nil, domain.ErrEmailNotVerified
+	}
+	if cost, err := bcrypt.Cost([]byte(creds.PasswordHash)); err == nil && cost < passwordCost {
+		if hash, err := bcrypt.GenerateFromPassword([]byte(password), passwordCost); err == nil {
+			if err := u.repo.UpdatePassword(user.ID, string(hash)); err != nil {
+				log.Println("Failed to upgrade password hash:", err)
+			}
+		}
+	}
 
 	if u.publisher != nil {
-		if err := u.publisher.Publish("user.logged_in", user); err != nil {
+		env, err := eventSource.New(topology.UserLoggedIn, events.UserLoggedInVersion, strconv.FormatInt(user.ID, 10),
+			events.UserLoggedInV1{ID: user.ID, Username: user.Username, LoggedInAt: time.Now()})
+		if err == nil {
+			err = u.publisher.Publish(topology.UserLoggedIn, env)
+		}
+		if err != nil {
 			log.Println(" Failed to publish user.logged_in:", err)
 		}
 	}
@@ -92,6 +318,126 @@ func (u *userUsecase) Login(email, password string) (*domain.User, error) {
 	return user, nil
 }
 
+// loginFailed counts a failed login and, when it locks an existing account,
+// emails the owner an unlock link.
+func (u *userUsecase) loginFailed(ctx context.Context, email, ip string, user *domain.User) {
+	locked, err := u.guard.fail(ctx, email, ip)
+	if err != nil {
+		log.Println("Failed to record failed login:", err)
+		return
+	}
+	if !locked || user == nil {
+		return
+	}
+	log.Printf("Account %d locked after repeated failed logins", user.ID)
+	if u.emailService == nil {
+		return
+	}
+	token, err := u.emailTokens.issue(ctx, purposeUnlockAccount, user.ID, u.guard.cfg.LockDuration)
+	if err != nil {
+		log.Println("Failed to issue unlock token:", err)
+		return
+	}
+	link := u.cfg.LinkBaseURL + "/unlock-account?token=" + url.QueryEscape(token)
+	if err := u.emailService.SendAccountLocked(user.Email, user.Username, link); err != nil {
+		log.Println("Failed to send account locked email:", err)
+	}
+}
+
+func (u *userUsecase) UnlockAccount(token string) error {
+	userID, err := u.emailTokens.consume(context.Background(), purposeUnlockAccount, token)
+	if err != nil {
+		return err
+	}
+	return u.unlock(userID)
+}
+
+func (u *userUsecase) unlock(userID int64) error {
+	user, err := u.repo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return domain.ErrInvalidEmailToken
+	}
+	return u.guard.reset(context.Background(), user.Email)
+}
+
+func (u *userUsecase) SetActive(userID int64, active bool) error {
+	if err := u.repo.SetActive(userID, active); err != nil {
+		return err
+	}
+	u.forget(userID)
+	return nil
+}
+
+func (u *userUsecase) SyncBalance(userID int64, balance money.Money, version int64) (bool, error) {
+	applied, err := u.repo.SetBalance(userID, balance, version)
+	if err != nil {
+		return false, err
+	}
+	if applied {
+		u.forget(userID)
+	}
+	return applied, nil
+}
+
+func (u *userUsecase) Exclude(userID int64, period limits.ExclusionPeriod) (*limits.Exclusion, error) {
+	now := time.Now()
+	e, err := limits.Exclude(period, now)
+	if err != nil {
+		return nil, err
+	}
+	user, err := u.repo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+	if current := user.Excluded(now); current != nil {
+		if !e.Outlasts(*current) {
+			return current, nil
+		}
+		// Extending keeps the original start
+		e.Since = current.Since
+	}
+
+	excluded := userMessage(topology.UserExcluded, events.UserExcludedVersion, func() (string, interface{}) {
+		return strconv.FormatInt(userID, 10), events.UserExcludedV1{ID: userID, Since: e.Since, Until: e.Until}
+	})
+	if err := u.repo.SetExclusion(userID, e, excluded); err != nil {
+		return nil, err
+	}
+	u.forget(userID)
+	return &e, nil
+}
+
+func (u *userUsecase) checkPassword(userID int64, password string) (*domain.Credentials, error) {
+	creds, err := u.repo.GetCredentials(userID)
+	if err != nil {
+		return nil, err
+	}
+	if creds == nil {
+		return nil, domain.ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(creds.PasswordHash), []byte(password)); err != nil {
+		return nil, domain.ErrInvalidCredentials
+	}
+	return creds, nil
+}
+
+func (u *userUsecase) ChangePassword(userID int64, currentPassword, newPassword string) error {
+	if _, err := u.checkPassword(userID, currentPassword); err != nil {
+		return err
+	}
+	hash, err := hashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	return u.repo.UpdatePassword(userID, hash)
+}
+
 func (u *userUsecase) GetAllUsers() ([]*domain.User, error) {
 	return u.repo.GetAll()
 }
@@ -117,6 +463,9 @@ func (u *userUsecase) GetUserByID(ctx context.Context, id int64) (*domain.User,
 		fmt.Println("Error fetching user from database:", err)
 		return nil, err
 	}
+	if user == nil {
+		return nil, nil
+	}
 
 	fmt.Println("User fetched from database:", user.ID)
 
@@ -138,17 +487,34 @@ func (u *userUsecase) GetUserByEmail(email string) (*domain.User, error) {
 }
 
 func (u *userUsecase) UpdateUser(user *domain.User) error {
-	err := u.repo.Update(user)
-	if err == nil && u.publisher != nil {
-		_ = u.publisher.Publish("user.updated", user)
+	current, err := u.repo.GetByID(user.ID)
+	if err != nil {
+		return err
+	}
+	emailChanged := current != nil && current.Email != user.Email
+	if emailChanged {
+		user.EmailVerified = false
+	} else if current != nil {
+		user.EmailVerified = current.EmailVerified
+	}
+	// The balance belongs to the payment service and arrives by SyncBalance
+	if current != nil {
+		user.Balance = current.Balance
 	}
-	return err
+
+	if err := u.repo.Update(user, userChanged(topology.UserUpdated, user)); err != nil {
+		return err
+	}
+	u.forget(user.ID)
+	// A new address has to be verified again
+	if emailChanged {
+		u.sendVerification(user)
+	}
+	return nil
 }
 
 func (u *userUsecase) DeleteUser(username string) error {
-	err := u.repo.Delete(username)
-	if err == nil && u.publisher != nil {
-		_ = u.publisher.Publish("user.deleted", map[string]string{"username": username})
-	}
-	return err
+	return u.repo.Delete(username, userMessage(topology.UserDeleted, events.UserDeletedVersion, func() (string, interface{}) {
+		return username, events.UserDeletedV1{Username: username}
+	}))
 }
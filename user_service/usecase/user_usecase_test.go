@@ -0,0 +1,283 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"muchway/pkg/limits"
+	"muchway/pkg/outbox"
+	"muchway/pkg/topology"
+	"muchway/user_service/domain"
+	"muchway/user_service/email"
+)
+
+func (r *fakeUsers) GetByEmail(email string) (*domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, u := range r.users {
+		if strings.EqualFold(u.Email, email) {
+			c := *u
+			return &c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeUsers) GetCredentials(userID int64) (*domain.Credentials, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	hash, ok := r.hashes[userID]
+	if !ok {
+		return nil, nil
+	}
+	return &domain.Credentials{UserID: userID, PasswordHash: hash}, nil
+}
+
+func (r *fakeUsers) UpdatePassword(userID int64, passwordHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hashes[userID] = passwordHash
+	return nil
+}
+
+// withPassword stores a hash of password for the user, made with cost.
+func (r *fakeUsers) withPassword(t *testing.T, userID int64, password string, cost int) {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), cost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.UpdatePassword(userID, string(hash))
+}
+
+var bob = &domain.User{ID: 2, Username: "bob", Email: "bob@example.com", Active: true}
+
+func newUsers(t *testing.T) (UserUsecase, *fakeUsers) {
+	t.Helper()
+	_, rdb := newTestRedis(t)
+	users := newFakeUsers(ann, bob)
+	return NewUserUsecase(users, nil, rdb, nil, UserConfig{EmailTokenSecret: []byte("test-secret")}), users
+}
+
+func TestChangePassword(t *testing.T) {
+	cases := []struct {
+		name    string
+		userID  int64
+		current string
+		next    string
+		want    error
+	}{
+		{"correct current password", bob.ID, "old password", "new password", nil},
+		{"wrong current password", bob.ID, "guess", "new password", domain.ErrInvalidCredentials},
+		{"weak new password", bob.ID, "old password", "short", domain.ErrWeakPassword},
+		{"user without a password", 99, "old password", "new password", domain.ErrInvalidCredentials},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			u, users := newUsers(t)
+			users.withPassword(t, bob.ID, "old password", bcrypt.MinCost)
+			before := users.hashes[bob.ID]
+
+			err := u.ChangePassword(c.userID, c.current, c.next)
+			if !errors.Is(err, c.want) {
+				t.Fatalf("ChangePassword = %v, want %v", err, c.want)
+			}
+			after := users.hashes[bob.ID]
+			if c.want != nil {
+				if after != before {
+					t.Error("a refused change replaced the password")
+				}
+				return
+			}
+			if bcrypt.CompareHashAndPassword([]byte(after), []byte(c.next)) != nil {
+				t.Error("the new password does not match the stored hash")
+			}
+			if cost, _ := bcrypt.Cost([]byte(after)); cost != passwordCost {
+				t.Errorf("new hash cost = %d, want %d", cost, passwordCost)
+			}
+		})
+	}
+}
+
+func TestLoginUpgradesWeakHashes(t *testing.T) {
+	u, users := newUsers(t)
+	users.withPassword(t, bob.ID, "old password", bcrypt.MinCost)
+
+	if _, err := u.Login(bob.Email, "wrong password", ""); !errors.Is(err, domain.ErrInvalidCredentials) {
+		t.Fatalf("Login with a wrong password = %v, want ErrInvalidCredentials", err)
+	}
+	if cost, _ := bcrypt.Cost([]byte(users.hashes[bob.ID])); cost != bcrypt.MinCost {
+		t.Errorf("a failed login changed the hash cost to %d", cost)
+	}
+
+	if _, err := u.Login(bob.Email, "old password", ""); err != nil {
+		t.Fatalf("Login = %v", err)
+	}
+	hash := users.hashes[bob.ID]
+	if cost, _ := bcrypt.Cost([]byte(hash)); cost != passwordCost {
+		t.Errorf("hash cost after login = %d, want %d", cost, passwordCost)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte("old password")) != nil {
+		t.Error("the upgraded hash does not match the password")
+	}
+}
+
+// fakeMail records the password reset and account locked emails sent.
+// Other emails are left to the embedded nil interface.
+type fakeMail struct {
+	email.EmailService
+	resets      []string
+	unlockLinks []string
+}
+
+func (m *fakeMail) SendPasswordReset(to, username, link string) error {
+	m.resets = append(m.resets, to)
+	return nil
+}
+
+func (m *fakeMail) SendAccountLocked(to, username, link string) error {
+	m.unlockLinks = append(m.unlockLinks, link)
+	return nil
+}
+
+func TestRequestPasswordResetIsThrottled(t *testing.T) {
+	limit := LockoutConfig{FreeAttempts: 2, BaseDelay: time.Minute, MaxDelay: time.Hour, Window: time.Hour,
+		AccountLockAt: 10, LockDuration: time.Hour, IPLockAt: 10}
+	cases := []struct {
+		name    string
+		request func(i int) (address, ip string)
+	}{
+		{"one address from one client", func(int) (string, string) { return bob.Email, "10.0.0.1" }},
+		{"one address from many clients", func(i int) (string, string) { return bob.Email, fmt.Sprint("10.0.0.", i) }},
+		{"one address in different case", func(i int) (string, string) {
+			return []string{"bob@example.com", "BOB@example.com", " Bob@Example.com"}[i%3], fmt.Sprint("10.0.0.", i)
+		}},
+		{"unknown address", func(int) (string, string) { return "nobody@example.com", "10.0.0.1" }},
+		{"many addresses from one client", func(i int) (string, string) { return fmt.Sprint("user", i, "@example.com"), "10.0.0.1" }},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, rdb := newTestRedis(t)
+			mail := &fakeMail{}
+			u := NewUserUsecase(newFakeUsers(bob), nil, rdb, mail, UserConfig{EmailTokenSecret: []byte("test-secret"), PasswordResetLimit: limit})
+
+			// The free requests and the first one over them go through
+			for i := 0; i <= limit.FreeAttempts; i++ {
+				address, ip := c.request(i)
+				if err := u.RequestPasswordReset(address, ip); err != nil {
+					t.Fatalf("request %d = %v", i+1, err)
+				}
+			}
+			address, ip := c.request(limit.FreeAttempts + 1)
+			var throttled *domain.ThrottledError
+			if err := u.RequestPasswordReset(address, ip); !errors.As(err, &throttled) {
+				t.Errorf("request %d = %v, want ThrottledError", limit.FreeAttempts+2, err)
+			}
+			for _, to := range mail.resets {
+				if to != bob.Email {
+					t.Errorf("reset email sent to %s", to)
+				}
+			}
+		})
+	}
+}
+
+func TestPasswordResetRequestsDoNotThrottleLogin(t *testing.T) {
+	u, users := newUsers(t)
+	users.withPassword(t, bob.ID, "old password", bcrypt.MinCost)
+	for i := 0; i < 10; i++ {
+		u.RequestPasswordReset(bob.Email, "10.0.0.1")
+	}
+	if _, err := u.Login(bob.Email, "old password", "10.0.0.1"); err != nil {
+		t.Errorf("Login after reset requests = %v", err)
+	}
+}
+
+func (r *fakeUsers) SetExclusion(userID int64, e limits.Exclusion, msgs ...outbox.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users[userID].Exclusion = &e
+	r.outbox = append(r.outbox, msgs...)
+	return nil
+}
+
+func TestExclude(t *testing.T) {
+	cases := []struct {
+		name        string
+		current     limits.ExclusionPeriod
+		next        limits.ExclusionPeriod
+		wantPeriod  limits.ExclusionPeriod
+		wantUpdated bool
+	}{
+		{"first exclusion", "", limits.ExcludeWeek, limits.ExcludeWeek, true},
+		{"longer exclusion", limits.ExcludeDay, limits.ExcludeSixMonths, limits.ExcludeSixMonths, true},
+		{"shorter exclusion", limits.ExcludeSixMonths, limits.ExcludeDay, limits.ExcludeSixMonths, false},
+		{"permanent exclusion", limits.ExcludeWeek, limits.ExcludePermanently, limits.ExcludePermanently, true},
+		{"after a permanent exclusion", limits.ExcludePermanently, limits.ExcludeSixMonths, limits.ExcludePermanently, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			u, users := newUsers(t)
+			start := time.Now().Add(-time.Hour)
+			if c.current != "" {
+				e, _ := limits.Exclude(c.current, start)
+				users.SetExclusion(bob.ID, e)
+			}
+
+			got, err := u.Exclude(bob.ID, c.next)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// A new or extended exclusion runs from now
+			from := start
+			if c.wantUpdated {
+				from = time.Now()
+			}
+			want, _ := limits.Exclude(c.wantPeriod, from)
+			if got.Permanent() != want.Permanent() || (!got.Permanent() && got.Until.Sub(*want.Until).Abs() > time.Minute) {
+				t.Errorf("Exclude = until %v, want the %s exclusion", got.Until, c.wantPeriod)
+			}
+			if c.current != "" && !got.Since.Equal(start) {
+				t.Errorf("exclusion starts at %s, want the original start %s", got.Since, start)
+			}
+			if updated := len(users.outbox) == 1; updated != c.wantUpdated {
+				t.Errorf("published %d user.excluded event(s), want updated %v", len(users.outbox), c.wantUpdated)
+			} else if updated && users.outbox[0].RoutingKey != topology.UserExcluded {
+				t.Errorf("published %s, want %s", users.outbox[0].RoutingKey, topology.UserExcluded)
+			}
+		})
+	}
+}
+
+func TestExcludeRefusals(t *testing.T) {
+	u, _ := newUsers(t)
+	if _, err := u.Exclude(bob.ID, "1y"); !errors.Is(err, limits.ErrInvalidExclusion) {
+		t.Errorf("Exclude for an unknown period = %v, want ErrInvalidExclusion", err)
+	}
+	if _, err := u.Exclude(99, limits.ExcludeDay); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("Exclude for an unknown user = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestExcludedUsersCannotLogIn(t *testing.T) {
+	u, users := newUsers(t)
+	users.withPassword(t, bob.ID, "old password", bcrypt.MinCost)
+	if _, err := u.Exclude(bob.ID, limits.ExcludeDay); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := u.Login(bob.Email, "old password", ""); !errors.Is(err, limits.ErrSelfExcluded) {
+		t.Errorf("Login while excluded = %v, want ErrSelfExcluded", err)
+	}
+
+	// An exclusion that has ended no longer counts
+	e, _ := limits.Exclude(limits.ExcludeDay, time.Now().Add(-25*time.Hour))
+	users.SetExclusion(bob.ID, e)
+	if _, err := u.Login(bob.Email, "old password", ""); err != nil {
+		t.Errorf("Login after the exclusion = %v", err)
+	}
+}